@@ -0,0 +1,124 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AccessTracker wraps a Backend and records when each item was last read
+// through GetItem or GetNotes, so stale secrets can be found for cleanup.
+// Access times are kept in memory and, if a path is given, persisted to a
+// small JSON sidecar file.
+//
+// Optional interfaces of the wrapped backend (ItemCreator, ItemSetter, ...)
+// are not exposed by the wrapper; type-assert the wrapped backend instead.
+type AccessTracker struct {
+	Backend
+
+	path     string
+	mu       sync.Mutex
+	accessed map[string]time.Time
+}
+
+// NewAccessTracker wraps b, loading previously recorded access times from
+// path if it exists. An empty path keeps access times in memory only.
+func NewAccessTracker(b Backend, path string) (*AccessTracker, error) {
+	t := &AccessTracker{
+		Backend:  b,
+		path:     path,
+		accessed: make(map[string]time.Time),
+	}
+	if path == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read access times: %w", err)
+	}
+	if err := json.Unmarshal(data, &t.accessed); err != nil {
+		return nil, fmt.Errorf("parse access times: %w", err)
+	}
+	return t, nil
+}
+
+// GetItem retrieves an item and records the access.
+func (t *AccessTracker) GetItem(ctx context.Context, name string, session Session) (*Item, error) {
+	item, err := t.Backend.GetItem(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+	return item, t.record(name)
+}
+
+// GetNotes retrieves an item's notes and records the access.
+func (t *AccessTracker) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	notes, err := t.Backend.GetNotes(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	return notes, t.record(name)
+}
+
+// LastAccessed returns when name was last read through the tracker.
+func (t *AccessTracker) LastAccessed(name string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	at, ok := t.accessed[name]
+	return at, ok
+}
+
+// StaleItems returns the sorted names of existing items that have not been
+// read within olderThan, including items that were never read.
+func (t *AccessTracker) StaleItems(ctx context.Context, olderThan time.Duration, session Session) ([]string, error) {
+	items, err := t.Backend.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var stale []string
+	for _, item := range items {
+		if at, ok := t.accessed[item.Name]; !ok || at.Before(cutoff) {
+			stale = append(stale, item.Name)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// record stores the access time for name and persists the sidecar file.
+func (t *AccessTracker) record(name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accessed[name] = time.Now()
+	if t.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(t.accessed)
+	if err != nil {
+		return fmt.Errorf("marshal access times: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return fmt.Errorf("create access times directory: %w", err)
+	}
+	if err := os.WriteFile(t.path, data, 0600); err != nil {
+		return fmt.Errorf("write access times: %w", err)
+	}
+	return nil
+}
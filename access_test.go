@@ -0,0 +1,69 @@
+package vaultmux_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestAccessTracker_StaleItems(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "a")
+	backend.SetItem("db-password", "b")
+	backend.SetItem("ssh-key", "c")
+	session, _ := backend.Authenticate(ctx)
+
+	path := filepath.Join(t.TempDir(), "access.json")
+	tracker, err := vaultmux.NewAccessTracker(backend, path)
+	if err != nil {
+		t.Fatalf("NewAccessTracker() error = %v", err)
+	}
+
+	if _, err := tracker.GetNotes(ctx, "db-password", session); err != nil {
+		t.Fatalf("GetNotes() error = %v", err)
+	}
+
+	if _, ok := tracker.LastAccessed("db-password"); !ok {
+		t.Error("LastAccessed(db-password) not recorded")
+	}
+	if _, ok := tracker.LastAccessed("api-key"); ok {
+		t.Error("LastAccessed(api-key) recorded without a read")
+	}
+
+	stale, err := tracker.StaleItems(ctx, time.Hour, session)
+	if err != nil {
+		t.Fatalf("StaleItems() error = %v", err)
+	}
+	if want := []string{"api-key", "ssh-key"}; !reflect.DeepEqual(stale, want) {
+		t.Errorf("StaleItems() = %v, want %v", stale, want)
+	}
+
+	// Access times survive a restart via the sidecar file
+	reloaded, err := vaultmux.NewAccessTracker(backend, path)
+	if err != nil {
+		t.Fatalf("NewAccessTracker() reload error = %v", err)
+	}
+	if _, ok := reloaded.LastAccessed("db-password"); !ok {
+		t.Error("LastAccessed(db-password) lost after reload")
+	}
+}
+
+func TestAccessTracker_FailedReadNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	tracker, _ := vaultmux.NewAccessTracker(backend, "")
+	if _, err := tracker.GetItem(ctx, "missing", session); err == nil {
+		t.Fatal("GetItem() on missing item should fail")
+	}
+	if _, ok := tracker.LastAccessed("missing"); ok {
+		t.Error("failed read should not be recorded")
+	}
+}
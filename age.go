@@ -0,0 +1,65 @@
+package vaultmux
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// SecretAgeHistogram counts items by age, for secret hygiene dashboards.
+// An item's age is the time since it was last modified, or since it was
+// created if the backend does not report modification times.
+//
+// buckets are lower bounds: each item is counted under the largest bucket
+// its age reaches, so with buckets 0, 30d and 90d an item modified 45 days
+// ago is counted under 30d. Items younger than every bucket are counted
+// under 0. Every bucket appears in the result, even with a count of zero.
+//
+// Ages come from ListItems, so no secret values are read on backends whose
+// listings are metadata-only (AWS, GCP, Azure). Items without any timestamp
+// are skipped, and a warning is logged to the default slog logger.
+func SecretAgeHistogram(ctx context.Context, b Backend, session Session, buckets []time.Duration) (map[time.Duration]int, error) {
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := slices.Clone(buckets)
+	slices.Sort(bounds)
+
+	histogram := make(map[time.Duration]int, len(bounds))
+	for _, bound := range bounds {
+		histogram[bound] = 0
+	}
+
+	now := time.Now()
+	unknown := 0
+	for _, item := range items {
+		changed := item.Modified
+		if changed.IsZero() {
+			changed = item.Created
+		}
+		if changed.IsZero() {
+			unknown++
+			continue
+		}
+
+		age := now.Sub(changed)
+		bucket := time.Duration(0)
+		for _, bound := range bounds {
+			if age < bound {
+				break
+			}
+			bucket = bound
+		}
+		histogram[bucket]++
+	}
+
+	if unknown > 0 {
+		slog.Default().WarnContext(ctx, "backend does not report item times; leaving items out of age histogram",
+			"backend", b.Name(), "items", unknown)
+	}
+
+	return histogram, nil
+}
@@ -0,0 +1,73 @@
+package vaultmux_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestSecretAgeHistogram(t *testing.T) {
+	ctx := context.Background()
+	const day = 24 * time.Hour
+
+	// Stagger item times relative to now, well clear of bucket bounds
+	clock := mock.NewClock(time.Now().Add(-100 * day))
+	backend := mock.New()
+	backend.Clock = clock
+	backend.SetItem("legacy-token", "a")
+	clock.Advance(55 * day)
+	backend.SetItem("db-password", "b")
+	backend.SetItem("api-key", "c")
+	clock.Advance(40 * day)
+	backend.SetItem("deploy-key", "d")
+	clock.Advance(5*day - time.Hour)
+	backend.SetItem("session-secret", "e")
+	session, _ := backend.Authenticate(ctx)
+
+	histogram, err := vaultmux.SecretAgeHistogram(ctx, backend, session, []time.Duration{90 * day, day, 30 * day})
+	if err != nil {
+		t.Fatalf("SecretAgeHistogram() error = %v", err)
+	}
+
+	want := map[time.Duration]int{
+		0:        1, // session-secret, 1h
+		day:      1, // deploy-key, 5d
+		30 * day: 2, // db-password and api-key, 45d
+		90 * day: 1, // legacy-token, 100d
+	}
+	if !reflect.DeepEqual(histogram, want) {
+		t.Errorf("SecretAgeHistogram() = %v, want %v", histogram, want)
+	}
+}
+
+func TestSecretAgeHistogram_SkipsUnknownTimes(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "a")
+	session, _ := backend.Authenticate(ctx)
+
+	histogram, err := vaultmux.SecretAgeHistogram(ctx, noTimesBackend{backend}, session, []time.Duration{0})
+	if err != nil {
+		t.Fatalf("SecretAgeHistogram() error = %v", err)
+	}
+	if histogram[0] != 0 {
+		t.Errorf("SecretAgeHistogram() counted %d items without times, want 0", histogram[0])
+	}
+}
+
+// noTimesBackend lists items without created or modified times.
+type noTimesBackend struct {
+	*mock.Backend
+}
+
+func (b noTimesBackend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	items, err := b.Backend.ListItems(ctx, session)
+	for _, item := range items {
+		item.Created, item.Modified = time.Time{}, time.Time{}
+	}
+	return items, err
+}
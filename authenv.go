@@ -0,0 +1,41 @@
+package vaultmux
+
+import (
+	"context"
+	"os"
+)
+
+// AuthEnvReporter is implemented by backends whose provider SDK or CLI reads
+// credentials and auth settings from environment variables.
+type AuthEnvReporter interface {
+	// AuthEnvVars returns the names of the environment variables consulted
+	// during authentication, such as AWS_ACCESS_KEY_ID or BW_SESSION.
+	AuthEnvVars() []string
+}
+
+// AuthDiagnosis reports which of a backend's auth environment variables are
+// set. It holds variable names only, never their values.
+type AuthDiagnosis struct {
+	Backend string   `json:"backend"` // Backend name
+	Set     []string `json:"set"`     // Variables set to a non-empty value
+	Unset   []string `json:"unset"`   // Variables unset or empty
+}
+
+// DiagnoseAuth checks which of b's auth environment variables are set, to
+// help explain why authentication failed. Backends that don't implement
+// AuthEnvReporter, such as Windows Credential Manager, report none.
+func DiagnoseAuth(ctx context.Context, b Backend) AuthDiagnosis {
+	d := AuthDiagnosis{Backend: b.Name()}
+	r, ok := b.(AuthEnvReporter)
+	if !ok {
+		return d
+	}
+	for _, name := range r.AuthEnvVars() {
+		if os.Getenv(name) != "" {
+			d.Set = append(d.Set, name)
+		} else {
+			d.Unset = append(d.Unset, name)
+		}
+	}
+	return d
+}
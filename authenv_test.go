@@ -0,0 +1,16 @@
+package vaultmux_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestDiagnoseAuth_NotSupported(t *testing.T) {
+	d := vaultmux.DiagnoseAuth(context.Background(), mock.New())
+	if d.Backend != "mock" || len(d.Set) != 0 || len(d.Unset) != 0 {
+		t.Errorf("DiagnoseAuth() = %+v, want an empty diagnosis for mock", d)
+	}
+}
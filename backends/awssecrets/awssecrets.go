@@ -8,12 +8,20 @@
 package awssecrets
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
@@ -21,16 +29,41 @@ import (
 	"github.com/blackwell-systems/vaultmux"
 )
 
+// secretsManagerAPI is the subset of the Secrets Manager client used by Backend.
+// It is satisfied by *secretsmanager.Client and by fakes in tests.
+type secretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+	ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error)
+	CreateSecret(ctx context.Context, params *secretsmanager.CreateSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error)
+	PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error)
+	DeleteSecret(ctx context.Context, params *secretsmanager.DeleteSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DeleteSecretOutput, error)
+	GetResourcePolicy(ctx context.Context, params *secretsmanager.GetResourcePolicyInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetResourcePolicyOutput, error)
+	ReplicateSecretToRegions(ctx context.Context, params *secretsmanager.ReplicateSecretToRegionsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ReplicateSecretToRegionsOutput, error)
+	RemoveRegionsFromReplication(ctx context.Context, params *secretsmanager.RemoveRegionsFromReplicationInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.RemoveRegionsFromReplicationOutput, error)
+}
+
 // Backend implements vaultmux.Backend for AWS Secrets Manager.
 type Backend struct {
 	// AWS Secrets Manager client
-	client *secretsmanager.Client
+	client secretsManagerAPI
 
 	// Configuration
-	region   string // AWS region (e.g., us-east-1, us-west-2)
+	region   string // AWS region (e.g., us-east-1, us-west-2); empty until resolved by Init if not set
 	prefix   string // Secret name prefix for namespacing (e.g., "myapp/")
 	endpoint string // Custom endpoint URL for LocalStack testing
 
+	skipConnectivityCheck bool          // Init skips the ListSecrets probe
+	skipNoopUpdates       bool          // UpdateItem skips writes of an unchanged value
+	initTimeout           time.Duration // Bounds the probe (0 uses vaultmux.DefaultInitTimeout)
+	proxyURL              *url.URL      // Proxy for all AWS requests (nil uses the environment)
+
+	// Receives the region fallback warning (optional, defaults to slog.Default())
+	logger *slog.Logger
+
+	// Result limit for ListItems (optional, set from Config.MaxListResults)
+	maxListResults int
+
 	// AWS config (credentials, region)
 	awsConfig aws.Config
 
@@ -41,9 +74,16 @@ type Backend struct {
 // New creates a new AWS Secrets Manager backend.
 //
 // Supported options:
-//   - region: AWS region (default: us-east-1)
+//   - region: AWS region (default: resolved by the SDK from AWS_REGION or
+//     shared config, falling back to us-east-1 with a warning)
 //   - prefix: Secret name prefix for namespacing (default: "vaultmux/")
 //   - endpoint: Custom endpoint URL (for LocalStack testing)
+//   - skip_connectivity_check: "true" to skip Init's ListSecrets probe, for
+//     credentials that may only read specific secrets (default: false)
+//   - skip_noop_updates: "true" to make UpdateItem skip the write, and the
+//     new version it creates, when the value is unchanged (default: false)
+//   - proxy_url: HTTP(S) or SOCKS5 proxy for all requests, e.g.
+//     "socks5://127.0.0.1:1080" (default: HTTPS_PROXY from the environment)
 //
 // Example:
 //
@@ -53,9 +93,6 @@ type Backend struct {
 //	}, "")
 func New(options map[string]string, sessionFile string) (*Backend, error) {
 	region := options["region"]
-	if region == "" {
-		region = "us-east-1"
-	}
 
 	prefix := options["prefix"]
 	if prefix == "" {
@@ -64,11 +101,38 @@ func New(options map[string]string, sessionFile string) (*Backend, error) {
 
 	endpoint := options["endpoint"]
 
+	skipCheck := false
+	if v := options["skip_connectivity_check"]; v != "" {
+		var err error
+		if skipCheck, err = strconv.ParseBool(v); err != nil {
+			return nil, &vaultmux.ConfigError{Field: "skip_connectivity_check", Reason: fmt.Sprintf("%q: must be true or false", v)}
+		}
+	}
+
+	skipNoopUpdates := false
+	if v := options["skip_noop_updates"]; v != "" {
+		var err error
+		if skipNoopUpdates, err = strconv.ParseBool(v); err != nil {
+			return nil, &vaultmux.ConfigError{Field: "skip_noop_updates", Reason: fmt.Sprintf("%q: must be true or false", v)}
+		}
+	}
+
+	var proxyURL *url.URL
+	if v := options["proxy_url"]; v != "" {
+		var err error
+		if proxyURL, err = vaultmux.ParseProxyURL(v); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Backend{
-		region:      region,
-		prefix:      prefix,
-		endpoint:    endpoint,
-		sessionFile: sessionFile,
+		region:                region,
+		prefix:                prefix,
+		endpoint:              endpoint,
+		skipConnectivityCheck: skipCheck,
+		skipNoopUpdates:       skipNoopUpdates,
+		proxyURL:              proxyURL,
+		sessionFile:           sessionFile,
 	}, nil
 }
 
@@ -77,6 +141,32 @@ func (b *Backend) Name() string {
 	return "awssecrets"
 }
 
+// AuthEnvVars returns the environment variables the AWS SDK's default
+// credential chain and region lookup read.
+func (b *Backend) AuthEnvVars() []string {
+	return []string{
+		"AWS_ACCESS_KEY_ID",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_SESSION_TOKEN",
+		"AWS_PROFILE",
+		"AWS_REGION",
+		"AWS_DEFAULT_REGION",
+		"AWS_CONFIG_FILE",
+		"AWS_SHARED_CREDENTIALS_FILE",
+		"AWS_ROLE_ARN",
+		"AWS_WEB_IDENTITY_TOKEN_FILE",
+	}
+}
+
+// Limits reports Secrets Manager's secret size and name limits.
+func (b *Backend) Limits() vaultmux.Limits {
+	return vaultmux.Limits{
+		MaxSecretBytes:     65536,
+		MaxNameLength:      512,
+		AllowedNameCharset: "A-Za-z0-9/_+=.@-",
+	}
+}
+
 // Init initializes the AWS Secrets Manager client and verifies connectivity.
 func (b *Backend) Init(ctx context.Context) error {
 	// Load AWS configuration (credentials, region)
@@ -85,16 +175,25 @@ func (b *Backend) Init(ctx context.Context) error {
 			fmt.Errorf("failed to load AWS config: %w", err))
 	}
 
-	// Create Secrets Manager client
-	b.client = secretsmanager.NewFromConfig(b.awsConfig, func(o *secretsmanager.Options) {
-		if b.endpoint != "" {
-			o.BaseEndpoint = aws.String(b.endpoint)
-		}
-	})
+	// Create Secrets Manager client, unless one was injected (tests)
+	if b.client == nil {
+		b.client = secretsmanager.NewFromConfig(b.awsConfig, func(o *secretsmanager.Options) {
+			if b.endpoint != "" {
+				o.BaseEndpoint = aws.String(b.endpoint)
+			}
+		})
+	}
+
+	if b.skipConnectivityCheck {
+		return nil
+	}
 
 	// Verify connectivity with lightweight API call
-	_, err := b.client.ListSecrets(ctx, &secretsmanager.ListSecretsInput{
-		MaxResults: aws.Int32(1),
+	err := vaultmux.ProbeConnectivity(ctx, b.initTimeout, func(ctx context.Context) error {
+		_, err := b.client.ListSecrets(ctx, &secretsmanager.ListSecretsInput{
+			MaxResults: aws.Int32(1),
+		})
+		return err
 	})
 	if err != nil {
 		return vaultmux.WrapError(b.Name(), "init", "",
@@ -104,15 +203,39 @@ func (b *Backend) Init(ctx context.Context) error {
 	return nil
 }
 
+// defaultRegion is used when neither the region option nor the SDK's
+// environment and shared config supply a region.
+const defaultRegion = "us-east-1"
+
 // initAWSConfig loads AWS configuration from environment, shared config, or instance metadata.
+// An explicit region option takes precedence over the SDK-resolved region.
 func (b *Backend) initAWSConfig(ctx context.Context) error {
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithRegion(b.region),
-	)
+	var opts []func(*config.LoadOptions) error
+	if b.region != "" {
+		opts = append(opts, config.WithRegion(b.region))
+	}
+	if b.proxyURL != nil {
+		opts = append(opts, config.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+			tr.Proxy = http.ProxyURL(b.proxyURL)
+		})))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return err
 	}
 
+	if cfg.Region == "" {
+		logger := b.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.WarnContext(ctx, "no AWS region configured; set the region option or AWS_REGION",
+			"backend", b.Name(), "region", defaultRegion)
+		cfg.Region = defaultRegion
+	}
+
+	b.region = cfg.Region
 	b.awsConfig = cfg
 	return nil
 }
@@ -154,31 +277,102 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 }
 
 // GetItem retrieves a secret from AWS Secrets Manager.
+// The returned item's Fields["versionStages"] lists the stages (e.g.
+// "AWSCURRENT") attached to the version that was read, comma-separated.
+// Description lives on the secret metadata, so GetItem makes one
+// DescribeSecret call for it; GetNotes and ItemExists skip it.
 func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
+	item, err := b.GetItemStage(ctx, name, "", session)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := b.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(b.secretName(name)),
+	})
+	if err != nil {
+		return nil, b.handleAWSError(err, "get", name)
+	}
+	item.Description = aws.ToString(metadata.Description)
+	return item, nil
+}
+
+// GetItemStage retrieves the version of a secret carrying the given staging
+// label, such as "AWSPENDING" during rotation. An empty stage reads the
+// default "AWSCURRENT" version. Unlike GetItem it reads only the secret
+// value, so Description is not set.
+func (b *Backend) GetItemStage(ctx context.Context, name, stage string, session vaultmux.Session) (*vaultmux.Item, error) {
 	if !session.IsValid(ctx) {
 		return nil, vaultmux.ErrNotAuthenticated
 	}
 
 	secretName := b.secretName(name)
 
-	result, err := b.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+	input := &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretName),
-	})
+	}
+	if stage != "" {
+		input.VersionStage = aws.String(stage)
+	}
+
+	result, err := b.client.GetSecretValue(ctx, input)
 	if err != nil {
 		return nil, b.handleAWSError(err, "get", name)
 	}
 
-	return &vaultmux.Item{
-		ID:    aws.ToString(result.ARN),
-		Name:  name,
-		Type:  vaultmux.ItemTypeSecureNote,
-		Notes: aws.ToString(result.SecretString),
-	}, nil
+	item := &vaultmux.Item{
+		ID:      aws.ToString(result.ARN),
+		Name:    name,
+		Type:    vaultmux.ItemTypeSecureNote,
+		Notes:   aws.ToString(result.SecretString),
+		Version: aws.ToString(result.VersionId),
+		Fields: map[string]string{
+			"versionStages": strings.Join(result.VersionStages, ","),
+		},
+	}
+	return item, nil
+}
+
+// Describe returns the DescribeSecret output as a map, keyed by the API's
+// field names: "Description", "CreatedDate", "RotationEnabled", "LastRotatedDate",
+// "RotationRules", "Tags", "VersionIdsToStages" and so on. Timestamps are
+// RFC 3339 strings. Secret values are not included.
+func (b *Backend) Describe(ctx context.Context, name string, session vaultmux.Session) (map[string]any, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	result, err := b.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(b.secretName(name)),
+	})
+	if err != nil {
+		return nil, b.handleAWSError(err, "describe", name)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, vaultmux.WrapError(b.Name(), "describe", name, err)
+	}
+	var metadata map[string]any
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, vaultmux.WrapError(b.Name(), "describe", name, err)
+	}
+	delete(metadata, "ResultMetadata") // SDK middleware state, not secret metadata
+	return metadata, nil
 }
 
 // GetNotes retrieves only the notes field of a secret (convenience method).
 func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
-	item, err := b.GetItem(ctx, name, session)
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		if err != nil {
+			return "", err
+		}
+		return item.Notes, nil
+	}
+	item, err := b.GetItemStage(ctx, name, "", session)
 	if err != nil {
 		return "", err
 	}
@@ -187,7 +381,7 @@ func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Se
 
 // ItemExists checks if a secret exists without retrieving its value.
 func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.Session) (bool, error) {
-	_, err := b.GetItem(ctx, name, session)
+	_, err := b.GetItemStage(ctx, name, "", session)
 	if err != nil {
 		if errors.Is(err, vaultmux.ErrNotFound) {
 			return false, nil
@@ -200,10 +394,22 @@ func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.
 // ListItems returns all secrets matching the configured prefix.
 // Handles pagination automatically for large secret collections.
 func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	return b.ListItemsWithOptions(ctx, session)
+}
+
+// ListItemsWithOptions returns all secrets matching the configured prefix,
+// applying any list options. Listing stops once the result limit
+// (WithMaxResults, or MaxListResults from the Config) is reached; the items
+// so far are returned with an error wrapping vaultmux.ErrListTruncated if
+// more secrets match.
+func (b *Backend) ListItemsWithOptions(ctx context.Context, session vaultmux.Session, opts ...vaultmux.ListOption) ([]*vaultmux.Item, error) {
 	if !session.IsValid(ctx) {
 		return nil, vaultmux.ErrNotAuthenticated
 	}
 
+	o := vaultmux.NewListOptions(opts...)
+	limit := cmp.Or(o.MaxResults, b.maxListResults)
+
 	var items []*vaultmux.Item
 
 	// Paginate through all secrets
@@ -226,12 +432,21 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 			if b.prefix != "" && !strings.HasPrefix(secretName, b.prefix) {
 				continue
 			}
+			if limit > 0 && len(items) == limit {
+				return items, vaultmux.WrapError(b.Name(), "list", "", vaultmux.ErrListTruncated)
+			}
 
 			name := strings.TrimPrefix(secretName, b.prefix)
+			if o.FullNames {
+				name = secretName
+			}
 			items = append(items, &vaultmux.Item{
-				ID:   aws.ToString(secret.ARN),
-				Name: name,
-				Type: vaultmux.ItemTypeSecureNote,
+				ID:          aws.ToString(secret.ARN),
+				Name:        name,
+				Type:        vaultmux.ItemTypeSecureNote,
+				Description: aws.ToString(secret.Description),
+				Created:     aws.ToTime(secret.CreatedDate),
+				Modified:    aws.ToTime(secret.LastChangedDate),
 				// Notes field not populated - requires separate GetSecretValue call
 			})
 		}
@@ -245,8 +460,47 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 	return items, nil
 }
 
+// ListItemNames returns the names of all secrets matching the configured
+// prefix, with the prefix stripped, without building Item values.
+func (b *Backend) ListItemNames(ctx context.Context, session vaultmux.Session) ([]string, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	var names []string
+	input := &secretsmanager.ListSecretsInput{
+		MaxResults: aws.Int32(100),
+	}
+
+	for {
+		result, err := b.client.ListSecrets(ctx, input)
+		if err != nil {
+			return nil, b.handleAWSError(err, "list", "")
+		}
+
+		for _, secret := range result.SecretList {
+			if name, ok := strings.CutPrefix(aws.ToString(secret.Name), b.prefix); ok {
+				names = append(names, name)
+			}
+		}
+
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	return names, nil
+}
+
 // CreateItem creates a new secret in AWS Secrets Manager.
 func (b *Backend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	return b.CreateItemWithOptions(ctx, name, content, session)
+}
+
+// CreateItemWithOptions creates a new secret, applying any create options.
+// The description is stored in the secret's native Description field.
+func (b *Backend) CreateItemWithOptions(ctx context.Context, name, content string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
 	if !session.IsValid(ctx) {
 		return vaultmux.ErrNotAuthenticated
 	}
@@ -262,19 +516,33 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, session
 		return vaultmux.ErrAlreadyExists
 	}
 
-	_, err = b.client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	_, err = b.client.CreateSecret(ctx, b.createSecretInput(secretName, content, opts...))
+	if err != nil {
+		return b.handleAWSError(err, "create", name)
+	}
+
+	return nil
+}
+
+// createSecretInput builds the CreateSecret request for a new secret,
+// tagged as managed by vaultmux.
+func (b *Backend) createSecretInput(secretName, content string, opts ...vaultmux.CreateOption) *secretsmanager.CreateSecretInput {
+	input := &secretsmanager.CreateSecretInput{
 		Name:         aws.String(secretName),
 		SecretString: aws.String(content),
 		Tags: []types.Tag{
 			{Key: aws.String("vaultmux"), Value: aws.String("true")},
 			{Key: aws.String("prefix"), Value: aws.String(b.prefix)},
 		},
-	})
-	if err != nil {
-		return b.handleAWSError(err, "create", name)
 	}
-
-	return nil
+	if o := vaultmux.NewCreateOptions(opts...); o.Description != "" {
+		input.Description = aws.String(o.Description)
+	}
+	return input
 }
 
 // UpdateItem updates an existing secret in AWS Secrets Manager.
@@ -286,16 +554,30 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 
 	secretName := b.secretName(name)
 
-	// Check if exists
-	exists, err := b.ItemExists(ctx, name, session)
-	if err != nil {
-		return err
+	if b.skipNoopUpdates {
+		// The read that compares values also checks the secret exists
+		current, err := b.GetNotes(ctx, name, session)
+		if err != nil {
+			return err
+		}
+		if current == content {
+			return nil
+		}
+	} else {
+		exists, err := b.ItemExists(ctx, name, session)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return vaultmux.ErrNotFound
+		}
 	}
-	if !exists {
-		return vaultmux.ErrNotFound
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
 	}
 
-	_, err = b.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+	_, err := b.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
 		SecretId:     aws.String(secretName),
 		SecretString: aws.String(content),
 	})
@@ -306,6 +588,43 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 	return nil
 }
 
+// SetItem creates the secret if missing, or puts a new value if present.
+// The value is put first, so existing secrets take a single API call and
+// missing ones two. If another writer creates the secret in between, the
+// value is put again.
+func (b *Backend) SetItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		_, err := b.ItemExists(ctx, name, session)
+		return err
+	}
+
+	secretName := b.secretName(name)
+	err := b.putSecretValue(ctx, name, secretName, content)
+	if !errors.Is(err, vaultmux.ErrNotFound) {
+		return err
+	}
+
+	_, err = b.client.CreateSecret(ctx, b.createSecretInput(secretName, content))
+	err = b.handleAWSError(err, "set", name)
+	if errors.Is(err, vaultmux.ErrAlreadyExists) {
+		return b.putSecretValue(ctx, name, secretName, content)
+	}
+	return err
+}
+
+// putSecretValue puts content as the current value of secretName.
+func (b *Backend) putSecretValue(ctx context.Context, name, secretName, content string) error {
+	_, err := b.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretName),
+		SecretString: aws.String(content),
+	})
+	return b.handleAWSError(err, "set", name)
+}
+
 // DeleteItem deletes a secret from AWS Secrets Manager.
 // Uses ForceDeleteWithoutRecovery for immediate deletion (consistent with other backends).
 func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.Session) error {
@@ -324,6 +643,10 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 		return vaultmux.ErrNotFound
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	_, err = b.client.DeleteSecret(ctx, &secretsmanager.DeleteSecretInput{
 		SecretId:                   aws.String(secretName),
 		ForceDeleteWithoutRecovery: aws.Bool(true),
@@ -335,6 +658,32 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 	return nil
 }
 
+// GetItemRaw retrieves a secret by its full stored name, without adding
+// the prefix. It bypasses namespacing and can read any secret the
+// credentials allow; see vaultmux.RawItemAccessor.
+func (b *Backend) GetItemRaw(ctx context.Context, storedName string, session vaultmux.Session) (*vaultmux.Item, error) {
+	return b.unprefixed().GetItem(ctx, storedName, session)
+}
+
+// DeleteItemRaw deletes a secret by its full stored name, without adding
+// the prefix. It bypasses namespacing and can delete any secret the
+// credentials allow; see vaultmux.RawItemAccessor.
+func (b *Backend) DeleteItemRaw(ctx context.Context, storedName string, session vaultmux.Session) error {
+	return b.unprefixed().DeleteItem(ctx, storedName, session)
+}
+
+// unprefixed returns a copy of b, sharing its client, with no prefix.
+func (b *Backend) unprefixed() *Backend {
+	raw := *b
+	raw.prefix = ""
+	return &raw
+}
+
+// Prefix returns the secret name prefix used for namespacing.
+func (b *Backend) Prefix() string {
+	return b.prefix
+}
+
 // secretName returns the full secret name with prefix applied.
 func (b *Backend) secretName(name string) string {
 	if b.prefix != "" {
@@ -380,29 +729,44 @@ func (b *Backend) handleAWSError(err error, operation, itemName string) error {
 }
 
 // Location management stubs (AWS doesn't have native "folders" like 1Password vaults)
-// These operations are not supported and return ErrNotSupported.
+// These operations are not supported and return a NotSupportedError.
 // Could be implemented using tags in the future, but not currently supported.
 
 func (b *Backend) ListLocations(ctx context.Context, session vaultmux.Session) ([]string, error) {
-	return nil, vaultmux.ErrNotSupported
+	return nil, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "ListLocations"}
 }
 
 func (b *Backend) LocationExists(ctx context.Context, name string, session vaultmux.Session) (bool, error) {
-	return false, vaultmux.ErrNotSupported
+	return false, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "LocationExists"}
 }
 
 func (b *Backend) CreateLocation(ctx context.Context, name string, session vaultmux.Session) error {
-	return vaultmux.ErrNotSupported
+	return &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "CreateLocation"}
 }
 
 func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue string, session vaultmux.Session) ([]*vaultmux.Item, error) {
-	return nil, vaultmux.ErrNotSupported
+	return nil, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "ListItemsInLocation"}
+}
+
+// validateOptions checks options as New does. New does not load AWS
+// config or credentials; Init does.
+func validateOptions(options map[string]string) error {
+	_, err := New(options, "")
+	return err
 }
 
 // init registers the AWS Secrets Manager backend with vaultmux.
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendAWSSecretsManager,
 		func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-			return New(cfg.Options, cfg.SessionFile)
+			b, err := New(cfg.Options, cfg.SessionFile)
+			if err != nil {
+				return nil, err
+			}
+			b.logger = cfg.Logger
+			b.maxListResults = cfg.MaxListResults
+			b.initTimeout = cfg.InitTimeout
+			return b, nil
 		})
+	vaultmux.RegisterConfigValidator(vaultmux.BackendAWSSecretsManager, validateOptions)
 }
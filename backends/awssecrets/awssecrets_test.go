@@ -1,13 +1,215 @@
 package awssecrets
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 
 	"github.com/blackwell-systems/vaultmux"
 )
 
+// fakeClient is an in-memory secretsManagerAPI for unit tests.
+// The current value lives in the stored CreateSecretInput; values put with
+// other staging labels (e.g. AWSPENDING) are kept in staged.
+type fakeClient struct {
+	secrets      map[string]*secretsmanager.CreateSecretInput
+	staged       map[string]map[string]*string // name -> stage -> value
+	versions     map[string]int                // name -> current version number
+	createInputs []*secretsmanager.CreateSecretInput
+	listErr      error                // returned by ListSecrets if set
+	policies     map[string]string    // name -> resource policy JSON
+	rotated      map[string]time.Time // name -> last rotation; rotation is enabled if set
+	replicas     map[string][]string  // name -> replica regions
+	describes    int                  // DescribeSecret calls
+	gets         int                  // GetSecretValue calls
+	beforeCreate func(name string)    // Called by CreateSecret, to simulate a concurrent writer
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		secrets:  make(map[string]*secretsmanager.CreateSecretInput),
+		staged:   make(map[string]map[string]*string),
+		versions: make(map[string]int),
+		policies: make(map[string]string),
+		rotated:  make(map[string]time.Time),
+		replicas: make(map[string][]string),
+	}
+}
+
+func (f *fakeClient) GetSecretValue(ctx context.Context, in *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	f.gets++
+	name := aws.ToString(in.SecretId)
+	s, ok := f.secrets[name]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+
+	stage := aws.ToString(in.VersionStage)
+	if stage == "" || stage == "AWSCURRENT" {
+		return &secretsmanager.GetSecretValueOutput{
+			ARN:           aws.String("arn:" + aws.ToString(s.Name)),
+			Name:          s.Name,
+			SecretString:  s.SecretString,
+			VersionId:     aws.String(fmt.Sprintf("v%d", f.versions[name])),
+			VersionStages: []string{"AWSCURRENT"},
+		}, nil
+	}
+
+	value, ok := f.staged[name][stage]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	return &secretsmanager.GetSecretValueOutput{
+		ARN:           aws.String("arn:" + aws.ToString(s.Name)),
+		Name:          s.Name,
+		SecretString:  value,
+		VersionStages: []string{stage},
+	}, nil
+}
+
+func (f *fakeClient) DescribeSecret(ctx context.Context, in *secretsmanager.DescribeSecretInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+	f.describes++
+	s, ok := f.secrets[aws.ToString(in.SecretId)]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	out := &secretsmanager.DescribeSecretOutput{
+		ARN:         aws.String("arn:" + aws.ToString(s.Name)),
+		Name:        s.Name,
+		Description: s.Description,
+	}
+	if rotated, ok := f.rotated[aws.ToString(s.Name)]; ok {
+		out.RotationEnabled = aws.Bool(true)
+		out.LastRotatedDate = aws.Time(rotated)
+	}
+	for _, region := range f.replicas[aws.ToString(s.Name)] {
+		out.ReplicationStatus = append(out.ReplicationStatus, types.ReplicationStatusType{
+			Region: aws.String(region),
+			Status: types.StatusTypeInSync,
+		})
+	}
+	return out, nil
+}
+
+func (f *fakeClient) ListSecrets(ctx context.Context, in *secretsmanager.ListSecretsInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	out := &secretsmanager.ListSecretsOutput{}
+	for _, s := range f.secrets {
+		out.SecretList = append(out.SecretList, types.SecretListEntry{
+			ARN:         aws.String("arn:" + aws.ToString(s.Name)),
+			Name:        s.Name,
+			Description: s.Description,
+		})
+	}
+	return out, nil
+}
+
+func (f *fakeClient) CreateSecret(ctx context.Context, in *secretsmanager.CreateSecretInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error) {
+	name := aws.ToString(in.Name)
+	if f.beforeCreate != nil {
+		f.beforeCreate(name)
+	}
+	if _, ok := f.secrets[name]; ok {
+		return nil, &types.ResourceExistsException{}
+	}
+	f.createInputs = append(f.createInputs, in)
+	f.secrets[name] = in
+	f.versions[name] = 1
+	return &secretsmanager.CreateSecretOutput{Name: in.Name}, nil
+}
+
+func (f *fakeClient) PutSecretValue(ctx context.Context, in *secretsmanager.PutSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error) {
+	name := aws.ToString(in.SecretId)
+	s, ok := f.secrets[name]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+
+	if len(in.VersionStages) == 0 {
+		s.SecretString = in.SecretString
+		f.versions[name]++
+	}
+	for _, stage := range in.VersionStages {
+		if stage == "AWSCURRENT" {
+			s.SecretString = in.SecretString
+			f.versions[name]++
+			continue
+		}
+		if f.staged[name] == nil {
+			f.staged[name] = make(map[string]*string)
+		}
+		f.staged[name][stage] = in.SecretString
+	}
+	return &secretsmanager.PutSecretValueOutput{Name: s.Name}, nil
+}
+
+func (f *fakeClient) DeleteSecret(ctx context.Context, in *secretsmanager.DeleteSecretInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.DeleteSecretOutput, error) {
+	name := aws.ToString(in.SecretId)
+	if _, ok := f.secrets[name]; !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	delete(f.secrets, name)
+	return &secretsmanager.DeleteSecretOutput{Name: aws.String(name)}, nil
+}
+
+func (f *fakeClient) GetResourcePolicy(ctx context.Context, in *secretsmanager.GetResourcePolicyInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetResourcePolicyOutput, error) {
+	name := aws.ToString(in.SecretId)
+	if _, ok := f.secrets[name]; !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	out := &secretsmanager.GetResourcePolicyOutput{Name: aws.String(name)}
+	if policy, ok := f.policies[name]; ok {
+		out.ResourcePolicy = aws.String(policy)
+	}
+	return out, nil
+}
+
+func (f *fakeClient) ReplicateSecretToRegions(ctx context.Context, in *secretsmanager.ReplicateSecretToRegionsInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.ReplicateSecretToRegionsOutput, error) {
+	name := aws.ToString(in.SecretId)
+	if _, ok := f.secrets[name]; !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	for _, r := range in.AddReplicaRegions {
+		f.replicas[name] = append(f.replicas[name], aws.ToString(r.Region))
+	}
+	return &secretsmanager.ReplicateSecretToRegionsOutput{ARN: aws.String("arn:" + name)}, nil
+}
+
+func (f *fakeClient) RemoveRegionsFromReplication(ctx context.Context, in *secretsmanager.RemoveRegionsFromReplicationInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.RemoveRegionsFromReplicationOutput, error) {
+	name := aws.ToString(in.SecretId)
+	if _, ok := f.secrets[name]; !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	f.replicas[name] = slices.DeleteFunc(f.replicas[name], func(region string) bool {
+		return slices.Contains(in.RemoveReplicaRegions, region)
+	})
+	return &secretsmanager.RemoveRegionsFromReplicationOutput{ARN: aws.String("arn:" + name)}, nil
+}
+
+// validSession is a session that is always valid, for use with fakeClient.
+type validSession struct{}
+
+func (validSession) Token() string                     { return "" }
+func (validSession) IsValid(ctx context.Context) bool  { return true }
+func (validSession) Refresh(ctx context.Context) error { return nil }
+func (validSession) ExpiresAt() time.Time              { return time.Time{} }
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -18,7 +220,6 @@ func TestNew(t *testing.T) {
 			name:    "defaults",
 			options: map[string]string{},
 			want: &Backend{
-				region: "us-east-1",
 				prefix: "vaultmux/",
 			},
 		},
@@ -39,7 +240,6 @@ func TestNew(t *testing.T) {
 				"endpoint": "http://localhost:4566",
 			},
 			want: &Backend{
-				region:   "us-east-1",
 				prefix:   "vaultmux/",
 				endpoint: "http://localhost:4566",
 			},
@@ -66,6 +266,152 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// isolateAWSConfig points the SDK at empty shared config so only the
+// environment set by the test influences region resolution.
+func isolateAWSConfig(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", dir+"/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", dir+"/credentials")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+func TestNew_ConfigOptions(t *testing.T) {
+	cfg := vaultmux.Config{
+		Backend: vaultmux.BackendAWSSecretsManager,
+		Options: map[string]string{"region": "eu-west-1", "prefix": "old/"},
+	}
+	b, err := vaultmux.New(cfg, vaultmux.WithRegion("us-west-2"), vaultmux.WithPrefix("app/"))
+	if err != nil {
+		t.Fatalf("vaultmux.New() error = %v", err)
+	}
+
+	backend := b.(*Backend)
+	if backend.region != "us-west-2" {
+		t.Errorf("region = %q, want us-west-2", backend.region)
+	}
+	if backend.prefix != "app/" {
+		t.Errorf("prefix = %q, want app/", backend.prefix)
+	}
+	if cfg.Options["region"] != "eu-west-1" {
+		t.Errorf("Config.Options modified: region = %q", cfg.Options["region"])
+	}
+}
+
+func TestBackend_InitAWSConfig_ProxyURL(t *testing.T) {
+	ctx := context.Background()
+	isolateAWSConfig(t)
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:8080")
+
+	backend, err := New(map[string]string{"proxy_url": "socks5://127.0.0.1:1080"}, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := backend.initAWSConfig(ctx); err != nil {
+		t.Fatalf("initAWSConfig() error = %v", err)
+	}
+
+	client, ok := backend.awsConfig.HTTPClient.(*awshttp.BuildableClient)
+	if !ok {
+		t.Fatalf("HTTPClient = %T, want *awshttp.BuildableClient", backend.awsConfig.HTTPClient)
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://secretsmanager.eu-west-1.amazonaws.com/", nil)
+	got, err := client.GetTransport().Proxy(req)
+	if err != nil || got == nil || got.String() != "socks5://127.0.0.1:1080" {
+		t.Errorf("Proxy() = %v, %v; want socks5://127.0.0.1:1080", got, err)
+	}
+
+	if _, err := New(map[string]string{"proxy_url": "ftp://proxy"}, ""); err == nil {
+		t.Error("New() accepted proxy_url with unsupported scheme")
+	}
+}
+
+func TestBackend_InitAWSConfig_Region(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resolved from environment", func(t *testing.T) {
+		isolateAWSConfig(t)
+		t.Setenv("AWS_REGION", "eu-west-1")
+
+		backend, _ := New(map[string]string{}, "")
+		if err := backend.initAWSConfig(ctx); err != nil {
+			t.Fatalf("initAWSConfig() error = %v", err)
+		}
+		if backend.awsConfig.Region != "eu-west-1" {
+			t.Errorf("config region = %q, want eu-west-1", backend.awsConfig.Region)
+		}
+	})
+
+	t.Run("option overrides environment", func(t *testing.T) {
+		isolateAWSConfig(t)
+		t.Setenv("AWS_REGION", "eu-west-1")
+
+		backend, _ := New(map[string]string{"region": "ap-south-1"}, "")
+		if err := backend.initAWSConfig(ctx); err != nil {
+			t.Fatalf("initAWSConfig() error = %v", err)
+		}
+		if backend.awsConfig.Region != "ap-south-1" {
+			t.Errorf("config region = %q, want ap-south-1", backend.awsConfig.Region)
+		}
+	})
+
+	t.Run("falls back with warning", func(t *testing.T) {
+		isolateAWSConfig(t)
+
+		var logs bytes.Buffer
+		backend, _ := New(map[string]string{}, "")
+		backend.logger = slog.New(slog.NewTextHandler(&logs, nil))
+		if err := backend.initAWSConfig(ctx); err != nil {
+			t.Fatalf("initAWSConfig() error = %v", err)
+		}
+		if backend.awsConfig.Region != defaultRegion {
+			t.Errorf("config region = %q, want %q", backend.awsConfig.Region, defaultRegion)
+		}
+		if !strings.Contains(logs.String(), "no AWS region configured") {
+			t.Errorf("expected region warning, got logs %q", logs.String())
+		}
+	})
+}
+
+func TestBackend_Init_SkipConnectivityCheck(t *testing.T) {
+	ctx := context.Background()
+	isolateAWSConfig(t)
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	// Credentials may read specific secrets but not list
+	fake := newFakeClient()
+	_ = (&Backend{client: fake, prefix: "app/"}).CreateItem(ctx, "db-password", "pw", validSession{})
+	fake.listErr = errors.New("AccessDeniedException: not authorized to perform secretsmanager:ListSecrets")
+
+	probing, _ := New(map[string]string{"prefix": "app/"}, "")
+	probing.client = fake
+	if err := probing.Init(ctx); err == nil {
+		t.Error("Init() with connectivity check succeeded, want AccessDenied error")
+	}
+
+	backend, err := New(map[string]string{"prefix": "app/", "skip_connectivity_check": "true"}, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	backend.client = fake
+	if err := backend.Init(ctx); err != nil {
+		t.Fatalf("Init() with skip_connectivity_check error = %v", err)
+	}
+
+	got, err := backend.GetNotes(ctx, "db-password", validSession{})
+	if err != nil || got != "pw" {
+		t.Errorf("GetNotes() = %q, %v; want %q", got, err, "pw")
+	}
+
+	if _, err := New(map[string]string{"skip_connectivity_check": "maybe"}, ""); !errors.Is(err, vaultmux.ErrInvalidConfig) {
+		t.Errorf("New() with invalid skip_connectivity_check error = %v, want ErrInvalidConfig", err)
+	}
+}
+
 func TestBackend_Name(t *testing.T) {
 	backend, _ := New(nil, "")
 	if got := backend.Name(); got != "awssecrets" {
@@ -73,6 +419,33 @@ func TestBackend_Name(t *testing.T) {
 	}
 }
 
+func TestBackend_AuthEnvVars(t *testing.T) {
+	backend, _ := New(nil, "")
+	vars := backend.AuthEnvVars()
+	for _, want := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_PROFILE", "AWS_REGION"} {
+		if !slices.Contains(vars, want) {
+			t.Errorf("AuthEnvVars() = %v, missing %s", vars, want)
+		}
+	}
+
+	for _, name := range vars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_REGION", "us-west-2")
+
+	d := vaultmux.DiagnoseAuth(context.Background(), backend)
+	if d.Backend != "awssecrets" {
+		t.Errorf("Backend = %q, want awssecrets", d.Backend)
+	}
+	if want := []string{"AWS_ACCESS_KEY_ID", "AWS_REGION"}; !reflect.DeepEqual(d.Set, want) {
+		t.Errorf("Set = %v, want %v", d.Set, want)
+	}
+	if len(d.Set)+len(d.Unset) != len(vars) || slices.Contains(d.Unset, "AWS_ACCESS_KEY_ID") {
+		t.Errorf("Unset = %v, want the remaining %d variables", d.Unset, len(vars)-len(d.Set))
+	}
+}
+
 func TestBackend_SecretName(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -174,6 +547,10 @@ func TestBackend_LocationManagement(t *testing.T) {
 		if !errors.Is(err, vaultmux.ErrNotSupported) {
 			t.Errorf("ListLocations() error = %v, want ErrNotSupported", err)
 		}
+		var notSupported *vaultmux.NotSupportedError
+		if !errors.As(err, &notSupported) || notSupported.Backend != "awssecrets" || notSupported.Operation != "ListLocations" {
+			t.Errorf("ListLocations() error = %#v, want NotSupportedError for awssecrets ListLocations", err)
+		}
 	})
 
 	t.Run("LocationExists", func(t *testing.T) {
@@ -240,8 +617,479 @@ func TestSession_ExpiresAt(t *testing.T) {
 	}
 }
 
+func TestBackend_CreateItemWithDescription(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = fake
+
+	err := backend.CreateItemWithOptions(ctx, "db-password", "s3cret", validSession{},
+		vaultmux.WithDescription("Primary database credentials"))
+	if err != nil {
+		t.Fatalf("CreateItemWithOptions() error = %v", err)
+	}
+
+	if len(fake.createInputs) != 1 {
+		t.Fatalf("CreateSecret called %d times, want 1", len(fake.createInputs))
+	}
+	if got := aws.ToString(fake.createInputs[0].Description); got != "Primary database credentials" {
+		t.Errorf("CreateSecret Description = %q, want %q", got, "Primary database credentials")
+	}
+
+	item, err := backend.GetItem(ctx, "db-password", validSession{})
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Notes != "s3cret" || item.Description != "Primary database credentials" {
+		t.Errorf("GetItem() = %q, %q; want s3cret with the description", item.Notes, item.Description)
+	}
+	if fake.describes != 1 {
+		t.Errorf("GetItem() made %d DescribeSecret calls, want 1", fake.describes)
+	}
+	if _, err := backend.GetNotes(ctx, "db-password", validSession{}); err != nil {
+		t.Fatalf("GetNotes() error = %v", err)
+	}
+	if fake.describes != 1 {
+		t.Errorf("GetNotes() made %d more DescribeSecret calls, want 0", fake.describes-1)
+	}
+
+	meta, err := backend.Describe(ctx, "db-password", validSession{})
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if got := meta["Description"]; got != "Primary database credentials" {
+		t.Errorf(`Describe()["Description"] = %v, want %q`, got, "Primary database credentials")
+	}
+	items, err := backend.ListItems(ctx, validSession{})
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Description != "Primary database credentials" {
+		t.Errorf("ListItems() = %+v, want one item with the description", items)
+	}
+}
+
+func TestBackend_CreateItem_NoDescription(t *testing.T) {
+	fake := newFakeClient()
+	backend, _ := New(nil, "")
+	backend.client = fake
+
+	if err := backend.CreateItem(context.Background(), "api-key", "value", validSession{}); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if fake.createInputs[0].Description != nil {
+		t.Errorf("CreateSecret Description = %q, want nil", aws.ToString(fake.createInputs[0].Description))
+	}
+}
+
+func TestBackend_CRUD_ErrorMapping(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = newFakeClient()
+	session := validSession{}
+
+	// ResourceNotFoundException maps to ErrNotFound
+	if _, err := backend.GetItem(ctx, "token", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetItem(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := backend.UpdateItem(ctx, "token", "v2", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("UpdateItem(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := backend.DeleteItem(ctx, "token", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("DeleteItem(missing) error = %v, want ErrNotFound", err)
+	}
+
+	if err := backend.CreateItem(ctx, "token", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if err := backend.CreateItem(ctx, "token", "v1", session); !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("CreateItem(existing) error = %v, want ErrAlreadyExists", err)
+	}
+	// CreateItem checks first; the exception covers a create racing another
+	if err := backend.handleAWSError(&types.ResourceExistsException{}, "create", "token"); !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("handleAWSError(ResourceExistsException) = %v, want ErrAlreadyExists", err)
+	}
+
+	if err := backend.UpdateItem(ctx, "token", "v2", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+	item, err := backend.GetItem(ctx, "token", session)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Name != "token" || item.Notes != "v2" {
+		t.Errorf("GetItem() = %q: %q, want token: v2", item.Name, item.Notes)
+	}
+
+	items, err := backend.ListItems(ctx, session)
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "token" {
+		t.Errorf("ListItems() = %v, want [token]", items)
+	}
+
+	if err := backend.DeleteItem(ctx, "token", session); err != nil {
+		t.Fatalf("DeleteItem() error = %v", err)
+	}
+	if exists, err := backend.ItemExists(ctx, "token", session); err != nil || exists {
+		t.Errorf("ItemExists() after delete = %v, %v; want false", exists, err)
+	}
+}
+
+func TestBackend_UpdateItem_SkipNoopUpdates(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		option       string
+		wantVersions int
+	}{
+		{"false", 3},
+		{"true", 2},
+	} {
+		fake := newFakeClient()
+		backend, _ := New(map[string]string{"skip_noop_updates": tt.option}, "")
+		backend.client = fake
+
+		if err := backend.CreateItem(ctx, "token", "v1", validSession{}); err != nil {
+			t.Fatal(err)
+		}
+		for _, value := range []string{"v2", "v2"} {
+			if err := backend.UpdateItem(ctx, "token", value, validSession{}); err != nil {
+				t.Fatalf("UpdateItem() error = %v", err)
+			}
+		}
+		if got := fake.versions["vaultmux/token"]; got != tt.wantVersions {
+			t.Errorf("skip_noop_updates=%s: %d versions, want %d", tt.option, got, tt.wantVersions)
+		}
+		// Create checks existence once; each update reads the secret once
+		if fake.gets != 3 {
+			t.Errorf("skip_noop_updates=%s: %d GetSecretValue calls, want 3", tt.option, fake.gets)
+		}
+	}
+
+	if _, err := New(map[string]string{"skip_noop_updates": "sometimes"}, ""); err == nil {
+		t.Error("New() accepted an invalid skip_noop_updates value")
+	}
+}
+
+func TestBackend_SetItem(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient()
+	backend, _ := New(nil, "")
+	backend.client = fake
+
+	// Missing secret is created
+	if err := backend.SetItem(ctx, "token", "v1", validSession{}); err != nil {
+		t.Fatalf("SetItem() create error = %v", err)
+	}
+	// Existing secret gets a new value without another create
+	if err := backend.SetItem(ctx, "token", "v2", validSession{}); err != nil {
+		t.Fatalf("SetItem() update error = %v", err)
+	}
+
+	if len(fake.createInputs) != 1 {
+		t.Errorf("CreateSecret called %d times, want 1", len(fake.createInputs))
+	}
+	got, err := backend.GetNotes(ctx, "token", validSession{})
+	if err != nil {
+		t.Fatalf("GetNotes() error = %v", err)
+	}
+	if got != "v2" {
+		t.Errorf("GetNotes() = %q, want %q", got, "v2")
+	}
+}
+
+func TestBackend_SetItem_CreateRace(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient()
+	backend, _ := New(nil, "")
+	backend.client = fake
+
+	// Another writer creates the secret between PutSecretValue and CreateSecret
+	fake.beforeCreate = func(name string) {
+		fake.beforeCreate = nil
+		_, _ = fake.CreateSecret(ctx, &secretsmanager.CreateSecretInput{Name: aws.String(name), SecretString: aws.String("theirs")})
+	}
+	if err := backend.SetItem(ctx, "token", "ours", validSession{}); err != nil {
+		t.Fatalf("SetItem() error = %v", err)
+	}
+	if fake.gets != 0 {
+		t.Errorf("SetItem() made %d GetSecretValue calls, want 0", fake.gets)
+	}
+	if got, _ := backend.GetNotes(ctx, "token", validSession{}); got != "ours" {
+		t.Errorf("GetNotes() = %q, want ours", got)
+	}
+}
+
+func TestBackend_GetItemStage(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = fake
+
+	if err := backend.CreateItem(ctx, "db-password", "old", validSession{}); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	// Rotation puts the new value under AWSPENDING
+	_, err := fake.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:      aws.String("app/db-password"),
+		SecretString:  aws.String("new"),
+		VersionStages: []string{"AWSPENDING"},
+	})
+	if err != nil {
+		t.Fatalf("PutSecretValue() error = %v", err)
+	}
+
+	pending, err := backend.GetItemStage(ctx, "db-password", "AWSPENDING", validSession{})
+	if err != nil {
+		t.Fatalf("GetItemStage(AWSPENDING) error = %v", err)
+	}
+	if pending.Notes != "new" {
+		t.Errorf("pending Notes = %q, want %q", pending.Notes, "new")
+	}
+	if got := pending.Fields["versionStages"]; got != "AWSPENDING" {
+		t.Errorf("pending versionStages = %q, want AWSPENDING", got)
+	}
+
+	current, err := backend.GetItem(ctx, "db-password", validSession{})
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if current.Notes != "old" {
+		t.Errorf("current Notes = %q, want %q", current.Notes, "old")
+	}
+	if got := current.Fields["versionStages"]; got != "AWSCURRENT" {
+		t.Errorf("current versionStages = %q, want AWSCURRENT", got)
+	}
+}
+
+func TestBackend_GetItemVersion(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = newFakeClient()
+
+	_ = backend.CreateItem(ctx, "api-key", "v1", validSession{})
+	if err := backend.UpdateItem(ctx, "api-key", "v2", validSession{}); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+
+	item, err := backend.GetItem(ctx, "api-key", validSession{})
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Version != "v2" {
+		t.Errorf("Version = %q, want VersionId %q", item.Version, "v2")
+	}
+}
+
+func TestBackend_ListItems_MaxResults(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = newFakeClient()
+	backend.maxListResults = 3
+
+	for i := range 5 {
+		_ = backend.CreateItem(ctx, fmt.Sprintf("secret-%d", i), "value", validSession{})
+	}
+
+	items, err := backend.ListItems(ctx, validSession{})
+	if !errors.Is(err, vaultmux.ErrListTruncated) {
+		t.Fatalf("ListItems() error = %v, want ErrListTruncated", err)
+	}
+	if len(items) != 3 {
+		t.Errorf("ListItems() returned %d items, want 3", len(items))
+	}
+
+	// Exactly at the limit is not truncated
+	items, err = backend.ListItemsWithOptions(ctx, validSession{}, vaultmux.WithMaxResults(5))
+	if err != nil || len(items) != 5 {
+		t.Errorf("ListItemsWithOptions() = %d items, %v; want 5, nil", len(items), err)
+	}
+}
+
+func TestBackend_ListItemsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = newFakeClient()
+
+	_ = backend.CreateItem(ctx, "db", "value", validSession{})
+
+	tests := []struct {
+		name string
+		opts []vaultmux.ListOption
+		want string
+	}{
+		{name: "stripped by default", want: "db"},
+		{name: "full names", opts: []vaultmux.ListOption{vaultmux.WithFullNames()}, want: "app/db"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := backend.ListItemsWithOptions(ctx, validSession{}, tt.opts...)
+			if err != nil {
+				t.Fatalf("ListItemsWithOptions() error = %v", err)
+			}
+			if len(items) != 1 || items[0].Name != tt.want {
+				t.Errorf("ListItemsWithOptions() = %+v, want single item %q", items, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackend_ListItemNames(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	fake := newFakeClient()
+	backend.client = fake
+
+	_ = backend.CreateItem(ctx, "db", "value", validSession{})
+	fake.secrets["other/db"] = &secretsmanager.CreateSecretInput{Name: aws.String("other/db")}
+
+	names, err := backend.ListItemNames(ctx, validSession{})
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	if len(names) != 1 || names[0] != "db" {
+		t.Errorf("ListItemNames() = %v, want [db]", names)
+	}
+}
+
+func TestBackend_GetAccessPolicy(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	fake := newFakeClient()
+	backend.client = fake
+
+	_ = backend.CreateItem(ctx, "db", "value", validSession{})
+	_ = backend.CreateItem(ctx, "open", "value", validSession{})
+	fake.policies["app/db"] = `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Principal": {"AWS": ["arn:aws:iam::123456789012:role/app", "arn:aws:iam::123456789012:role/ci"]},
+				"Action": "secretsmanager:GetSecretValue",
+				"Resource": "*"
+			},
+			{
+				"Effect": "Allow",
+				"Principal": {"AWS": "arn:aws:iam::123456789012:role/admin"},
+				"Action": ["secretsmanager:GetSecretValue", "secretsmanager:PutSecretValue"],
+				"Resource": "*"
+			},
+			{
+				"Effect": "Deny",
+				"Principal": "*",
+				"Action": "secretsmanager:DeleteSecret",
+				"Resource": "*"
+			}
+		]
+	}`
+
+	policy, err := backend.GetAccessPolicy(ctx, "db", validSession{})
+	if err != nil {
+		t.Fatalf("GetAccessPolicy() error = %v", err)
+	}
+	want := vaultmux.AccessPolicy{Bindings: []vaultmux.Binding{
+		{
+			Role: "secretsmanager:GetSecretValue",
+			Members: []string{
+				"AWS:arn:aws:iam::123456789012:role/app",
+				"AWS:arn:aws:iam::123456789012:role/ci",
+				"AWS:arn:aws:iam::123456789012:role/admin",
+			},
+		},
+		{
+			Role:    "secretsmanager:PutSecretValue",
+			Members: []string{"AWS:arn:aws:iam::123456789012:role/admin"},
+		},
+	}}
+	if !reflect.DeepEqual(policy, want) {
+		t.Errorf("GetAccessPolicy() = %+v, want %+v", policy, want)
+	}
+
+	policy, err = backend.GetAccessPolicy(ctx, "open", validSession{})
+	if err != nil || len(policy.Bindings) != 0 {
+		t.Errorf("GetAccessPolicy(no policy) = %+v, %v, want empty policy", policy, err)
+	}
+
+	if _, err := backend.GetAccessPolicy(ctx, "missing", validSession{}); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetAccessPolicy(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBackend_RawItems(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	fake := newFakeClient()
+	backend.client = fake
+
+	fake.secrets["other/db"] = &secretsmanager.CreateSecretInput{Name: aws.String("other/db"), SecretString: aws.String("raw")}
+
+	item, err := backend.GetItemRaw(ctx, "other/db", validSession{})
+	if err != nil {
+		t.Fatalf("GetItemRaw() error = %v", err)
+	}
+	if item.Name != "other/db" || item.Notes != "raw" {
+		t.Errorf("GetItemRaw() = %s %q, want other/db %q", item.Name, item.Notes, "raw")
+	}
+
+	if err := backend.DeleteItemRaw(ctx, "other/db", validSession{}); err != nil {
+		t.Fatalf("DeleteItemRaw() error = %v", err)
+	}
+	if _, ok := fake.secrets["other/db"]; ok {
+		t.Error("DeleteItemRaw() left other/db in place")
+	}
+	if backend.Prefix() != "app/" {
+		t.Errorf("Prefix() = %q after raw access, want app/", backend.Prefix())
+	}
+}
+
+func TestBackend_Describe(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	fake := newFakeClient()
+	backend.client = fake
+
+	_ = backend.CreateItem(ctx, "db", "value", validSession{})
+	rotated := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	fake.rotated["app/db"] = rotated
+
+	metadata, err := backend.Describe(ctx, "db", validSession{})
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if metadata["RotationEnabled"] != true {
+		t.Errorf("Describe() RotationEnabled = %v, want true", metadata["RotationEnabled"])
+	}
+	if want := rotated.Format(time.RFC3339); metadata["LastRotatedDate"] != want {
+		t.Errorf("Describe() LastRotatedDate = %v, want %s", metadata["LastRotatedDate"], want)
+	}
+	if metadata["Name"] != "app/db" {
+		t.Errorf("Describe() Name = %v, want app/db", metadata["Name"])
+	}
+	if _, ok := metadata["ResultMetadata"]; ok {
+		t.Error("Describe() includes SDK ResultMetadata")
+	}
+
+	if _, err := backend.Describe(ctx, "missing", validSession{}); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("Describe(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
 func TestBackend_InterfaceCompliance(t *testing.T) {
 	var _ vaultmux.Backend = (*Backend)(nil)
+	var _ vaultmux.ItemCreator = (*Backend)(nil)
+	var _ vaultmux.ItemLister = (*Backend)(nil)
+	var _ vaultmux.ItemSetter = (*Backend)(nil)
+	var _ vaultmux.ItemNameLister = (*Backend)(nil)
+	var _ vaultmux.RawItemAccessor = (*Backend)(nil)
+	var _ vaultmux.Describer = (*Backend)(nil)
+	var _ vaultmux.LimitsReporter = (*Backend)(nil)
+	var _ vaultmux.AccessPolicyReader = (*Backend)(nil)
+	var _ vaultmux.ServerTimer = (*Backend)(nil)
+	var _ secretsManagerAPI = (*secretsmanager.Client)(nil)
 }
 
 // Integration test note:
@@ -250,3 +1098,112 @@ func TestBackend_InterfaceCompliance(t *testing.T) {
 // 2. LOCALSTACK_ENDPOINT=http://localhost:4566 AWS_ACCESS_KEY_ID=test AWS_SECRET_ACCESS_KEY=test go test -v
 //
 // See awssecrets_integration_test.go for LocalStack tests.
+
+func TestBackend_DryRun(t *testing.T) {
+	ctx := vaultmux.WithDryRun(context.Background())
+	fake := newFakeClient()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	backend.client = fake
+
+	_ = backend.CreateItem(context.Background(), "existing", "old", validSession{})
+
+	if err := backend.CreateItem(ctx, "new", "v", validSession{}); err != nil {
+		t.Errorf("CreateItem() error = %v", err)
+	}
+	if err := backend.UpdateItem(ctx, "existing", "v", validSession{}); err != nil {
+		t.Errorf("UpdateItem() error = %v", err)
+	}
+	if err := backend.SetItem(ctx, "new", "v", validSession{}); err != nil {
+		t.Errorf("SetItem() error = %v", err)
+	}
+	if err := backend.DeleteItem(ctx, "existing", validSession{}); err != nil {
+		t.Errorf("DeleteItem() error = %v", err)
+	}
+	if err := backend.CreateItem(ctx, "existing", "v", validSession{}); !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("CreateItem(existing) error = %v, want ErrAlreadyExists", err)
+	}
+
+	if len(fake.secrets) != 1 || aws.ToString(fake.secrets["app/existing"].SecretString) != "old" {
+		t.Errorf("dry run changed secrets: %v", fake.secrets)
+	}
+}
+
+func TestBackend_ServerTime(t *testing.T) {
+	ctx := context.Background()
+	isolateAWSConfig(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	// Stub Secrets Manager whose clock runs 10 minutes ahead
+	serverTime := time.Now().Add(10 * time.Minute).UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = w.Write([]byte(`{"SecretList":[]}`))
+	}))
+	defer server.Close()
+
+	backend, err := New(map[string]string{
+		"region":                  "us-east-1",
+		"endpoint":                server.URL,
+		"skip_connectivity_check": "true",
+	}, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := backend.Init(ctx); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	skew, err := vaultmux.CheckClockSkew(ctx, backend)
+	if err != nil {
+		t.Fatalf("CheckClockSkew() error = %v", err)
+	}
+	if want := -10 * time.Minute; skew > want+2*time.Second || skew < want-2*time.Second {
+		t.Errorf("CheckClockSkew() = %v, want about %v", skew, want)
+	}
+}
+
+func TestBackend_ReplicateSecret(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(map[string]string{"prefix": "app/"}, "")
+	fake := newFakeClient()
+	backend.client = fake
+
+	_ = backend.CreateItem(ctx, "db", "value", validSession{})
+	if err := backend.ReplicateSecret(ctx, "db", []string{"eu-west-1", "us-gov-west-1"}, validSession{}); err != nil {
+		t.Fatalf("ReplicateSecret() error = %v", err)
+	}
+	if want := []string{"eu-west-1", "us-gov-west-1"}; !reflect.DeepEqual(fake.replicas["app/db"], want) {
+		t.Errorf("replicated to %v, want %v", fake.replicas["app/db"], want)
+	}
+
+	regions, err := backend.ReplicaRegions(ctx, "db", validSession{})
+	if err != nil {
+		t.Fatalf("ReplicaRegions() error = %v", err)
+	}
+	if want := []string{"eu-west-1", "us-gov-west-1"}; !reflect.DeepEqual(regions, want) {
+		t.Errorf("ReplicaRegions() = %v, want %v", regions, want)
+	}
+	meta, _ := backend.Describe(ctx, "db", validSession{})
+	if status, ok := meta["ReplicationStatus"].([]any); !ok || len(status) != 2 {
+		t.Errorf(`Describe()["ReplicationStatus"] = %v, want 2 replicas`, meta["ReplicationStatus"])
+	}
+
+	if err := backend.RemoveRegions(ctx, "db", []string{"eu-west-1"}, validSession{}); err != nil {
+		t.Fatalf("RemoveRegions() error = %v", err)
+	}
+	regions, _ = backend.ReplicaRegions(ctx, "db", validSession{})
+	if want := []string{"us-gov-west-1"}; !reflect.DeepEqual(regions, want) {
+		t.Errorf("ReplicaRegions() after removal = %v, want %v", regions, want)
+	}
+
+	for _, regions := range [][]string{nil, {"Europe"}, {"eu-west-1", "us-east"}} {
+		if err := backend.ReplicateSecret(ctx, "db", regions, validSession{}); !errors.Is(err, errInvalidRegion) {
+			t.Errorf("ReplicateSecret(%v) error = %v, want errInvalidRegion", regions, err)
+		}
+	}
+	if err := backend.ReplicateSecret(ctx, "missing", []string{"eu-west-1"}, validSession{}); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("ReplicateSecret(missing) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,30 @@
+package awssecrets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// ServerTime returns the time reported in the Date header of a lightweight
+// ListSecrets call, as recorded by the SDK.
+func (b *Backend) ServerTime(ctx context.Context) (time.Time, error) {
+	out, err := b.client.ListSecrets(ctx, &secretsmanager.ListSecretsInput{
+		MaxResults: aws.Int32(1),
+	})
+	if err != nil {
+		return time.Time{}, vaultmux.WrapError(b.Name(), "clock-skew", "", err)
+	}
+
+	server, ok := awsmiddleware.GetServerTime(out.ResultMetadata)
+	if !ok {
+		return time.Time{}, vaultmux.WrapError(b.Name(), "clock-skew", "", errors.New("response has no Date header"))
+	}
+	return server, nil
+}
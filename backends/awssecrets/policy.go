@@ -0,0 +1,136 @@
+package awssecrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// GetAccessPolicy returns the resource policy attached to the secret, with
+// one binding per allowed action. Access granted by identity-based IAM
+// policies is not visible from the secret and is not included; Deny
+// statements are skipped. A secret without a resource policy returns an
+// empty AccessPolicy.
+func (b *Backend) GetAccessPolicy(ctx context.Context, name string, session vaultmux.Session) (vaultmux.AccessPolicy, error) {
+	if !session.IsValid(ctx) {
+		return vaultmux.AccessPolicy{}, vaultmux.ErrNotAuthenticated
+	}
+
+	result, err := b.client.GetResourcePolicy(ctx, &secretsmanager.GetResourcePolicyInput{
+		SecretId: aws.String(b.secretName(name)),
+	})
+	if err != nil {
+		return vaultmux.AccessPolicy{}, b.handleAWSError(err, "get-policy", name)
+	}
+	if aws.ToString(result.ResourcePolicy) == "" {
+		return vaultmux.AccessPolicy{}, nil
+	}
+
+	policy, err := accessPolicyFromDocument(aws.ToString(result.ResourcePolicy))
+	if err != nil {
+		return vaultmux.AccessPolicy{}, vaultmux.WrapError(b.Name(), "get-policy", name, err)
+	}
+	return policy, nil
+}
+
+// policyDocument is the subset of an IAM policy document used to build
+// bindings.
+type policyDocument struct {
+	Statement statements
+}
+
+type policyStatement struct {
+	Effect    string
+	Principal json.RawMessage
+	Action    stringOrSlice
+}
+
+// statements accepts a single statement object or an array of them.
+type statements []policyStatement
+
+func (s *statements) UnmarshalJSON(data []byte) error {
+	var one policyStatement
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = statements{one}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]policyStatement)(s))
+}
+
+// stringOrSlice accepts a JSON string or an array of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = stringOrSlice{one}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(s))
+}
+
+// principals flattens a statement Principal into members: "*" for anyone,
+// otherwise "<type>:<value>", e.g. "AWS:arn:aws:iam::123456789012:root".
+func principals(raw json.RawMessage) ([]string, error) {
+	var wildcard string
+	if err := json.Unmarshal(raw, &wildcard); err == nil {
+		return []string{wildcard}, nil
+	}
+
+	var byType map[string]stringOrSlice
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return nil, fmt.Errorf("invalid policy principal: %w", err)
+	}
+	var members []string
+	for kind, values := range byType {
+		for _, v := range values {
+			members = append(members, kind+":"+v)
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// accessPolicyFromDocument converts a resource policy document into
+// bindings, merging statements that allow the same action.
+func accessPolicyFromDocument(document string) (vaultmux.AccessPolicy, error) {
+	var doc policyDocument
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		return vaultmux.AccessPolicy{}, fmt.Errorf("invalid resource policy: %w", err)
+	}
+
+	members := make(map[string][]string)
+	var actions []string
+	for _, stmt := range doc.Statement {
+		if stmt.Effect != "Allow" || len(stmt.Principal) == 0 {
+			continue
+		}
+		who, err := principals(stmt.Principal)
+		if err != nil {
+			return vaultmux.AccessPolicy{}, err
+		}
+		for _, action := range stmt.Action {
+			if _, seen := members[action]; !seen {
+				actions = append(actions, action)
+			}
+			for _, m := range who {
+				if !slices.Contains(members[action], m) {
+					members[action] = append(members[action], m)
+				}
+			}
+		}
+	}
+
+	var policy vaultmux.AccessPolicy
+	for _, action := range actions {
+		policy.Bindings = append(policy.Bindings, vaultmux.Binding{Role: action, Members: members[action]})
+	}
+	return policy, nil
+}
@@ -0,0 +1,113 @@
+package awssecrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// errInvalidRegion indicates a malformed region passed to ReplicateSecret
+// or RemoveRegions.
+var errInvalidRegion = errors.New("invalid AWS region")
+
+// regionPattern matches AWS region names such as "us-east-1",
+// "us-gov-west-1" and "cn-north-1".
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// validateRegions checks that regions is non-empty and well-formed.
+func validateRegions(regions []string) error {
+	if len(regions) == 0 {
+		return fmt.Errorf("%w: at least one region is required", errInvalidRegion)
+	}
+	for _, region := range regions {
+		if !regionPattern.MatchString(region) {
+			return fmt.Errorf("%w: %q", errInvalidRegion, region)
+		}
+	}
+	return nil
+}
+
+// ReplicateSecret replicates a secret to regions with
+// ReplicateSecretToRegions. Replicas are encrypted with the default
+// aws/secretsmanager key of their region and kept in sync by AWS;
+// ReplicaRegions lists them.
+func (b *Backend) ReplicateSecret(ctx context.Context, name string, regions []string, session vaultmux.Session) error {
+	if err := validateRegions(regions); err != nil {
+		return vaultmux.WrapError(b.Name(), "replicate", name, err)
+	}
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	replicas := make([]types.ReplicaRegionType, len(regions))
+	for i, region := range regions {
+		replicas[i] = types.ReplicaRegionType{Region: aws.String(region)}
+	}
+	_, err := b.client.ReplicateSecretToRegions(ctx, &secretsmanager.ReplicateSecretToRegionsInput{
+		SecretId:          aws.String(b.secretName(name)),
+		AddReplicaRegions: replicas,
+	})
+	if err != nil {
+		return b.handleAWSError(err, "replicate", name)
+	}
+	return nil
+}
+
+// RemoveRegions deletes the secret's replicas in regions with
+// RemoveRegionsFromReplication. The primary secret is not affected.
+func (b *Backend) RemoveRegions(ctx context.Context, name string, regions []string, session vaultmux.Session) error {
+	if err := validateRegions(regions); err != nil {
+		return vaultmux.WrapError(b.Name(), "remove-regions", name, err)
+	}
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	_, err := b.client.RemoveRegionsFromReplication(ctx, &secretsmanager.RemoveRegionsFromReplicationInput{
+		SecretId:             aws.String(b.secretName(name)),
+		RemoveReplicaRegions: regions,
+	})
+	if err != nil {
+		return b.handleAWSError(err, "remove-regions", name)
+	}
+	return nil
+}
+
+// ReplicaRegions returns the regions the secret is replicated to, read from
+// the ReplicationStatus of DescribeSecret. A secret without replicas
+// returns an empty slice.
+func (b *Backend) ReplicaRegions(ctx context.Context, name string, session vaultmux.Session) ([]string, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	result, err := b.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(b.secretName(name)),
+	})
+	if err != nil {
+		return nil, b.handleAWSError(err, "replica-regions", name)
+	}
+	return replicaRegions(result.ReplicationStatus), nil
+}
+
+// replicaRegions returns the regions of a secret's replicas.
+func replicaRegions(status []types.ReplicationStatusType) []string {
+	regions := make([]string, len(status))
+	for i, s := range status {
+		regions[i] = aws.ToString(s.Region)
+	}
+	return regions
+}
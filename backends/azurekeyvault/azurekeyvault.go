@@ -10,9 +10,14 @@ package azurekeyvault
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
@@ -30,6 +35,11 @@ type Backend struct {
 	vaultURL string // Azure Key Vault URL (required, e.g., "https://myvault.vault.azure.net/")
 	prefix   string // Secret name prefix for namespacing (e.g., "myapp-")
 
+	skipConnectivityCheck bool          // Init skips the list probe
+	skipNoopUpdates       bool          // UpdateItem skips writes of an unchanged value
+	initTimeout           time.Duration // Bounds the probe (0 uses vaultmux.DefaultInitTimeout)
+	proxyURL              *url.URL      // Proxy for Key Vault and Azure AD requests (nil uses the environment)
+
 	// Azure AD credential (service principal, managed identity, CLI, etc.)
 	credential azcore.TokenCredential
 
@@ -40,11 +50,18 @@ type Backend struct {
 // New creates a new Azure Key Vault backend.
 //
 // Supported options:
-//   - vault_url: Azure Key Vault URL (required, e.g., "https://myvault.vault.azure.net/")
+//   - vault_url: Azure Key Vault URL (required, e.g., "https://myvault.vault.azure.net/");
+//     Managed HSM and sovereign cloud suffixes are also accepted (see vaultURLSuffixes)
 //   - prefix: Secret name prefix for namespacing (default: "vaultmux-")
 //   - tenant_id: Azure AD tenant ID (optional, for service principal auth)
 //   - client_id: Azure AD client ID (optional, for service principal auth)
 //   - client_secret: Azure AD client secret (optional, for service principal auth)
+//   - skip_connectivity_check: "true" to skip Init's list probe, for
+//     credentials that may only read specific secrets (default: false)
+//   - skip_noop_updates: "true" to make UpdateItem skip the write, and the
+//     new version it creates, when the value is unchanged (default: false)
+//   - proxy_url: HTTP(S) or SOCKS5 proxy for all requests, e.g.
+//     "socks5://127.0.0.1:1080" (default: HTTPS_PROXY from the environment)
 //
 // Authentication uses DefaultAzureCredential by default, which tries in order:
 //   - Environment variables (AZURE_TENANT_ID, AZURE_CLIENT_ID, AZURE_CLIENT_SECRET)
@@ -62,12 +79,12 @@ type Backend struct {
 func New(options map[string]string, sessionFile string) (*Backend, error) {
 	vaultURL := options["vault_url"]
 	if vaultURL == "" {
-		return nil, fmt.Errorf("vault_url is required for Azure Key Vault")
+		return nil, &vaultmux.ConfigError{Field: "vault_url", Reason: "required for Azure Key Vault"}
 	}
 
 	// Validate vault URL format
-	if !strings.HasPrefix(vaultURL, "https://") || !strings.HasSuffix(vaultURL, ".vault.azure.net/") {
-		return nil, fmt.Errorf("vault_url must be in format: https://<vault-name>.vault.azure.net/")
+	if !validVaultURL(vaultURL) {
+		return nil, &vaultmux.ConfigError{Field: "vault_url", Reason: "must be in format: https://<vault-name>.vault.azure.net/ (or a Managed HSM/sovereign cloud equivalent)"}
 	}
 
 	prefix := options["prefix"]
@@ -75,10 +92,37 @@ func New(options map[string]string, sessionFile string) (*Backend, error) {
 		prefix = "vaultmux-"
 	}
 
+	skipCheck := false
+	if v := options["skip_connectivity_check"]; v != "" {
+		var err error
+		if skipCheck, err = strconv.ParseBool(v); err != nil {
+			return nil, &vaultmux.ConfigError{Field: "skip_connectivity_check", Reason: fmt.Sprintf("%q: must be true or false", v)}
+		}
+	}
+
+	skipNoopUpdates := false
+	if v := options["skip_noop_updates"]; v != "" {
+		var err error
+		if skipNoopUpdates, err = strconv.ParseBool(v); err != nil {
+			return nil, &vaultmux.ConfigError{Field: "skip_noop_updates", Reason: fmt.Sprintf("%q: must be true or false", v)}
+		}
+	}
+
+	var proxyURL *url.URL
+	if v := options["proxy_url"]; v != "" {
+		var err error
+		if proxyURL, err = vaultmux.ParseProxyURL(v); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Backend{
-		vaultURL:    vaultURL,
-		prefix:      prefix,
-		sessionFile: sessionFile,
+		vaultURL:              vaultURL,
+		prefix:                prefix,
+		skipConnectivityCheck: skipCheck,
+		skipNoopUpdates:       skipNoopUpdates,
+		proxyURL:              proxyURL,
+		sessionFile:           sessionFile,
 	}, nil
 }
 
@@ -87,6 +131,35 @@ func (b *Backend) Name() string {
 	return "azurekeyvault"
 }
 
+// AuthEnvVars returns the environment variables DefaultAzureCredential
+// reads for service principal, workload identity and managed identity
+// auth.
+func (b *Backend) AuthEnvVars() []string {
+	return []string{
+		"AZURE_CLIENT_ID",
+		"AZURE_TENANT_ID",
+		"AZURE_CLIENT_SECRET",
+		"AZURE_CLIENT_CERTIFICATE_PATH",
+		"AZURE_CLIENT_CERTIFICATE_PASSWORD",
+		"AZURE_USERNAME",
+		"AZURE_PASSWORD",
+		"AZURE_FEDERATED_TOKEN_FILE",
+		"AZURE_AUTHORITY_HOST",
+	}
+}
+
+// CaseSensitive reports false: Key Vault secret names are case-insensitive.
+func (b *Backend) CaseSensitive() bool { return false }
+
+// Limits reports Key Vault's secret size and name limits.
+func (b *Backend) Limits() vaultmux.Limits {
+	return vaultmux.Limits{
+		MaxSecretBytes:     25000,
+		MaxNameLength:      127,
+		AllowedNameCharset: "A-Za-z0-9-",
+	}
+}
+
 // Init initializes the Azure Key Vault client and verifies connectivity.
 func (b *Backend) Init(ctx context.Context) error {
 	if err := b.initCredential(); err != nil {
@@ -95,22 +168,32 @@ func (b *Backend) Init(ctx context.Context) error {
 	}
 
 	// Create Azure Key Vault client
-	client, err := azsecrets.NewClient(b.vaultURL, b.credential, nil)
+	client, err := azsecrets.NewClient(b.vaultURL, b.credential, &azsecrets.ClientOptions{
+		ClientOptions: b.clientOptions(),
+	})
 	if err != nil {
 		return vaultmux.WrapError(b.Name(), "init", "",
 			fmt.Errorf("failed to create Azure Key Vault client: %w", err))
 	}
 	b.client = client
 
+	if b.skipConnectivityCheck {
+		return nil
+	}
+
 	// Verify connectivity with lightweight API call (list with max 1)
-	pager := b.client.NewListSecretPropertiesPager(nil)
-	if pager.More() {
-		_, err := pager.NextPage(ctx)
-		// EOF is ok (no secrets exist yet), other errors indicate connectivity issues
-		if err != nil {
-			return vaultmux.WrapError(b.Name(), "init", "",
-				fmt.Errorf("failed to connect to Azure Key Vault: %w", err))
+	err = vaultmux.ProbeConnectivity(ctx, b.initTimeout, func(ctx context.Context) error {
+		pager := b.client.NewListSecretPropertiesPager(nil)
+		if !pager.More() {
+			return nil
 		}
+		// EOF is ok (no secrets exist yet), other errors indicate connectivity issues
+		_, err := pager.NextPage(ctx)
+		return err
+	})
+	if err != nil {
+		return vaultmux.WrapError(b.Name(), "init", "",
+			fmt.Errorf("failed to connect to Azure Key Vault: %w", err))
 	}
 
 	return nil
@@ -120,7 +203,9 @@ func (b *Backend) Init(ctx context.Context) error {
 // Uses DefaultAzureCredential which tries multiple auth methods automatically.
 func (b *Backend) initCredential() error {
 	// Use DefaultAzureCredential (tries env vars, managed identity, CLI, etc.)
-	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	credential, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+		ClientOptions: b.clientOptions(),
+	})
 	if err != nil {
 		return err
 	}
@@ -129,6 +214,21 @@ func (b *Backend) initCredential() error {
 	return nil
 }
 
+// clientOptions returns the Azure SDK options shared by the credential and
+// the Key Vault client, routing requests through proxyURL if set.
+func (b *Backend) clientOptions() azcore.ClientOptions {
+	var opts azcore.ClientOptions
+	if b.proxyURL != nil {
+		opts.Transport = b.httpClient()
+	}
+	return opts
+}
+
+// httpClient returns an HTTP client that sends requests through proxyURL.
+func (b *Backend) httpClient() *http.Client {
+	return &http.Client{Transport: vaultmux.ProxyTransport(b.proxyURL)}
+}
+
 // Close releases Azure Key Vault client resources.
 func (b *Backend) Close() error {
 	// Azure SDK doesn't require explicit cleanup
@@ -168,6 +268,10 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 // GetItem retrieves a secret from Azure Key Vault.
 // Returns the latest version of the secret.
 func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
+
 	if !session.IsValid(ctx) {
 		return nil, vaultmux.ErrNotAuthenticated
 	}
@@ -181,13 +285,42 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 	}
 
 	return &vaultmux.Item{
-		ID:    string(*resp.Secret.ID),
-		Name:  name,
-		Type:  vaultmux.ItemTypeSecureNote,
-		Notes: *resp.Secret.Value,
+		ID:          string(*resp.Secret.ID),
+		Name:        name,
+		Type:        vaultmux.ItemTypeSecureNote,
+		Notes:       *resp.Secret.Value,
+		Description: tagValue(resp.Secret.Tags, descriptionTag),
+		Version:     resp.Secret.ID.Version(),
 	}, nil
 }
 
+// Describe returns the latest version of the secret, minus its value, as a
+// map in Key Vault's wire format: "id", "attributes" (with "created",
+// "updated", "exp", "enabled", "recoveryLevel", ...), "contentType" and
+// "tags". Timestamps are Unix seconds, as Key Vault sends them.
+func (b *Backend) Describe(ctx context.Context, name string, session vaultmux.Session) (map[string]any, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	resp, err := b.client.GetSecret(ctx, b.secretName(name), "", nil)
+	if err != nil {
+		return nil, b.handleAzureError(err, "describe", name)
+	}
+
+	secret := resp.Secret
+	secret.Value = nil
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return nil, vaultmux.WrapError(b.Name(), "describe", name, err)
+	}
+	var metadata map[string]any
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, vaultmux.WrapError(b.Name(), "describe", name, err)
+	}
+	return metadata, nil
+}
+
 // GetNotes retrieves only the notes field of a secret (convenience method).
 func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
 	item, err := b.GetItem(ctx, name, session)
@@ -198,12 +331,16 @@ func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Se
 }
 
 // ItemExists checks if a secret exists without retrieving its value.
+// Disabled secrets exist.
 func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.Session) (bool, error) {
 	_, err := b.GetItem(ctx, name, session)
 	if err != nil {
 		if errors.Is(err, vaultmux.ErrNotFound) {
 			return false, nil
 		}
+		if errors.Is(err, vaultmux.ErrDisabled) {
+			return true, nil
+		}
 		return false, err
 	}
 	return true, nil
@@ -212,10 +349,18 @@ func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.
 // ListItems returns all secrets matching the configured prefix.
 // Azure SDK uses pager pattern for pagination.
 func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	return b.ListItemsWithOptions(ctx, session)
+}
+
+// ListItemsWithOptions returns all secrets matching the configured prefix,
+// applying any list options.
+func (b *Backend) ListItemsWithOptions(ctx context.Context, session vaultmux.Session, opts ...vaultmux.ListOption) ([]*vaultmux.Item, error) {
 	if !session.IsValid(ctx) {
 		return nil, vaultmux.ErrNotAuthenticated
 	}
 
+	o := vaultmux.NewListOptions(opts...)
+
 	var items []*vaultmux.Item
 
 	// Create pager for listing secret properties
@@ -242,20 +387,72 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 			}
 
 			name := strings.TrimPrefix(fullName, b.prefix)
-			items = append(items, &vaultmux.Item{
-				ID:   string(*secret.ID),
-				Name: name,
-				Type: vaultmux.ItemTypeSecureNote,
+			if o.FullNames {
+				name = fullName
+			}
+			item := &vaultmux.Item{
+				ID:          string(*secret.ID),
+				Name:        name,
+				Type:        vaultmux.ItemTypeSecureNote,
+				Description: tagValue(secret.Tags, descriptionTag),
 				// Notes not included (requires separate GetSecret call)
-			})
+			}
+			if attrs := secret.Attributes; attrs != nil {
+				if attrs.Created != nil {
+					item.Created = *attrs.Created
+				}
+				if attrs.Updated != nil {
+					item.Modified = *attrs.Updated
+				}
+				item.Disabled = attrs.Enabled != nil && !*attrs.Enabled
+			}
+			items = append(items, item)
 		}
 	}
 
 	return items, nil
 }
 
+// ListItemNames returns the names of all secrets matching the configured
+// prefix, with the prefix stripped, without building Item values.
+func (b *Backend) ListItemNames(ctx context.Context, session vaultmux.Session) ([]string, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	var names []string
+	pager := b.client.NewListSecretPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, b.handleAzureError(err, "list", "")
+		}
+
+		for _, secret := range page.Value {
+			if secret.ID == nil {
+				continue
+			}
+			if name, ok := strings.CutPrefix(secret.ID.Name(), b.prefix); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// descriptionTag is the secret tag holding Item.Description.
+// Azure Key Vault has no dedicated description field.
+const descriptionTag = "vaultmux-description"
+
 // CreateItem creates a new secret in Azure Key Vault.
 func (b *Backend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	return b.CreateItemWithOptions(ctx, name, content, session)
+}
+
+// CreateItemWithOptions creates a new secret, applying any create options.
+// The description is stored as a secret tag.
+func (b *Backend) CreateItemWithOptions(ctx context.Context, name, content string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
 	if !session.IsValid(ctx) {
 		return vaultmux.ErrNotAuthenticated
 	}
@@ -271,10 +468,19 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, session
 		return vaultmux.ErrAlreadyExists
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	// Create secret
 	params := azsecrets.SetSecretParameters{
 		Value: &content,
 	}
+	if o := vaultmux.NewCreateOptions(opts...); o.Description != "" {
+		params.Tags = map[string]*string{
+			descriptionTag: &o.Description,
+		}
+	}
 
 	_, err = b.client.SetSecret(ctx, secretName, params, nil)
 	if err != nil {
@@ -293,13 +499,27 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 
 	secretName := b.secretName(name)
 
-	// Check if exists
-	exists, err := b.ItemExists(ctx, name, session)
-	if err != nil {
-		return err
+	if b.skipNoopUpdates {
+		// The read that compares values also checks the secret exists
+		current, err := b.GetNotes(ctx, name, session)
+		if err != nil {
+			return err
+		}
+		if current == content {
+			return nil
+		}
+	} else {
+		exists, err := b.ItemExists(ctx, name, session)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return vaultmux.ErrNotFound
+		}
 	}
-	if !exists {
-		return vaultmux.ErrNotFound
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
 	}
 
 	// Update secret (creates new version automatically)
@@ -307,7 +527,7 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 		Value: &content,
 	}
 
-	_, err = b.client.SetSecret(ctx, secretName, params, nil)
+	_, err := b.client.SetSecret(ctx, secretName, params, nil)
 	if err != nil {
 		return b.handleAzureError(err, "update", name)
 	}
@@ -315,6 +535,51 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 	return nil
 }
 
+// SetItem creates or updates the secret. Azure's SetSecret is already an
+// upsert, so no existence check is made.
+func (b *Backend) SetItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		_, err := b.ItemExists(ctx, name, session)
+		return err
+	}
+
+	params := azsecrets.SetSecretParameters{
+		Value: &content,
+	}
+
+	_, err := b.client.SetSecret(ctx, b.secretName(name), params, nil)
+	if err != nil {
+		return b.handleAzureError(err, "set", name)
+	}
+
+	return nil
+}
+
+// SetEnabled enables or disables a secret without changing its value.
+// Reading a disabled secret fails with vaultmux.ErrDisabled; it is still
+// listed, with Item.Disabled set.
+func (b *Backend) SetEnabled(ctx context.Context, name string, enabled bool, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	params := azsecrets.UpdateSecretPropertiesParameters{
+		SecretAttributes: &azsecrets.SecretAttributes{Enabled: &enabled},
+	}
+	if _, err := b.client.UpdateSecretProperties(ctx, b.secretName(name), "", params, nil); err != nil {
+		return b.handleAzureError(err, "set-enabled", name)
+	}
+	return nil
+}
+
 // DeleteItem deletes a secret from Azure Key Vault.
 // Azure uses soft-delete by default (recoverable for configured retention period).
 func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.Session) error {
@@ -333,6 +598,10 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 		return vaultmux.ErrNotFound
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	// Delete secret (soft delete - recoverable for retention period)
 	_, err = b.client.DeleteSecret(ctx, secretName, nil)
 	if err != nil {
@@ -342,6 +611,32 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 	return nil
 }
 
+// GetItemRaw retrieves a secret by its full stored name, without adding
+// the prefix. It bypasses namespacing and can read any secret the
+// credentials allow; see vaultmux.RawItemAccessor.
+func (b *Backend) GetItemRaw(ctx context.Context, storedName string, session vaultmux.Session) (*vaultmux.Item, error) {
+	return b.unprefixed().GetItem(ctx, storedName, session)
+}
+
+// DeleteItemRaw deletes a secret by its full stored name, without adding
+// the prefix. It bypasses namespacing and can delete any secret the
+// credentials allow; see vaultmux.RawItemAccessor.
+func (b *Backend) DeleteItemRaw(ctx context.Context, storedName string, session vaultmux.Session) error {
+	return b.unprefixed().DeleteItem(ctx, storedName, session)
+}
+
+// unprefixed returns a copy of b, sharing its client, with no prefix.
+func (b *Backend) unprefixed() *Backend {
+	raw := *b
+	raw.prefix = ""
+	return &raw
+}
+
+// Prefix returns the secret name prefix used for namespacing.
+func (b *Backend) Prefix() string {
+	return b.prefix
+}
+
 // secretName returns the full secret name with prefix applied.
 func (b *Backend) secretName(name string) string {
 	if b.prefix != "" {
@@ -350,6 +645,41 @@ func (b *Backend) secretName(name string) string {
 	return name
 }
 
+// vaultURLSuffixes lists the DNS suffixes of Key Vault and Managed HSM
+// endpoints in the public and sovereign clouds.
+var vaultURLSuffixes = []string{
+	".vault.azure.net/",
+	".managedhsm.azure.net/",
+	".vault.azure.cn/",
+	".managedhsm.azure.cn/",
+	".vault.usgovcloudapi.net/",
+	".managedhsm.usgovcloudapi.net/",
+}
+
+// validVaultURL reports whether vaultURL is an https URL of the form
+// https://<name><suffix> for a known suffix.
+func validVaultURL(vaultURL string) bool {
+	host, ok := strings.CutPrefix(vaultURL, "https://")
+	if !ok {
+		return false
+	}
+	for _, suffix := range vaultURLSuffixes {
+		name, ok := strings.CutSuffix(host, suffix)
+		if ok && name != "" && !strings.ContainsAny(name, "/.") {
+			return true
+		}
+	}
+	return false
+}
+
+// tagValue returns the value of an Azure secret tag, or "" if unset.
+func tagValue(tags map[string]*string, key string) string {
+	if v := tags[key]; v != nil {
+		return *v
+	}
+	return ""
+}
+
 // handleAzureError maps Azure SDK errors to vaultmux standard errors.
 func (b *Backend) handleAzureError(err error, operation, itemName string) error {
 	if err == nil {
@@ -367,6 +697,9 @@ func (b *Backend) handleAzureError(err error, operation, itemName string) error
 			return vaultmux.ErrAlreadyExists
 
 		case 403:
+			if strings.Contains(err.Error(), "SecretDisabled") {
+				return vaultmux.WrapError(b.Name(), operation, itemName, vaultmux.ErrDisabled)
+			}
 			return vaultmux.WrapError(b.Name(), operation, itemName,
 				fmt.Errorf("permission denied - check Azure RBAC permissions: %w", err))
 
@@ -390,29 +723,42 @@ func (b *Backend) handleAzureError(err error, operation, itemName string) error
 }
 
 // Location management stubs (Azure doesn't have native "folders" like 1Password vaults).
-// These operations are not supported and return ErrNotSupported.
+// These operations are not supported and return a NotSupportedError.
 // Could be implemented using tags in the future, but not currently supported.
 
 func (b *Backend) ListLocations(ctx context.Context, session vaultmux.Session) ([]string, error) {
-	return nil, vaultmux.ErrNotSupported
+	return nil, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "ListLocations"}
 }
 
 func (b *Backend) LocationExists(ctx context.Context, name string, session vaultmux.Session) (bool, error) {
-	return false, vaultmux.ErrNotSupported
+	return false, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "LocationExists"}
 }
 
 func (b *Backend) CreateLocation(ctx context.Context, name string, session vaultmux.Session) error {
-	return vaultmux.ErrNotSupported
+	return &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "CreateLocation"}
 }
 
 func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue string, session vaultmux.Session) ([]*vaultmux.Item, error) {
-	return nil, vaultmux.ErrNotSupported
+	return nil, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "ListItemsInLocation"}
+}
+
+// validateOptions checks options as New does. The credential and client
+// are built by Init, so New needs no Azure AD access.
+func validateOptions(options map[string]string) error {
+	_, err := New(options, "")
+	return err
 }
 
 // init registers the Azure Key Vault backend with vaultmux.
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendAzureKeyVault,
 		func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-			return New(cfg.Options, cfg.SessionFile)
+			b, err := New(cfg.Options, cfg.SessionFile)
+			if err != nil {
+				return nil, err
+			}
+			b.initTimeout = cfg.InitTimeout
+			return b, nil
 		})
+	vaultmux.RegisterConfigValidator(vaultmux.BackendAzureKeyVault, validateOptions)
 }
@@ -2,6 +2,7 @@ package azurekeyvault
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -278,3 +279,70 @@ func TestIntegration_Pagination(t *testing.T) {
 
 	t.Logf("Successfully listed %d total items, including %d created items", len(items), foundCount)
 }
+
+// TestIntegration_DisabledSecret disables a secret and checks that it is
+// still listed, marked disabled, and that reading it returns ErrDisabled.
+func TestIntegration_DisabledSecret(t *testing.T) {
+	vaultURL := os.Getenv("AZURE_VAULT_URL")
+	if vaultURL == "" {
+		t.Skip("AZURE_VAULT_URL not set - skipping disabled secret test")
+	}
+
+	ctx := context.Background()
+
+	backend, err := New(map[string]string{
+		"vault_url": vaultURL,
+		"prefix":    "vaultmux-test-",
+	}, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = backend.Close() }()
+
+	if initErr := backend.Init(ctx); initErr != nil {
+		t.Fatalf("Init() error = %v", initErr)
+	}
+
+	session, err := backend.Authenticate(ctx)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	itemName := fmt.Sprintf("disabled-test-%d", os.Getpid())
+	if err := backend.CreateItem(ctx, itemName, "value", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	defer func() { _ = backend.DeleteItem(ctx, itemName, session) }()
+
+	if err := backend.SetEnabled(ctx, itemName, false, session); err != nil {
+		t.Fatalf("SetEnabled(false) error = %v", err)
+	}
+
+	items, err := backend.ListItems(ctx, session)
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	var found bool
+	for _, item := range items {
+		if item.Name == itemName {
+			found = true
+			if !item.Disabled {
+				t.Errorf("ListItems() item %s Disabled = false, want true", itemName)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("ListItems() did not include disabled secret %s", itemName)
+	}
+
+	if _, err := backend.GetItem(ctx, itemName, session); !errors.Is(err, vaultmux.ErrDisabled) {
+		t.Errorf("GetItem() on disabled secret error = %v, want ErrDisabled", err)
+	}
+
+	if err := backend.SetEnabled(ctx, itemName, true, session); err != nil {
+		t.Fatalf("SetEnabled(true) error = %v", err)
+	}
+	if notes, err := backend.GetNotes(ctx, itemName, session); err != nil || notes != "value" {
+		t.Errorf("GetNotes() after enabling = %q, %v; want value", notes, err)
+	}
+}
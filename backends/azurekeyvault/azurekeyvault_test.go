@@ -3,11 +3,58 @@ package azurekeyvault
 import (
 	"context"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+
 	"github.com/blackwell-systems/vaultmux"
 )
 
+func TestBackend_ProxyURL(t *testing.T) {
+	// The stub proxy records the absolute URLs it is asked to fetch
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	backend, err := New(map[string]string{
+		"vault_url": "https://myvault.vault.azure.net/",
+		"proxy_url": proxy.URL,
+	}, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	transport := backend.clientOptions().Transport
+	if transport == nil {
+		t.Fatal("clientOptions().Transport = nil, want proxying client")
+	}
+
+	target := "http://myvault.vault.azure.net/secrets?api-version=7.4"
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	resp, err := transport.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(proxied) != 1 || proxied[0] != target {
+		t.Errorf("proxy saw %v, want [%s]", proxied, target)
+	}
+
+	// Without proxy_url the SDK's default transport is used
+	direct, _ := New(map[string]string{"vault_url": "https://myvault.vault.azure.net/"}, "")
+	if direct.clientOptions().Transport != nil {
+		t.Error("clientOptions().Transport set without proxy_url")
+	}
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -22,7 +69,7 @@ func TestNew(t *testing.T) {
 				"prefix": "test-",
 			},
 			wantErr:   true,
-			errString: "vault_url is required",
+			errString: "invalid vault_url: required",
 		},
 		{
 			name: "invalid vault_url format",
@@ -30,7 +77,7 @@ func TestNew(t *testing.T) {
 				"vault_url": "http://invalid.com",
 			},
 			wantErr:   true,
-			errString: "vault_url must be in format",
+			errString: "invalid vault_url: must be in format",
 		},
 		{
 			name: "defaults",
@@ -53,6 +100,36 @@ func TestNew(t *testing.T) {
 				prefix:   "myapp-",
 			},
 		},
+		{
+			name: "skip connectivity check",
+			options: map[string]string{
+				"vault_url":               "https://myvault.vault.azure.net/",
+				"skip_connectivity_check": "true",
+			},
+			want: &Backend{
+				vaultURL:              "https://myvault.vault.azure.net/",
+				prefix:                "vaultmux-",
+				skipConnectivityCheck: true,
+			},
+		},
+		{
+			name: "invalid proxy scheme",
+			options: map[string]string{
+				"vault_url": "https://myvault.vault.azure.net/",
+				"proxy_url": "ftp://proxy.internal",
+			},
+			wantErr:   true,
+			errString: "invalid proxy_url",
+		},
+		{
+			name: "invalid skip connectivity check",
+			options: map[string]string{
+				"vault_url":               "https://myvault.vault.azure.net/",
+				"skip_connectivity_check": "sometimes",
+			},
+			wantErr:   true,
+			errString: "invalid skip_connectivity_check",
+		},
 	}
 
 	for _, tt := range tests {
@@ -67,6 +144,9 @@ func TestNew(t *testing.T) {
 				if err.Error() == "" || !contains(err.Error(), tt.errString) {
 					t.Errorf("New() error = %q, want error containing %q", err.Error(), tt.errString)
 				}
+				if !errors.Is(err, vaultmux.ErrInvalidConfig) {
+					t.Errorf("New() error = %v, want ErrInvalidConfig", err)
+				}
 				return
 			}
 
@@ -80,6 +160,34 @@ func TestNew(t *testing.T) {
 			if got.prefix != tt.want.prefix {
 				t.Errorf("prefix = %q, want %q", got.prefix, tt.want.prefix)
 			}
+			if got.skipConnectivityCheck != tt.want.skipConnectivityCheck {
+				t.Errorf("skipConnectivityCheck = %v, want %v", got.skipConnectivityCheck, tt.want.skipConnectivityCheck)
+			}
+		})
+	}
+}
+
+func TestValidVaultURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://myvault.vault.azure.net/", true},
+		{"https://myhsm.managedhsm.azure.net/", true},
+		{"https://myvault.vault.usgovcloudapi.net/", true},
+		{"https://myvault.vault.azure.cn/", true},
+		{"http://myvault.vault.azure.net/", false},
+		{"https://myvault.example.com/", false},
+		{"https://.vault.azure.net/", false},
+		{"https://evil.com/x.vault.azure.net/", false},
+		{"https://myvault.vault.azure.net", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := validVaultURL(tt.url); got != tt.want {
+				t.Errorf("validVaultURL(%q) = %v, want %v", tt.url, got, tt.want)
+			}
 		})
 	}
 }
@@ -142,6 +250,10 @@ func TestBackend_LocationManagement(t *testing.T) {
 		if !errors.Is(err, vaultmux.ErrNotSupported) {
 			t.Errorf("ListLocations() error = %v, want ErrNotSupported", err)
 		}
+		var notSupported *vaultmux.NotSupportedError
+		if !errors.As(err, &notSupported) || notSupported.Backend != "azurekeyvault" || notSupported.Operation != "ListLocations" {
+			t.Errorf("ListLocations() error = %#v, want NotSupportedError for azurekeyvault ListLocations", err)
+		}
 	})
 
 	t.Run("LocationExists", func(t *testing.T) {
@@ -261,6 +373,29 @@ func TestSession_IsValid(t *testing.T) {
 
 func TestBackend_InterfaceCompliance(t *testing.T) {
 	var _ vaultmux.Backend = (*Backend)(nil)
+	var _ vaultmux.ItemSetter = (*Backend)(nil)
+	var _ vaultmux.ItemNameLister = (*Backend)(nil)
+	var _ vaultmux.RawItemAccessor = (*Backend)(nil)
+	var _ vaultmux.Describer = (*Backend)(nil)
+	var _ vaultmux.LimitsReporter = (*Backend)(nil)
+	var _ vaultmux.CaseSensitivity = (*Backend)(nil)
+}
+
+func TestBackend_HandleAzureError_Disabled(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://myvault.vault.azure.net/secrets/vaultmux-db/", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{},
+		Request:    req,
+		Body: io.NopCloser(strings.NewReader(
+			`{"error":{"code":"Forbidden","message":"Operation get is not allowed on a disabled secret.","innererror":{"code":"SecretDisabled"}}}`)),
+	}
+
+	b := &Backend{}
+	err := b.handleAzureError(runtime.NewResponseError(resp), "get", "db")
+	if !errors.Is(err, vaultmux.ErrDisabled) {
+		t.Errorf("handleAzureError() = %v, want ErrDisabled", err)
+	}
 }
 
 // Helper functions
@@ -289,3 +424,15 @@ func containsHelper(s, substr string) bool {
 //
 // Alternatively, use mocked SDK for offline testing.
 // See azurekeyvault_integration_test.go for full CRUD integration tests.
+
+func TestNew_ConfigErrorField(t *testing.T) {
+	_, err := New(map[string]string{"prefix": "app-"}, "")
+
+	var cfgErr *vaultmux.ConfigError
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("New() error = %v, want *vaultmux.ConfigError", err)
+	}
+	if cfgErr.Field != "vault_url" {
+		t.Errorf("ConfigError.Field = %q, want vault_url", cfgErr.Field)
+	}
+}
@@ -4,7 +4,10 @@ package bitwarden
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,12 +15,33 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/blackwell-systems/vaultmux"
 )
 
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendBitwarden, func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-		return New(cfg.Options, cfg.SessionFile)
+		b, err := New(cfg.Options, cfg.SessionFile)
+		if err != nil {
+			return nil, err
+		}
+		b.observer = cfg.Observer
+		b.logger = cfg.Logger
+		b.scrubOnClose = cfg.ScrubSessionOnClose
+		b.prompt = cfg.PasswordPrompt
+		if cfg.SessionStore != nil {
+			// Accounts default to the session file so distinct files stay distinct
+			account := cfg.Options["account"]
+			if account == "" {
+				account = b.sessionFile
+			}
+			b.cache = cfg.SessionStore.Cache("bitwarden", account, b.sessionFile, 30*time.Minute)
+		}
+		if cfg.Clock != nil {
+			b.setClock(cfg.Clock)
+		}
+		return b, nil
 	})
 }
 
@@ -26,6 +50,7 @@ type statusCache struct {
 	authenticated bool
 	timestamp     time.Time
 	mu            sync.RWMutex
+	clock         vaultmux.Clock // Time source (nil means time.Now)
 }
 
 // get returns the cached status if still valid (within TTL).
@@ -33,7 +58,7 @@ func (s *statusCache) get(ttl time.Duration) (bool, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if time.Since(s.timestamp) < ttl {
+	if s.now().Sub(s.timestamp) < ttl {
 		return s.authenticated, true // cached result is valid
 	}
 	return false, false // cache expired
@@ -45,14 +70,54 @@ func (s *statusCache) set(authenticated bool) {
 	defer s.mu.Unlock()
 
 	s.authenticated = authenticated
-	s.timestamp = time.Now()
+	s.timestamp = s.now()
+}
+
+// now returns the current time from the cache's clock.
+func (s *statusCache) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// runFunc executes a CLI command and returns its stdout.
+// A nil env inherits the current process environment.
+type runFunc func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error)
+
+// execRun runs the command as a subprocess.
+func execRun(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.Stdin = stdin
+	return cmd.Output()
+}
+
+// command runs a bw command through b.run and reports it to the observer.
+func (b *Backend) command(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	start := time.Now()
+	out, err := b.run(ctx, env, stdin, name, args...)
+	vaultmux.ReportSubprocess(b.observer, b.Name(), name, args, start, err)
+	return out, err
 }
 
 // Backend implements vaultmux.Backend for Bitwarden CLI.
 type Backend struct {
-	sessionFile string
-	cache       *vaultmux.SessionCache
-	statusCache statusCache // Caches IsAuthenticated results
+	sessionFile  string
+	cache        *vaultmux.SessionCache
+	scrubOnClose bool              // Close clears the cached session
+	statusCache  statusCache       // Caches IsAuthenticated results
+	run          runFunc           // Executes bw commands (replaced in tests)
+	observer     vaultmux.Observer // Receives subprocess events (optional)
+	logger       *slog.Logger      // Receives slow sync warnings (optional)
+	syncMu       sync.Mutex
+	syncing      *sharedSync        // The bw sync in flight, if any
+	reauthGroup  singleflight.Group // Shares one unlock between locked commands
+	identityMu   sync.Mutex
+	fingerprint  string // Logged-in account, once bw status has reported one
+
+	// Supplies the master password to bw unlock (optional; nil prompts on the terminal)
+	prompt func(ctx context.Context) (string, error)
 }
 
 // New creates a new Bitwarden backend.
@@ -65,12 +130,32 @@ func New(opts map[string]string, sessionFile string) (*Backend, error) {
 	return &Backend{
 		sessionFile: sessionFile,
 		cache:       vaultmux.NewSessionCache(sessionFile, 30*time.Minute),
+		run:         execRun,
 	}, nil
 }
 
+// setClock sets the time source for session and status caching.
+func (b *Backend) setClock(clock vaultmux.Clock) {
+	b.statusCache.clock = clock
+	b.cache.SetClock(clock)
+}
+
 // Name returns the backend name.
 func (b *Backend) Name() string { return "bitwarden" }
 
+// AuthEnvVars returns the environment variables bw reads for the session
+// token, API key login and its data directory.
+func (b *Backend) AuthEnvVars() []string {
+	return []string{"BW_SESSION", "BW_CLIENTID", "BW_CLIENTSECRET", "BITWARDENCLI_APPDATA_DIR"}
+}
+
+// Limits reports Bitwarden's 10,000 character limit on notes.
+func (b *Backend) Limits() vaultmux.Limits {
+	limits := vaultmux.DefaultLimits
+	limits.MaxSecretBytes = 10000
+	return limits
+}
+
 // Init checks if the Bitwarden CLI is installed.
 func (b *Backend) Init(ctx context.Context) error {
 	if _, err := exec.LookPath("bw"); err != nil {
@@ -79,8 +164,26 @@ func (b *Backend) Init(ctx context.Context) error {
 	return nil
 }
 
-// Close is a no-op for Bitwarden.
-func (b *Backend) Close() error { return nil }
+// Close clears the cached session if Config.ScrubSessionOnClose was set,
+// and is otherwise a no-op.
+func (b *Backend) Close() error {
+	if b.scrubOnClose {
+		return b.cache.Clear()
+	}
+	return nil
+}
+
+// Lock runs bw lock and clears the cached session and status, so the next
+// operation has to unlock again. The caches are cleared even if bw lock
+// fails.
+func (b *Backend) Lock(ctx context.Context) error {
+	_, lockErr := b.command(ctx, nil, nil, "bw", "lock")
+	b.statusCache.set(false)
+	if err := b.cache.Clear(); err != nil {
+		return vaultmux.WrapError("bitwarden", "lock", "", err)
+	}
+	return vaultmux.WrapError("bitwarden", "lock", "", lockErr)
+}
 
 // IsAuthenticated checks if there's a valid session.
 // Results are cached for 5 seconds to reduce subprocess overhead.
@@ -90,7 +193,8 @@ func (b *Backend) IsAuthenticated(ctx context.Context) bool {
 		return result
 	}
 
-	// Try loading cached session
+	// Try loading cached session, checked against the logged-in account
+	b.identify(ctx)
 	cached, err := b.cache.Load()
 	if err != nil || cached == nil {
 		b.statusCache.set(false)
@@ -98,9 +202,8 @@ func (b *Backend) IsAuthenticated(ctx context.Context) bool {
 	}
 
 	// Verify with bw status
-	cmd := exec.CommandContext(ctx, "bw", "unlock", "--check")
-	cmd.Env = append(os.Environ(), "BW_SESSION="+cached.Token)
-	authenticated := cmd.Run() == nil
+	_, err = b.command(ctx, tokenEnv(cached.Token), nil, "bw", "unlock", "--check")
+	authenticated := err == nil
 
 	// Cache the result
 	b.statusCache.set(authenticated)
@@ -109,39 +212,33 @@ func (b *Backend) IsAuthenticated(ctx context.Context) bool {
 
 // Authenticate unlocks the Bitwarden vault and returns a session.
 func (b *Backend) Authenticate(ctx context.Context) (vaultmux.Session, error) {
+	// The logged-in account fingerprints the session cache, so a session
+	// cached for another account is not reused.
+	status := b.identify(ctx)
+
 	// Try cached session first
 	if cached, err := b.cache.Load(); err == nil && cached != nil {
 		sess := &bwSession{token: cached.Token, backend: b}
 		if sess.IsValid(ctx) {
 			return sess, nil
 		}
+		// The session is dead, possibly because another account logged in
+		b.forgetIdentity()
+		status = nil
 	}
 
-	// Check login status
-	cmd := exec.CommandContext(ctx, "bw", "status")
-	out, _ := cmd.Output()
-
-	var status struct {
-		Status string `json:"status"`
+	if status == nil {
+		status = b.identify(ctx)
 	}
-	_ = json.Unmarshal(out, &status)
-
 	if status.Status == "unauthenticated" {
 		return nil, fmt.Errorf("not logged in to Bitwarden - run: bw login")
 	}
 
-	// Unlock and get session
-	cmd = exec.CommandContext(ctx, "bw", "unlock", "--raw")
-	cmd.Stdin = os.Stdin
-	cmd.Stderr = os.Stderr
-
-	out, err := cmd.Output()
+	token, err := b.unlock(ctx)
 	if err != nil {
 		return nil, vaultmux.WrapError("bitwarden", "authenticate", "", err)
 	}
 
-	token := strings.TrimSpace(string(out))
-
 	// Cache the session
 	_ = b.cache.Save(token, "bitwarden")
 
@@ -151,11 +248,217 @@ func (b *Backend) Authenticate(ctx context.Context) (vaultmux.Session, error) {
 	return &bwSession{token: token, backend: b}, nil
 }
 
+// bwStatus is the part of bw status output the backend uses.
+type bwStatus struct {
+	Status    string `json:"status"`
+	UserEmail string `json:"userEmail"`
+	ServerURL string `json:"serverUrl"`
+}
+
+// identify fingerprints the session cache with the logged-in account. bw
+// status only runs while no account is known; the result is returned when
+// it does, and nil is returned when the fingerprint was already cached.
+func (b *Backend) identify(ctx context.Context) *bwStatus {
+	b.identityMu.Lock()
+	defer b.identityMu.Unlock()
+
+	if b.fingerprint != "" {
+		return nil
+	}
+
+	var status bwStatus
+	out, _ := b.command(ctx, nil, nil, "bw", "status")
+	_ = json.Unmarshal(out, &status)
+
+	if status.UserEmail != "" {
+		b.fingerprint = vaultmux.CredentialFingerprint(status.UserEmail, status.ServerURL)
+		b.cache.SetFingerprint(b.fingerprint)
+	}
+	return &status
+}
+
+// forgetIdentity drops the cached account fingerprint, so the next identify
+// asks bw status again.
+func (b *Backend) forgetIdentity() {
+	b.identityMu.Lock()
+	defer b.identityMu.Unlock()
+	b.fingerprint = ""
+}
+
+// unlock runs bw unlock and returns the new session token. With a password
+// prompt the password is passed through the environment; otherwise bw
+// prompts on the terminal.
+func (b *Backend) unlock(ctx context.Context) (string, error) {
+	if b.prompt != nil {
+		password, err := b.prompt(ctx)
+		if err != nil {
+			return "", err
+		}
+		env := append(os.Environ(), "BW_PASSWORD="+password)
+		out, err := b.command(ctx, env, nil, "bw", "unlock", "--raw", "--passenv", "BW_PASSWORD")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	// Interactive, so run directly against the terminal
+	cmd := exec.CommandContext(ctx, "bw", "unlock", "--raw")
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Reauthenticate unlocks the vault again and refreshes session with the
+// new token, for a session that stopped working because the vault locked.
+// The cached session is discarded first, since it holds the stale token.
+// Concurrent calls for the same stale token share a single unlock.
+func (b *Backend) Reauthenticate(ctx context.Context, session vaultmux.Session) error {
+	return b.reauthenticate(ctx, session, session.Token())
+}
+
+// reauthenticate refreshes session, which failed with the token stale. It
+// does nothing if session no longer holds stale, because a concurrent
+// command already refreshed it.
+func (b *Backend) reauthenticate(ctx context.Context, session vaultmux.Session, stale string) error {
+	if session.Token() != stale {
+		return nil
+	}
+
+	_, err, _ := b.reauthGroup.Do(stale, func() (interface{}, error) {
+		if session.Token() != stale {
+			return nil, nil // Refreshed by an unlock that finished just now
+		}
+		if err := b.cache.Clear(); err != nil {
+			return nil, vaultmux.WrapError("bitwarden", "authenticate", "", err)
+		}
+		b.statusCache.set(false)
+		return nil, session.Refresh(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Another session holding the same token shared the unlock; refreshing
+	// it picks up the newly cached session without prompting again
+	if session.Token() == stale {
+		return session.Refresh(ctx)
+	}
+	return nil
+}
+
+// sessionCommand runs a bw command with the session token. If bw reports
+// the vault is locked, it reauthenticates and retries the command once.
+func (b *Backend) sessionCommand(ctx context.Context, session vaultmux.Session, args ...string) ([]byte, error) {
+	token := session.Token()
+	out, err := b.command(ctx, tokenEnv(token), nil, "bw", args...)
+	if err == nil || !isLocked(out, err) {
+		return out, err
+	}
+
+	if reauthErr := b.reauthenticate(ctx, session, token); reauthErr != nil {
+		return out, err
+	}
+	return b.command(ctx, sessionEnv(session), nil, "bw", args...)
+}
+
+// isLocked reports whether a failed bw command says the vault is locked.
+func isLocked(out []byte, err error) bool {
+	return outputContains(out, err, "Vault is locked")
+}
+
+// outputContains reports whether a failed bw command printed msg, on stdout
+// or on the stderr captured in its *exec.ExitError. bw writes most errors
+// to stderr.
+func outputContains(out []byte, err error, msg string) bool {
+	if strings.Contains(string(out), msg) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), msg)
+}
+
+// slowSyncThreshold is how long bw sync may run before a warning is logged.
+const slowSyncThreshold = 10 * time.Second
+
+// sharedSync is a bw sync shared by concurrent Sync calls.
+type sharedSync struct {
+	cancel  context.CancelFunc // Kills bw sync
+	waiters int                // Calls still waiting, guarded by Backend.syncMu
+	done    chan struct{}      // Closed when bw sync has exited
+	err     error              // Set before done is closed
+}
+
 // Sync synchronizes the vault with the server.
+// Concurrent calls share a single bw sync. Each caller returns as soon as
+// its own context is done; the sync keeps running for the callers still
+// waiting and is killed once all of them have cancelled.
 func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
-	cmd := exec.CommandContext(ctx, "bw", "sync")
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	if err := cmd.Run(); err != nil {
+	b.syncMu.Lock()
+	run := b.syncing
+	if run == nil {
+		syncCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		run = &sharedSync{cancel: cancel, done: make(chan struct{})}
+		b.syncing = run
+		go func() {
+			defer cancel()
+			run.err = b.runSync(syncCtx, session)
+			b.syncMu.Lock()
+			if b.syncing == run {
+				b.syncing = nil
+			}
+			b.syncMu.Unlock()
+			close(run.done)
+		}()
+	}
+	run.waiters++
+	b.syncMu.Unlock()
+
+	select {
+	case <-run.done:
+		b.leaveSync(run)
+		return run.err
+	case <-ctx.Done():
+		b.leaveSync(run)
+		return vaultmux.WrapError("bitwarden", "sync", "", ctx.Err())
+	}
+}
+
+// leaveSync drops a waiter from run, killing the sync when it was the last
+// one. A later Sync call then starts a new one.
+func (b *Backend) leaveSync(run *sharedSync) {
+	b.syncMu.Lock()
+	defer b.syncMu.Unlock()
+	run.waiters--
+	if run.waiters == 0 {
+		run.cancel()
+		if b.syncing == run {
+			b.syncing = nil
+		}
+	}
+}
+
+// runSync runs bw sync, logging a warning if it exceeds slowSyncThreshold.
+func (b *Backend) runSync(ctx context.Context, session vaultmux.Session) error {
+	if b.logger != nil {
+		start := time.Now()
+		slow := time.AfterFunc(slowSyncThreshold, func() {
+			b.logger.WarnContext(ctx, "bw sync is taking longer than expected",
+				"backend", "bitwarden", "threshold", slowSyncThreshold)
+		})
+		defer func() {
+			if !slow.Stop() {
+				b.logger.InfoContext(ctx, "bw sync finished", "backend", "bitwarden", "duration", time.Since(start))
+			}
+		}()
+	}
+
+	if _, err := b.sessionCommand(ctx, session, "sync"); err != nil {
 		return vaultmux.WrapError("bitwarden", "sync", "", err)
 	}
 	return nil
@@ -166,15 +469,13 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 	if err := vaultmux.ValidateItemName(name); err != nil {
 		return nil, vaultmux.WrapError("bitwarden", "get", name, err)
 	}
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
 
-	cmd := exec.CommandContext(ctx, "bw", "get", "item", name)
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	out, err := cmd.Output()
+	out, err := b.getItemJSON(ctx, name, session)
 	if err != nil {
-		if strings.Contains(string(out), "Not found") {
-			return nil, vaultmux.ErrNotFound
-		}
-		return nil, vaultmux.WrapError("bitwarden", "get", name, err)
+		return nil, err
 	}
 
 	var bwItem struct {
@@ -184,29 +485,86 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 		Notes    string    `json:"notes"`
 		FolderID string    `json:"folderId"`
 		Created  time.Time `json:"revisionDate"`
+		Login    *struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			TOTP     string `json:"totp"`
+		} `json:"login"`
+		Fields []bwField `json:"fields"`
 	}
 
 	if err := json.Unmarshal(out, &bwItem); err != nil {
 		return nil, vaultmux.WrapError("bitwarden", "parse", name, err)
 	}
 
-	// Map Bitwarden type to vaultmux ItemType
-	itemType := vaultmux.ItemTypeSecureNote
-	if bwItem.Type >= 0 && bwItem.Type <= 4 {
-		itemType = vaultmux.ItemType(bwItem.Type)
+	fields := make(map[string]string)
+	if login := bwItem.Login; login != nil {
+		for key, value := range map[string]string{
+			FieldLoginUsername: login.Username,
+			FieldLoginPassword: login.Password,
+			FieldLoginTOTP:     login.TOTP,
+		} {
+			if value != "" {
+				fields[key] = value
+			}
+		}
+	}
+	for _, field := range bwItem.Fields {
+		if field.Value != nil {
+			fields[CustomFieldPrefix+field.Name] = *field.Value
+		}
+	}
+	if len(fields) == 0 {
+		fields = nil
 	}
 
 	return &vaultmux.Item{
 		ID:       bwItem.ID,
 		Name:     bwItem.Name,
-		Type:     itemType,
+		Type:     itemType(bwItem.Type),
 		Notes:    bwItem.Notes,
+		Fields:   fields,
 		Location: bwItem.FolderID,
 		Created:  bwItem.Created,
 		Modified: bwItem.Created,
 	}, nil
 }
 
+// Keys of login fields in Item.Fields. Custom fields are stored under
+// CustomFieldPrefix plus the field name, so they can't collide with these.
+const (
+	FieldLoginUsername = "login.username"
+	FieldLoginPassword = "login.password"
+	FieldLoginTOTP     = "login.totp"
+
+	CustomFieldPrefix = "custom."
+)
+
+// bwField is a custom field of a Bitwarden item. Value is null for linked
+// fields, which point at another field of the item.
+type bwField struct {
+	Name  string  `json:"name"`
+	Value *string `json:"value"`
+	Type  int     `json:"type"`
+}
+
+// GetField returns the value of the custom field fieldName of an item, as
+// named in Bitwarden. Hidden fields are returned in plain text. A missing
+// field returns an error wrapping vaultmux.ErrNotFound; login fields are in
+// GetItem's Item.Fields under FieldLoginUsername and friends.
+func (b *Backend) GetField(ctx context.Context, name, fieldName string, session vaultmux.Session) (string, error) {
+	item, err := b.GetItem(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	value, ok := item.Fields[CustomFieldPrefix+fieldName]
+	if !ok {
+		return "", vaultmux.WrapError("bitwarden", "get-field", name,
+			fmt.Errorf("%w: no field %q", vaultmux.ErrNotFound, fieldName))
+	}
+	return value, nil
+}
+
 // GetNotes retrieves just the notes field of an item.
 func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
 	item, err := b.GetItem(ctx, name, session)
@@ -233,9 +591,7 @@ func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.
 
 // ListItems lists all items in the vault.
 func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
-	cmd := exec.CommandContext(ctx, "bw", "list", "items")
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	out, err := cmd.Output()
+	out, err := b.sessionCommand(ctx, session, "list", "items")
 	if err != nil {
 		return nil, vaultmux.WrapError("bitwarden", "list", "", err)
 	}
@@ -253,14 +609,10 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 
 	items := make([]*vaultmux.Item, len(bwItems))
 	for i, bwItem := range bwItems {
-		itemType := vaultmux.ItemTypeSecureNote
-		if bwItem.Type >= 0 && bwItem.Type <= 4 {
-			itemType = vaultmux.ItemType(bwItem.Type)
-		}
 		items[i] = &vaultmux.Item{
 			ID:    bwItem.ID,
 			Name:  bwItem.Name,
-			Type:  itemType,
+			Type:  itemType(bwItem.Type),
 			Notes: bwItem.Notes,
 		}
 	}
@@ -268,12 +620,117 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 	return items, nil
 }
 
+// FindItems returns every item named name across all folders. Location is
+// the folder ID. Use it to pick an item by ID when GetItem returns
+// vaultmux.ErrAmbiguous.
+func (b *Backend) FindItems(ctx context.Context, name string, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	if err := vaultmux.ValidateItemName(name); err != nil {
+		return nil, vaultmux.WrapError("bitwarden", "find", name, err)
+	}
+
+	out, err := b.sessionCommand(ctx, session, "list", "items", "--search", name)
+	if err != nil {
+		return nil, vaultmux.WrapError("bitwarden", "find", name, err)
+	}
+
+	var bwItems []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Type     int    `json:"type"`
+		FolderID string `json:"folderId"`
+	}
+	if err := json.Unmarshal(out, &bwItems); err != nil {
+		return nil, vaultmux.WrapError("bitwarden", "parse-list", name, err)
+	}
+
+	// --search also matches substrings and other fields
+	var items []*vaultmux.Item
+	for _, bwItem := range bwItems {
+		if bwItem.Name != name && bwItem.ID != name {
+			continue
+		}
+		items = append(items, &vaultmux.Item{
+			ID:       bwItem.ID,
+			Name:     bwItem.Name,
+			Type:     itemType(bwItem.Type),
+			Location: bwItem.FolderID,
+		})
+	}
+
+	return items, nil
+}
+
 // CreateItem creates a new secure note.
 func (b *Backend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	return b.createItem(ctx, name, content, "", session)
+}
+
+// CreateItemInLocation creates a new secure note directly in a folder.
+// locType is ignored; locValue names the folder.
+func (b *Backend) CreateItemInLocation(ctx context.Context, name, content, locType, locValue string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
+	if err := vaultmux.ValidateLocationName(locValue); err != nil {
+		return vaultmux.WrapError("bitwarden", "create", locValue, err)
+	}
+
+	folderID, err := b.folderID(ctx, locValue, session)
+	if err != nil {
+		return err
+	}
+	if folderID == "" {
+		if !vaultmux.NewCreateOptions(opts...).CreateLocation {
+			return vaultmux.WrapError("bitwarden", "create", name, fmt.Errorf("folder %q: %w", locValue, vaultmux.ErrNotFound))
+		}
+		if err := b.CreateLocation(ctx, locValue, session); err != nil {
+			return err
+		}
+		if vaultmux.IsDryRun(ctx) {
+			// The folder wasn't created, so there's no ID to look up
+			return b.createItem(ctx, name, content, "", session)
+		}
+		if folderID, err = b.folderID(ctx, locValue, session); err != nil {
+			return err
+		}
+		if folderID == "" {
+			return vaultmux.WrapError("bitwarden", "create", name, fmt.Errorf("folder %q: %w", locValue, vaultmux.ErrNotFound))
+		}
+	}
+
+	return b.createItem(ctx, name, content, folderID, session)
+}
+
+// folderID returns the ID of the named folder, or "" if it doesn't exist.
+func (b *Backend) folderID(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	out, err := b.sessionCommand(ctx, session, "list", "folders")
+	if err != nil {
+		return "", vaultmux.WrapError("bitwarden", "list-folders", "", err)
+	}
+
+	var folders []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &folders); err != nil {
+		return "", vaultmux.WrapError("bitwarden", "parse-folders", "", err)
+	}
+
+	for _, folder := range folders {
+		if folder.Name == name {
+			return folder.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// createItem creates a secure note, in the given folder if folderID is non-empty.
+func (b *Backend) createItem(ctx context.Context, name, content, folderID string, session vaultmux.Session) error {
 	if err := vaultmux.ValidateItemName(name); err != nil {
 		return vaultmux.WrapError("bitwarden", "create", name, err)
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	// Create JSON template
 	template := map[string]interface{}{
 		"type":  2, // Secure note
@@ -283,21 +740,18 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, session
 			"type": 0, // Generic
 		},
 	}
-
-	jsonData, _ := json.Marshal(template)
+	if folderID != "" {
+		template["folderId"] = folderID
+	}
 
 	// Encode as base64 for bw
-	cmd := exec.CommandContext(ctx, "bw", "encode")
-	cmd.Stdin = strings.NewReader(string(jsonData))
-	encoded, err := cmd.Output()
+	encoded, err := b.encode(ctx, template)
 	if err != nil {
 		return vaultmux.WrapError("bitwarden", "encode", name, err)
 	}
 
 	// Create item
-	cmd = exec.CommandContext(ctx, "bw", "create", "item", strings.TrimSpace(string(encoded)))
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	if err := cmd.Run(); err != nil {
+	if _, err := b.sessionCommand(ctx, session, "create", "item", encoded); err != nil {
 		return vaultmux.WrapError("bitwarden", "create", name, err)
 	}
 
@@ -312,6 +766,10 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 		return err
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	// Update notes field
 	template := map[string]interface{}{
 		"type":  item.Type,
@@ -319,20 +777,14 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 		"notes": content,
 	}
 
-	jsonData, _ := json.Marshal(template)
-
 	// Encode
-	cmd := exec.CommandContext(ctx, "bw", "encode")
-	cmd.Stdin = strings.NewReader(string(jsonData))
-	encoded, err := cmd.Output()
+	encoded, err := b.encode(ctx, template)
 	if err != nil {
 		return vaultmux.WrapError("bitwarden", "encode", name, err)
 	}
 
 	// Edit item
-	cmd = exec.CommandContext(ctx, "bw", "edit", "item", item.ID, strings.TrimSpace(string(encoded)))
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	if err := cmd.Run(); err != nil {
+	if _, err := b.sessionCommand(ctx, session, "edit", "item", item.ID, encoded); err != nil {
 		return vaultmux.WrapError("bitwarden", "update", name, err)
 	}
 
@@ -347,9 +799,11 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "bw", "delete", "item", item.ID)
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	if err := cmd.Run(); err != nil {
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	if _, err := b.sessionCommand(ctx, session, "delete", "item", item.ID); err != nil {
 		return vaultmux.WrapError("bitwarden", "delete", name, err)
 	}
 
@@ -358,9 +812,7 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 
 // ListLocations lists folders.
 func (b *Backend) ListLocations(ctx context.Context, session vaultmux.Session) ([]string, error) {
-	cmd := exec.CommandContext(ctx, "bw", "list", "folders")
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	out, err := cmd.Output()
+	out, err := b.sessionCommand(ctx, session, "list", "folders")
 	if err != nil {
 		return nil, vaultmux.WrapError("bitwarden", "list-folders", "", err)
 	}
@@ -403,21 +855,19 @@ func (b *Backend) CreateLocation(ctx context.Context, name string, session vault
 		return vaultmux.WrapError("bitwarden", "create-folder", name, err)
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	template := map[string]interface{}{
 		"name": name,
 	}
-	jsonData, _ := json.Marshal(template)
-
-	cmd := exec.CommandContext(ctx, "bw", "encode")
-	cmd.Stdin = strings.NewReader(string(jsonData))
-	encoded, err := cmd.Output()
+	encoded, err := b.encode(ctx, template)
 	if err != nil {
 		return vaultmux.WrapError("bitwarden", "encode-folder", name, err)
 	}
 
-	cmd = exec.CommandContext(ctx, "bw", "create", "folder", strings.TrimSpace(string(encoded)))
-	cmd.Env = append(os.Environ(), "BW_SESSION="+session.Token())
-	if err := cmd.Run(); err != nil {
+	if _, err := b.sessionCommand(ctx, session, "create", "folder", encoded); err != nil {
 		return vaultmux.WrapError("bitwarden", "create-folder", name, err)
 	}
 
@@ -442,18 +892,186 @@ func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue str
 	return items, nil
 }
 
+// GetPassword retrieves the login.password field of a login item.
+func (b *Backend) GetPassword(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	if err := vaultmux.ValidateItemName(name); err != nil {
+		return "", vaultmux.WrapError("bitwarden", "get-password", name, err)
+	}
+
+	out, err := b.getItemJSON(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+
+	var bwItem struct {
+		Login *struct {
+			Password string `json:"password"`
+		} `json:"login"`
+	}
+	if err := json.Unmarshal(out, &bwItem); err != nil {
+		return "", vaultmux.WrapError("bitwarden", "parse", name, err)
+	}
+	if bwItem.Login == nil {
+		return "", vaultmux.WrapError("bitwarden", "get-password", name, errNotLoginItem)
+	}
+
+	return bwItem.Login.Password, nil
+}
+
+// SetPassword sets the login.password field of a login item.
+// If the item doesn't exist, a new login item is created.
+func (b *Backend) SetPassword(ctx context.Context, name, password string, session vaultmux.Session) error {
+	if err := vaultmux.ValidateItemName(name); err != nil {
+		return vaultmux.WrapError("bitwarden", "set-password", name, err)
+	}
+
+	out, err := b.getItemJSON(ctx, name, session)
+	if errors.Is(err, vaultmux.ErrNotFound) {
+		if vaultmux.IsDryRun(ctx) {
+			return nil
+		}
+
+		template := map[string]interface{}{
+			"type": bwTypeLogin,
+			"name": name,
+			"login": map[string]interface{}{
+				"password": password,
+			},
+		}
+
+		encoded, err := b.encode(ctx, template)
+		if err != nil {
+			return vaultmux.WrapError("bitwarden", "encode", name, err)
+		}
+		if _, err := b.sessionCommand(ctx, session, "create", "item", encoded); err != nil {
+			return vaultmux.WrapError("bitwarden", "set-password", name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Edit the full item JSON so unrelated fields are preserved
+	var raw map[string]interface{}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return vaultmux.WrapError("bitwarden", "parse", name, err)
+	}
+	if itemType, _ := raw["type"].(float64); int(itemType) != bwTypeLogin {
+		return vaultmux.WrapError("bitwarden", "set-password", name, errNotLoginItem)
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	login, _ := raw["login"].(map[string]interface{})
+	if login == nil {
+		login = make(map[string]interface{})
+	}
+	login["password"] = password
+	raw["login"] = login
+
+	id, _ := raw["id"].(string)
+	encoded, err := b.encode(ctx, raw)
+	if err != nil {
+		return vaultmux.WrapError("bitwarden", "encode", name, err)
+	}
+	if _, err := b.sessionCommand(ctx, session, "edit", "item", id, encoded); err != nil {
+		return vaultmux.WrapError("bitwarden", "set-password", name, err)
+	}
+
+	return nil
+}
+
+// bwTypeLogin is Bitwarden's item type number for login items.
+const bwTypeLogin = 1
+
+// itemType maps a Bitwarden item type number to a vaultmux.ItemType.
+// Unknown types are reported as secure notes.
+func itemType(bwType int) vaultmux.ItemType {
+	switch bwType {
+	case bwTypeLogin:
+		return vaultmux.ItemTypeLogin
+	case 3:
+		return vaultmux.ItemTypeCard
+	case 4:
+		return vaultmux.ItemTypeIdentity
+	case 5:
+		return vaultmux.ItemTypeSSHKey
+	default:
+		return vaultmux.ItemTypeSecureNote
+	}
+}
+
+// errNotLoginItem indicates a password operation on a non-login item.
+var errNotLoginItem = errors.New("not a login item")
+
+// getItemJSON returns the raw JSON for an item, mapping "Not found" to ErrNotFound.
+func (b *Backend) getItemJSON(ctx context.Context, name string, session vaultmux.Session) ([]byte, error) {
+	out, err := b.sessionCommand(ctx, session, "get", "item", name)
+	if err != nil {
+		if outputContains(out, err, "Not found") {
+			return nil, vaultmux.ErrNotFound
+		}
+		if outputContains(out, err, "More than one result was found") {
+			return nil, b.ambiguousError(ctx, name, session)
+		}
+		return nil, vaultmux.WrapError("bitwarden", "get", name, err)
+	}
+	return out, nil
+}
+
+// ambiguousError builds the error returned when name matches several
+// items, listing the candidates.
+func (b *Backend) ambiguousError(ctx context.Context, name string, session vaultmux.Session) error {
+	candidates, err := b.FindItems(ctx, name, session)
+	if err != nil {
+		return err
+	}
+	return vaultmux.WrapError("bitwarden", "get", name, &vaultmux.AmbiguousError{Name: name, Candidates: candidates})
+}
+
+// encode marshals v to JSON and base64-encodes it with bw encode.
+func (b *Backend) encode(ctx context.Context, v interface{}) (string, error) {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := b.command(ctx, nil, strings.NewReader(string(jsonData)), "bw", "encode")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(encoded)), nil
+}
+
+// sessionEnv returns environment with the session token set.
+func sessionEnv(session vaultmux.Session) []string {
+	return tokenEnv(session.Token())
+}
+
+// tokenEnv returns environment with BW_SESSION set to token.
+func tokenEnv(token string) []string {
+	return append(os.Environ(), "BW_SESSION="+token)
+}
+
 // bwSession implements vaultmux.Session for Bitwarden.
 type bwSession struct {
+	mu      sync.RWMutex // Guards token, replaced by Refresh
 	token   string
 	backend *Backend
 }
 
-func (s *bwSession) Token() string { return s.token }
+func (s *bwSession) Token() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token
+}
 
 func (s *bwSession) IsValid(ctx context.Context) bool {
-	cmd := exec.CommandContext(ctx, "bw", "unlock", "--check")
-	cmd.Env = append(os.Environ(), "BW_SESSION="+s.token)
-	return cmd.Run() == nil
+	_, err := s.backend.run(ctx, tokenEnv(s.Token()), nil, "bw", "unlock", "--check")
+	return err == nil
 }
 
 func (s *bwSession) Refresh(ctx context.Context) error {
@@ -462,7 +1080,9 @@ func (s *bwSession) Refresh(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	s.mu.Lock()
 	s.token = newSession.Token()
+	s.mu.Unlock()
 	return nil
 }
 
@@ -0,0 +1,103 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_Close_ScrubSession(t *testing.T) {
+	for _, scrub := range []bool{true, false} {
+		ctx := context.Background()
+		sessionFile := filepath.Join(t.TempDir(), ".bw-session")
+
+		vb, err := vaultmux.New(vaultmux.Config{
+			Backend:             vaultmux.BackendBitwarden,
+			SessionFile:         sessionFile,
+			ScrubSessionOnClose: scrub,
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		backend := vb.(*Backend)
+		backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+			if len(args) == 2 && args[0] == "unlock" && args[1] == "--check" {
+				return nil, nil
+			}
+			return nil, errors.New("unexpected command")
+		}
+
+		// Save the session as a successful unlock would, then authenticate from it
+		if err := backend.cache.Save("session-token", "bitwarden"); err != nil {
+			t.Fatalf("cache.Save() error = %v", err)
+		}
+		session, err := backend.Authenticate(ctx)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if session.Token() != "session-token" {
+			t.Errorf("Token() = %q, want cached token", session.Token())
+		}
+
+		if err := backend.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+
+		_, statErr := os.Stat(sessionFile)
+		if removed := os.IsNotExist(statErr); removed != scrub {
+			t.Errorf("ScrubSessionOnClose=%v: session file removed = %v", scrub, removed)
+		}
+	}
+}
+
+func TestBackend_Lock(t *testing.T) {
+	ctx := context.Background()
+	sessionFile := filepath.Join(t.TempDir(), ".bw-session")
+	backend, err := New(nil, sessionFile)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var locked bool
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case len(args) == 2 && args[0] == "unlock" && args[1] == "--check":
+			if locked {
+				return []byte("Vault is locked."), errors.New("exit status 1")
+			}
+			return nil, nil
+		case len(args) == 1 && args[0] == "lock":
+			locked = true
+			return []byte("Your vault is locked."), nil
+		}
+		return nil, errors.New("unexpected command")
+	}
+
+	if err := backend.cache.Save("session-token", "bitwarden"); err != nil {
+		t.Fatalf("cache.Save() error = %v", err)
+	}
+	if _, err := backend.Authenticate(ctx); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !backend.IsAuthenticated(ctx) {
+		t.Fatal("IsAuthenticated() = false after Authenticate()")
+	}
+
+	if err := vaultmux.Lock(ctx, backend); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if !locked {
+		t.Error("Lock() did not run bw lock")
+	}
+	if _, err := os.Stat(sessionFile); !os.IsNotExist(err) {
+		t.Errorf("session file still present after Lock(): %v", err)
+	}
+	if backend.IsAuthenticated(ctx) {
+		t.Error("IsAuthenticated() = true after Lock()")
+	}
+}
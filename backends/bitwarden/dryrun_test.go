@@ -0,0 +1,49 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_DryRun(t *testing.T) {
+	ctx := vaultmux.WithDryRun(context.Background())
+	fake := &fakeBW{items: map[string]map[string]interface{}{
+		"existing": {"id": "id-existing", "name": "existing", "type": 2, "notes": "old"},
+		"github":   {"id": "id-github", "name": "github", "type": 1, "login": map[string]interface{}{"password": "pw"}},
+	}}
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = fake.run
+
+	if err := backend.CreateItem(ctx, "new", "x", fakeSession{}); err != nil {
+		t.Errorf("CreateItem() error = %v", err)
+	}
+	if err := backend.UpdateItem(ctx, "existing", "x", fakeSession{}); err != nil {
+		t.Errorf("UpdateItem() error = %v", err)
+	}
+	if err := backend.DeleteItem(ctx, "existing", fakeSession{}); err != nil {
+		t.Errorf("DeleteItem() error = %v", err)
+	}
+	if err := backend.SetPassword(ctx, "github", "new-pw", fakeSession{}); err != nil {
+		t.Errorf("SetPassword() error = %v", err)
+	}
+	if err := backend.CreateItemInLocation(ctx, "key", "x", "folder", "prod", fakeSession{}, vaultmux.WithCreateLocation()); err != nil {
+		t.Errorf("CreateItemInLocation() error = %v", err)
+	}
+	if err := backend.DeleteItem(ctx, "missing", fakeSession{}); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("DeleteItem(missing) error = %v, want ErrNotFound", err)
+	}
+
+	for _, call := range fake.calls {
+		switch call[0] {
+		case "create", "edit", "delete":
+			t.Errorf("dry run executed mutating command %v", call)
+		}
+	}
+	if len(fake.items) != 2 || len(fake.folders) != 0 {
+		t.Errorf("vault changed: %d items, %d folders", len(fake.items), len(fake.folders))
+	}
+}
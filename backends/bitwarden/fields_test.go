@@ -0,0 +1,79 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_GetItem_Fields(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeBW{items: map[string]map[string]interface{}{
+		"github": {
+			"id":   "item-1",
+			"name": "github",
+			"type": 1,
+			"login": map[string]interface{}{
+				"username": "octocat",
+				"password": "hunter2",
+				"totp":     nil,
+			},
+			"fields": []map[string]interface{}{
+				{"name": "api-token", "value": "ghp_abc", "type": 1},
+				{"name": "org", "value": "blackwell-systems", "type": 0},
+				{"name": "linked", "value": nil, "type": 3, "linkedId": 100},
+			},
+		},
+	}}
+
+	backend, err := New(nil, t.TempDir()+"/session")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	backend.run = fake.run
+
+	item, err := backend.GetItem(ctx, "github", fakeSession{})
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Type != vaultmux.ItemTypeLogin {
+		t.Errorf("GetItem() type = %v, want %v", item.Type, vaultmux.ItemTypeLogin)
+	}
+
+	want := map[string]string{
+		"login.username":   "octocat",
+		"login.password":   "hunter2",
+		"custom.api-token": "ghp_abc",
+		"custom.org":       "blackwell-systems",
+	}
+	if !reflect.DeepEqual(item.Fields, want) {
+		t.Errorf("GetItem() fields = %v, want %v", item.Fields, want)
+	}
+
+	token, err := backend.GetField(ctx, "github", "api-token", fakeSession{})
+	if err != nil || token != "ghp_abc" {
+		t.Errorf("GetField(api-token) = %q, %v; want ghp_abc", token, err)
+	}
+	if _, err := backend.GetField(ctx, "github", "missing", fakeSession{}); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetField(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestItemType(t *testing.T) {
+	tests := map[int]vaultmux.ItemType{
+		1:  vaultmux.ItemTypeLogin,
+		2:  vaultmux.ItemTypeSecureNote,
+		3:  vaultmux.ItemTypeCard,
+		4:  vaultmux.ItemTypeIdentity,
+		5:  vaultmux.ItemTypeSSHKey,
+		99: vaultmux.ItemTypeSecureNote,
+	}
+	for bwType, want := range tests {
+		if got := itemType(bwType); got != want {
+			t.Errorf("itemType(%d) = %v, want %v", bwType, got, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_FindItems_DuplicateNames(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case len(args) == 4 && args[0] == "list" && args[2] == "--search":
+			return []byte(`[
+				{"id": "1", "name": "api-key", "type": 2, "folderId": "folder-personal"},
+				{"id": "2", "name": "api-key", "type": 2, "folderId": "folder-work"},
+				{"id": "3", "name": "api-key-old", "type": 2, "folderId": null}
+			]`), nil
+		case len(args) == 3 && args[0] == "get" && args[1] == "item":
+			return []byte("More than one result was found. Try getting a specific object by `id` instead."), errors.New("exit status 1")
+		}
+		return nil, errors.New("unexpected command")
+	}
+
+	found, err := backend.FindItems(ctx, "api-key", fakeSession{})
+	if err != nil {
+		t.Fatalf("FindItems() error = %v", err)
+	}
+	if len(found) != 2 || found[0].Location != "folder-personal" || found[1].Location != "folder-work" {
+		t.Errorf("FindItems() = %+v, want the two exact matches", found)
+	}
+
+	_, err = backend.GetItem(ctx, "api-key", fakeSession{})
+	var ambiguous *vaultmux.AmbiguousError
+	if !errors.Is(err, vaultmux.ErrAmbiguous) || !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Errorf("GetItem() error = %v, want AmbiguousError with 2 candidates", err)
+	}
+}
+
+func TestBackend_GetItem_AmbiguousOnStderr(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case len(args) == 4 && args[0] == "list" && args[2] == "--search":
+			return []byte(`[{"id": "1", "name": "api-key", "type": 2}, {"id": "2", "name": "api-key", "type": 2}]`), nil
+		case len(args) == 3 && args[0] == "get" && args[1] == "item" && args[2] == "api-key":
+			return nil, &exec.ExitError{Stderr: []byte("More than one result was found. Try getting a specific object by `id` instead.")}
+		case len(args) == 3 && args[0] == "get" && args[1] == "item":
+			return nil, &exec.ExitError{Stderr: []byte("Not found.")}
+		}
+		return nil, errors.New("unexpected command")
+	}
+
+	_, err := backend.GetItem(ctx, "api-key", fakeSession{})
+	var ambiguous *vaultmux.AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Errorf("GetItem() error = %v, want AmbiguousError with 2 candidates", err)
+	}
+	if _, err := backend.GetItem(ctx, "missing", fakeSession{}); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetItem(missing) error = %v, want ErrNotFound", err)
+	}
+}
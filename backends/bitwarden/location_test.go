@@ -0,0 +1,35 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_CreateItemInLocation(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeBW{items: make(map[string]map[string]interface{})}
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = fake.run
+
+	err := backend.CreateItemInLocation(ctx, "api-key", "secret", "folder", "work", fakeSession{})
+	if !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Fatalf("CreateItemInLocation() error = %v, want ErrNotFound", err)
+	}
+
+	err = backend.CreateItemInLocation(ctx, "api-key", "secret", "folder", "work", fakeSession{}, vaultmux.WithCreateLocation())
+	if err != nil {
+		t.Fatalf("CreateItemInLocation() error = %v", err)
+	}
+
+	item := fake.items["api-key"]
+	if item == nil {
+		t.Fatal("item not created")
+	}
+	if item["folderId"] != "folder-work" {
+		t.Errorf("item folderId = %v, want folder-work", item["folderId"])
+	}
+}
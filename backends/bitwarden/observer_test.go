@@ -0,0 +1,47 @@
+package bitwarden
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_ReportsSubprocessEvents(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeBW{items: map[string]map[string]interface{}{
+		"ssh-key": {"id": "1", "name": "ssh-key", "type": 2, "notes": "secret"},
+	}}
+
+	var events []vaultmux.SubprocessEvent
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		time.Sleep(time.Millisecond)
+		return fake.run(ctx, env, stdin, name, args...)
+	}
+	backend.observer = vaultmux.ObserverFunc(func(e vaultmux.Event) {
+		if se, ok := e.(vaultmux.SubprocessEvent); ok {
+			events = append(events, se)
+		}
+	})
+
+	if _, err := backend.GetItem(ctx, "ssh-key", fakeSession{}); err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("recorded %d subprocess events, want 1", len(events))
+	}
+	e := events[0]
+	if e.Backend != "bitwarden" || e.Command != "bw" || e.Subcommand != "get" {
+		t.Errorf("event = %+v, want bitwarden/bw/get", e)
+	}
+	if e.Duration <= 0 {
+		t.Errorf("event Duration = %v, want > 0", e.Duration)
+	}
+	if e.ExitCode != 0 {
+		t.Errorf("event ExitCode = %d, want 0", e.ExitCode)
+	}
+}
@@ -0,0 +1,143 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeBW simulates the bw CLI for a small vault.
+type fakeBW struct {
+	items   map[string]map[string]interface{} // keyed by name
+	folders []map[string]interface{}
+	calls   [][]string
+}
+
+func (f *fakeBW) run(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+
+	switch {
+	case len(args) == 1 && args[0] == "encode":
+		data, _ := io.ReadAll(stdin)
+		return []byte(base64.StdEncoding.EncodeToString(data) + "\n"), nil
+
+	case len(args) == 3 && args[0] == "get" && args[1] == "item":
+		item, ok := f.items[args[2]]
+		if !ok {
+			return []byte("Not found."), errors.New("exit status 1")
+		}
+		return json.Marshal(item)
+
+	case len(args) == 2 && args[0] == "list" && args[1] == "folders":
+		return json.Marshal(f.folders)
+
+	case len(args) == 3 && args[0] == "create" && args[1] == "folder":
+		folder, err := decodeItem(args[2])
+		if err != nil {
+			return nil, err
+		}
+		folder["id"] = "folder-" + folder["name"].(string)
+		f.folders = append(f.folders, folder)
+		return json.Marshal(folder)
+
+	case len(args) == 3 && args[0] == "create" && args[1] == "item":
+		item, err := decodeItem(args[2])
+		if err != nil {
+			return nil, err
+		}
+		item["id"] = "id-" + item["name"].(string)
+		f.items[item["name"].(string)] = item
+		return nil, nil
+
+	case len(args) == 4 && args[0] == "edit" && args[1] == "item":
+		item, err := decodeItem(args[3])
+		if err != nil {
+			return nil, err
+		}
+		f.items[item["name"].(string)] = item
+		return nil, nil
+	}
+
+	return nil, errors.New("unexpected command")
+}
+
+func decodeItem(encoded string) (map[string]interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var item map[string]interface{}
+	err = json.Unmarshal(data, &item)
+	return item, err
+}
+
+type fakeSession struct{}
+
+func (fakeSession) Token() string                     { return "token" }
+func (fakeSession) IsValid(ctx context.Context) bool  { return true }
+func (fakeSession) Refresh(ctx context.Context) error { return nil }
+func (fakeSession) ExpiresAt() time.Time              { return time.Time{} }
+
+func TestBackend_SetGetPassword(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeBW{items: make(map[string]map[string]interface{})}
+
+	backend, err := New(nil, t.TempDir()+"/session")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	backend.run = fake.run
+
+	if err := backend.SetPassword(ctx, "github", "hunter2", fakeSession{}); err != nil {
+		t.Fatalf("SetPassword() error = %v", err)
+	}
+
+	created := fake.items["github"]
+	if created == nil {
+		t.Fatal("SetPassword() did not create item")
+	}
+	if created["type"] != float64(bwTypeLogin) {
+		t.Errorf("created item type = %v, want %d (login)", created["type"], bwTypeLogin)
+	}
+
+	got, err := backend.GetPassword(ctx, "github", fakeSession{})
+	if err != nil {
+		t.Fatalf("GetPassword() error = %v", err)
+	}
+	if got != "hunter2" {
+		t.Errorf("GetPassword() = %q, want %q", got, "hunter2")
+	}
+
+	// Updating an existing login item edits it in place
+	if err := backend.SetPassword(ctx, "github", "correct-horse", fakeSession{}); err != nil {
+		t.Fatalf("SetPassword() update error = %v", err)
+	}
+	got, _ = backend.GetPassword(ctx, "github", fakeSession{})
+	if got != "correct-horse" {
+		t.Errorf("GetPassword() after update = %q, want %q", got, "correct-horse")
+	}
+	if fake.items["github"]["id"] != "id-github" {
+		t.Error("SetPassword() update should preserve item ID")
+	}
+}
+
+func TestBackend_GetPassword_NotLoginItem(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeBW{items: map[string]map[string]interface{}{
+		"note": {"id": "1", "name": "note", "type": 2, "notes": "secret"},
+	}}
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = fake.run
+
+	if _, err := backend.GetPassword(ctx, "note", fakeSession{}); !errors.Is(err, errNotLoginItem) {
+		t.Errorf("GetPassword() error = %v, want errNotLoginItem", err)
+	}
+	if err := backend.SetPassword(ctx, "note", "pw", fakeSession{}); !errors.Is(err, errNotLoginItem) {
+		t.Errorf("SetPassword() error = %v, want errNotLoginItem", err)
+	}
+}
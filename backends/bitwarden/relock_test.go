@@ -0,0 +1,197 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackend_GetItem_ReauthenticatesWhenLocked(t *testing.T) {
+	ctx := context.Background()
+	backend, err := New(nil, filepath.Join(t.TempDir(), ".bw-session"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	prompts := 0
+	backend.prompt = func(ctx context.Context) (string, error) {
+		prompts++
+		return "master-password", nil
+	}
+
+	var gets int
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case slices.Equal(args, []string{"status"}):
+			return []byte(`{"status":"locked"}`), nil
+		case slices.Equal(args, []string{"unlock", "--raw", "--passenv", "BW_PASSWORD"}):
+			if !slices.Contains(env, "BW_PASSWORD=master-password") {
+				t.Error("bw unlock run without the prompted password")
+			}
+			return []byte("new-token\n"), nil
+		case slices.Equal(args, []string{"unlock", "--check"}):
+			if slices.Contains(env, "BW_SESSION=new-token") {
+				return nil, nil
+			}
+			return []byte("Vault is locked."), errors.New("exit status 1")
+		case slices.Equal(args, []string{"get", "item", "db"}):
+			gets++
+			if !slices.Contains(env, "BW_SESSION=new-token") {
+				return []byte("Vault is locked."), errors.New("exit status 1")
+			}
+			return json.Marshal(map[string]interface{}{"id": "1", "name": "db", "type": 2, "notes": "secret"})
+		}
+		t.Errorf("unexpected command: bw %s", strings.Join(args, " "))
+		return nil, errors.New("exit status 1")
+	}
+
+	session := &bwSession{token: "expired-token", backend: backend}
+	item, err := backend.GetItem(ctx, "db", session)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Notes != "secret" {
+		t.Errorf("GetItem() notes = %q, want secret", item.Notes)
+	}
+	if gets != 2 || prompts != 1 {
+		t.Errorf("bw get ran %d times with %d unlock prompts, want 2 and 1", gets, prompts)
+	}
+	if session.Token() != "new-token" {
+		t.Errorf("session token = %q after reauthentication, want new-token", session.Token())
+	}
+}
+
+func TestBackend_GetItem_RetriesOnlyOnce(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(nil, filepath.Join(t.TempDir(), ".bw-session"))
+	backend.prompt = func(ctx context.Context) (string, error) { return "pw", nil }
+
+	var gets int
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case slices.Equal(args, []string{"status"}):
+			return []byte(`{"status":"locked"}`), nil
+		case args[0] == "unlock" && args[1] == "--raw":
+			return []byte("still-locked\n"), nil
+		case args[0] == "get":
+			gets++
+		}
+		return []byte("Vault is locked."), errors.New("exit status 1")
+	}
+
+	if _, err := backend.GetItem(ctx, "db", &bwSession{token: "t", backend: backend}); err == nil {
+		t.Fatal("GetItem() succeeded on a vault that stays locked")
+	}
+	if gets != 2 {
+		t.Errorf("bw get ran %d times, want 2 (one retry)", gets)
+	}
+}
+
+func TestBackend_GetItem_LockedOnStderr(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(nil, filepath.Join(t.TempDir(), ".bw-session"))
+	backend.prompt = func(ctx context.Context) (string, error) { return "pw", nil }
+
+	var gets int
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case slices.Equal(args, []string{"status"}):
+			return []byte(`{"status":"locked"}`), nil
+		case args[0] == "unlock" && args[1] == "--raw":
+			return []byte("new-token\n"), nil
+		case args[0] == "unlock":
+			return nil, nil
+		case args[0] == "get":
+			gets++
+			if !slices.Contains(env, "BW_SESSION=new-token") {
+				// As exec.Cmd.Output reports it: stdout empty, message on stderr
+				return nil, &exec.ExitError{Stderr: []byte("Vault is locked.")}
+			}
+			return json.Marshal(map[string]interface{}{"id": "1", "name": "db", "type": 2, "notes": "secret"})
+		}
+		t.Errorf("unexpected command: bw %s", strings.Join(args, " "))
+		return nil, errors.New("exit status 1")
+	}
+
+	item, err := backend.GetItem(ctx, "db", &bwSession{token: "expired-token", backend: backend})
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Notes != "secret" || gets != 2 {
+		t.Errorf("GetItem() = %q after %d gets, want secret after a retry", item.Notes, gets)
+	}
+}
+
+func TestBackend_GetItem_ConcurrentLockedShareUnlock(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(nil, filepath.Join(t.TempDir(), ".bw-session"))
+
+	var prompts, gets atomic.Int32
+	release := make(chan struct{})
+	backend.prompt = func(ctx context.Context) (string, error) {
+		prompts.Add(1)
+		<-release // Hold the unlock until every command has failed
+		return "pw", nil
+	}
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case slices.Equal(args, []string{"status"}):
+			return []byte(`{"status":"locked"}`), nil
+		case args[0] == "unlock" && args[1] == "--raw":
+			return []byte("new-token\n"), nil
+		case args[0] == "unlock":
+			if slices.Contains(env, "BW_SESSION=new-token") {
+				return nil, nil
+			}
+			return nil, &exec.ExitError{Stderr: []byte("Vault is locked.")}
+		case args[0] == "get":
+			if !slices.Contains(env, "BW_SESSION=new-token") {
+				gets.Add(1)
+				return nil, &exec.ExitError{Stderr: []byte("Vault is locked.")}
+			}
+			return json.Marshal(map[string]interface{}{"id": "1", "name": "db", "type": 2, "notes": "secret"})
+		}
+		t.Errorf("unexpected command: bw %s", strings.Join(args, " "))
+		return nil, errors.New("exit status 1")
+	}
+
+	const callers = 8
+	session := &bwSession{token: "expired-token", backend: backend}
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := backend.GetItem(ctx, "db", session)
+			errs <- err
+		}()
+	}
+	for gets.Load() < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetItem() error = %v", err)
+		}
+	}
+	if n := prompts.Load(); n != 1 {
+		t.Errorf("unlock prompted %d times for %d locked commands, want 1", n, callers)
+	}
+	if session.Token() != "new-token" {
+		t.Errorf("session token = %q, want new-token", session.Token())
+	}
+}
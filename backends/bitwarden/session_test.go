@@ -0,0 +1,96 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_Authenticate_IdentityChange(t *testing.T) {
+	ctx := context.Background()
+	backend, err := New(nil, filepath.Join(t.TempDir(), ".bw-session"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	user := "alice@example.com"
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case len(args) == 1 && args[0] == "status":
+			return []byte(`{"status":"locked","userEmail":"` + user + `","serverUrl":"https://vault.example.com"}`), nil
+		default: // unlock --check: only the logged-in account's token unlocks
+			if slices.Contains(env, "BW_SESSION="+strings.TrimSuffix(user, "@example.com")+"-token") {
+				return nil, nil
+			}
+			return nil, errors.New("invalid session")
+		}
+	}
+
+	// Session cached while alice was logged in
+	backend.cache.SetFingerprint(vaultmux.CredentialFingerprint(user, "https://vault.example.com"))
+	if err := backend.cache.Save("alice-token", "bitwarden"); err != nil {
+		t.Fatalf("cache.Save() error = %v", err)
+	}
+
+	session, err := backend.Authenticate(ctx)
+	if err != nil || session.Token() != "alice-token" {
+		t.Fatalf("Authenticate() as alice = %v, %v; want cached alice-token", session, err)
+	}
+
+	// bob logs in with bw; alice's cached session must not be reused. The
+	// context is cancelled so the interactive bw unlock fails at once.
+	user = "bob@example.com"
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if session, err := backend.Authenticate(cancelled); err == nil {
+		t.Errorf("Authenticate() as bob reused cached session %q", session.Token())
+	}
+	if cached, _ := backend.cache.Load(); cached != nil {
+		t.Errorf("cache.Load() as bob = %+v, want alice's session discarded", cached)
+	}
+}
+
+func TestBackend_Identity_Cached(t *testing.T) {
+	ctx := context.Background()
+	backend, err := New(nil, filepath.Join(t.TempDir(), ".bw-session"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	statusCalls := 0
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		if len(args) == 1 && args[0] == "status" {
+			statusCalls++
+			return []byte(`{"status":"unlocked","userEmail":"alice@example.com","serverUrl":"https://vault.example.com"}`), nil
+		}
+		return nil, nil // unlock --check
+	}
+
+	// A session cached for another account must not count as authenticated
+	backend.cache.SetFingerprint(vaultmux.CredentialFingerprint("bob@example.com", "https://vault.example.com"))
+	if err := backend.cache.Save("bob-token", "bitwarden"); err != nil {
+		t.Fatalf("cache.Save() error = %v", err)
+	}
+	if backend.IsAuthenticated(ctx) {
+		t.Error("IsAuthenticated() with bob's cached session as alice = true, want false")
+	}
+
+	backend.cache.SetFingerprint(vaultmux.CredentialFingerprint("alice@example.com", "https://vault.example.com"))
+	if err := backend.cache.Save("alice-token", "bitwarden"); err != nil {
+		t.Fatalf("cache.Save() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if session, err := backend.Authenticate(ctx); err != nil || session.Token() != "alice-token" {
+			t.Fatalf("Authenticate() = %v, %v; want cached alice-token", session, err)
+		}
+	}
+	if statusCalls != 1 {
+		t.Errorf("bw status ran %d times, want 1", statusCalls)
+	}
+}
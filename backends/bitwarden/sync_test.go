@@ -0,0 +1,158 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackend_Sync_Deduplicates(t *testing.T) {
+	var syncs atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		syncs.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[0] = backend.Sync(ctx, fakeSession{})
+	}()
+	<-started
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[1] = backend.Sync(ctx, fakeSession{})
+	}()
+
+	// Give the second call time to join the in-flight sync
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Sync() #%d error = %v", i, err)
+		}
+	}
+	if n := syncs.Load(); n != 1 {
+		t.Errorf("bw sync ran %d times, want 1", n)
+	}
+}
+
+func TestBackend_Sync_Cancelled(t *testing.T) {
+	backend, _ := New(nil, t.TempDir()+"/session")
+	killed := make(chan struct{})
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		<-ctx.Done() // exec.CommandContext kills the process on cancellation
+		close(killed)
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := backend.Sync(ctx, fakeSession{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Sync() error = %v, want context.DeadlineExceeded", err)
+	}
+	select {
+	case <-killed:
+	case <-time.After(time.Second):
+		t.Error("bw sync still running after its only caller cancelled")
+	}
+}
+
+func TestBackend_Sync_AllCallersCancelled(t *testing.T) {
+	var syncs atomic.Int32
+	started := make(chan struct{}, 2)
+	killed := make(chan struct{}, 2)
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		syncs.Add(1)
+		started <- struct{}{}
+		<-ctx.Done()
+		killed <- struct{}{}
+		return nil, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() { errs <- backend.Sync(first, fakeSession{}) }()
+	<-started
+	go func() { errs <- backend.Sync(second, fakeSession{}) }()
+	time.Sleep(50 * time.Millisecond) // Let the second call join the in-flight sync
+
+	cancelFirst()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("first Sync() error = %v, want context.Canceled", err)
+	}
+	select {
+	case <-killed:
+		t.Fatal("bw sync killed while a caller was still waiting")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancelSecond()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("second Sync() error = %v, want context.Canceled", err)
+	}
+	select {
+	case <-killed:
+	case <-time.After(time.Second):
+		t.Fatal("bw sync still running after every caller cancelled")
+	}
+	if n := syncs.Load(); n != 1 {
+		t.Errorf("bw sync ran %d times, want 1", n)
+	}
+}
+
+func TestBackend_Sync_FirstCallerCancelled(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() { firstErr <- backend.Sync(first, fakeSession{}) }()
+	<-started
+
+	secondErr := make(chan error, 1)
+	go func() { secondErr <- backend.Sync(context.Background(), fakeSession{}) }()
+	time.Sleep(50 * time.Millisecond) // Let the second call join the in-flight sync
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Sync() error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("joined Sync() error = %v, want the shared sync to finish", err)
+	}
+}
@@ -8,19 +8,29 @@
 package gcpsecrets
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/blackwell-systems/vaultmux"
 )
@@ -34,6 +44,21 @@ type Backend struct {
 	projectID string // GCP project ID (required, e.g., "my-project-123")
 	prefix    string // Secret name prefix for namespacing (e.g., "myapp-")
 	endpoint  string // Custom endpoint for testing (optional)
+	location  string // Regional Secret Manager location (optional, e.g., "europe-west3"); empty uses the global API
+
+	skipConnectivityCheck bool          // Init skips the ListSecrets probe
+	skipNoopUpdates       bool          // UpdateItem adds no version if the value is unchanged
+	initTimeout           time.Duration // Bounds the probe (0 uses vaultmux.DefaultInitTimeout)
+	proxyURL              *url.URL      // Proxy for Secret Manager gRPC connections (nil uses the environment)
+
+	// Extra projects included in listings (optional, e.g., a shared-secrets project)
+	additionalProjects []string
+
+	// Pub/Sub topics notified of changes to created secrets (optional)
+	topics []*secretmanagerpb.Topic
+
+	// Result limit for ListItems (optional, set from Config.MaxListResults)
+	maxListResults int
 
 	// Session cache file (currently unused - GCP credentials are long-lived)
 	sessionFile string
@@ -45,6 +70,23 @@ type Backend struct {
 //   - project_id: GCP project ID (required)
 //   - prefix: Secret name prefix for namespacing (default: "vaultmux-")
 //   - endpoint: Custom endpoint URL (for fake-gcp-server testing, optional)
+//   - location: Region for regional secrets, e.g. "europe-west3" (optional).
+//     Secrets are created and read as projects/*/locations/*/secrets/*
+//     through secretmanager.<location>.rep.googleapis.com, keeping their
+//     data in that region (default: global secrets with automatic
+//     replication)
+//   - topics: Comma-separated Pub/Sub topics ("projects/*/topics/*") attached
+//     to created secrets for rotation/version notifications (optional)
+//   - additional_projects: Comma-separated project IDs whose secrets are
+//     included in ListItems alongside project_id (optional, read via
+//     GetItemFromProject)
+//   - skip_connectivity_check: "true" to skip Init's ListSecrets probe, for
+//     credentials that may only access specific secrets (default: false)
+//   - skip_noop_updates: "true" to make UpdateItem add no version when the
+//     new value equals the latest one (default: false, every update adds one)
+//   - proxy_url: HTTP(S) CONNECT or SOCKS5 proxy for Secret Manager
+//     connections, e.g. "socks5://127.0.0.1:1080" (default: HTTPS_PROXY from
+//     the environment). Credential token requests still use the environment.
 //
 // Authentication uses Application Default Credentials (ADC):
 //   - GOOGLE_APPLICATION_CREDENTIALS env var pointing to service account JSON
@@ -60,7 +102,7 @@ type Backend struct {
 func New(options map[string]string, sessionFile string) (*Backend, error) {
 	projectID := options["project_id"]
 	if projectID == "" {
-		return nil, fmt.Errorf("project_id is required for GCP Secret Manager")
+		return nil, &vaultmux.ConfigError{Field: "project_id", Reason: "required for GCP Secret Manager"}
 	}
 
 	prefix := options["prefix"]
@@ -70,19 +112,106 @@ func New(options map[string]string, sessionFile string) (*Backend, error) {
 
 	endpoint := options["endpoint"]
 
+	location := options["location"]
+	if location != "" && !locationPattern.MatchString(location) {
+		return nil, &vaultmux.ConfigError{Field: "location", Reason: fmt.Sprintf("%q: must be a region such as europe-west3", location)}
+	}
+
+	topics, err := parseTopics(options["topics"])
+	if err != nil {
+		return nil, err
+	}
+
+	skipCheck := false
+	if v := options["skip_connectivity_check"]; v != "" {
+		var err error
+		if skipCheck, err = strconv.ParseBool(v); err != nil {
+			return nil, &vaultmux.ConfigError{Field: "skip_connectivity_check", Reason: fmt.Sprintf("%q: must be true or false", v)}
+		}
+	}
+
+	skipNoopUpdates := false
+	if v := options["skip_noop_updates"]; v != "" {
+		if skipNoopUpdates, err = strconv.ParseBool(v); err != nil {
+			return nil, &vaultmux.ConfigError{Field: "skip_noop_updates", Reason: fmt.Sprintf("%q: must be true or false", v)}
+		}
+	}
+
+	var proxyURL *url.URL
+	if v := options["proxy_url"]; v != "" {
+		if proxyURL, err = vaultmux.ParseProxyURL(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var additionalProjects []string
+	for _, project := range strings.Split(options["additional_projects"], ",") {
+		if project = strings.TrimSpace(project); project != "" && project != projectID {
+			additionalProjects = append(additionalProjects, project)
+		}
+	}
+
 	return &Backend{
-		projectID:   projectID,
-		prefix:      prefix,
-		endpoint:    endpoint,
-		sessionFile: sessionFile,
+		projectID:             projectID,
+		additionalProjects:    additionalProjects,
+		prefix:                prefix,
+		endpoint:              endpoint,
+		location:              location,
+		skipConnectivityCheck: skipCheck,
+		skipNoopUpdates:       skipNoopUpdates,
+		proxyURL:              proxyURL,
+		topics:                topics,
+		sessionFile:           sessionFile,
 	}, nil
 }
 
+// locationPattern matches Secret Manager region names.
+var locationPattern = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`)
+
+// topicPattern matches Pub/Sub topic resource names.
+var topicPattern = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+
+// parseTopics parses a comma-separated list of Pub/Sub topic names.
+func parseTopics(value string) ([]*secretmanagerpb.Topic, error) {
+	var topics []*secretmanagerpb.Topic
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !topicPattern.MatchString(name) {
+			return nil, &vaultmux.ConfigError{Field: "topics", Reason: fmt.Sprintf("%q: must be projects/*/topics/*", name)}
+		}
+		topics = append(topics, &secretmanagerpb.Topic{Name: name})
+	}
+	return topics, nil
+}
+
 // Name returns the backend identifier.
 func (b *Backend) Name() string {
 	return "gcpsecrets"
 }
 
+// AuthEnvVars returns the environment variables Application Default
+// Credentials read.
+func (b *Backend) AuthEnvVars() []string {
+	return []string{
+		"GOOGLE_APPLICATION_CREDENTIALS",
+		"GOOGLE_CLOUD_PROJECT",
+		"CLOUDSDK_CONFIG",
+		"GCE_METADATA_HOST",
+	}
+}
+
+// Limits reports Secret Manager's payload size and secret ID limits.
+func (b *Backend) Limits() vaultmux.Limits {
+	return vaultmux.Limits{
+		MaxSecretBytes:     65536,
+		MaxNameLength:      255,
+		AllowedNameCharset: "A-Za-z0-9_-",
+	}
+}
+
 // Init initializes the GCP Secret Manager client and verifies connectivity.
 func (b *Backend) Init(ctx context.Context) error {
 	if err := b.initGCPClient(ctx); err != nil {
@@ -90,18 +219,28 @@ func (b *Backend) Init(ctx context.Context) error {
 			fmt.Errorf("failed to initialize GCP client: %w", err))
 	}
 
+	if b.skipConnectivityCheck {
+		return nil
+	}
+
 	// Verify connectivity with lightweight API call (list with limit 1)
-	parent := fmt.Sprintf("projects/%s", b.projectID)
+	parent := b.parent(b.projectID)
 	req := &secretmanagerpb.ListSecretsRequest{
 		Parent:   parent,
 		PageSize: 1,
 	}
 
-	iter := b.client.ListSecrets(ctx, req)
-	_, err := iter.Next()
-
-	// EOF is ok (no secrets exist yet)
-	if err != nil && err != iterator.Done {
+	err := vaultmux.ProbeConnectivity(ctx, b.initTimeout, func(ctx context.Context) error {
+		_, err := b.client.ListSecrets(ctx, req).Next()
+		if err == iterator.Done {
+			return nil // No secrets exist yet
+		}
+		if status.Code(err) == codes.ResourceExhausted {
+			return b.handleGCPError(err, "init", "") // Carries RetryAfter for the probe
+		}
+		return err
+	})
+	if err != nil {
 		return vaultmux.WrapError(b.Name(), "init", "",
 			fmt.Errorf("failed to connect to GCP Secret Manager: %w", err))
 	}
@@ -114,6 +253,11 @@ func (b *Backend) Init(ctx context.Context) error {
 func (b *Backend) initGCPClient(ctx context.Context) error {
 	var opts []option.ClientOption
 
+	// Regional secrets are only served by the regional endpoint
+	if b.endpoint == "" && b.location != "" {
+		opts = append(opts, option.WithEndpoint(fmt.Sprintf("secretmanager.%s.rep.googleapis.com:443", b.location)))
+	}
+
 	// Custom endpoint for testing (e.g., gcp-secret-manager-mock)
 	if b.endpoint != "" {
 		opts = append(opts, option.WithEndpoint(b.endpoint))
@@ -121,6 +265,9 @@ func (b *Backend) initGCPClient(ctx context.Context) error {
 		// Use insecure transport for local mock servers (no TLS)
 		opts = append(opts, option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
 	}
+	if b.proxyURL != nil {
+		opts = append(opts, option.WithGRPCDialOption(grpc.WithContextDialer(proxyDialer(b.proxyURL))))
+	}
 
 	client, err := secretmanager.NewClient(ctx, opts...)
 	if err != nil {
@@ -171,13 +318,39 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 // GetItem retrieves a secret from GCP Secret Manager.
 // Returns the latest version of the secret.
 func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
+	return b.GetItemFromProject(ctx, b.projectID, name, session)
+}
+
+// GetItemFromProject retrieves a secret from another GCP project, such as one
+// listed in additional_projects. The backend prefix still applies to name.
+func (b *Backend) GetItemFromProject(ctx context.Context, project, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	return b.getItem(ctx, project, name, "latest", session)
+}
+
+// GetItemVersion retrieves a specific version of a secret. version is a
+// version number, a version alias set with SetVersionAlias (e.g. "prod"),
+// or "latest". Item.Version reports the resolved version number.
+func (b *Backend) GetItemVersion(ctx context.Context, name, version string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if version == "" {
+		return nil, vaultmux.WrapError(b.Name(), "get", name, fmt.Errorf("version is required"))
+	}
+	return b.getItem(ctx, b.projectID, name, version, session)
+}
+
+// getItem retrieves a version of a secret, with its metadata, from project.
+func (b *Backend) getItem(ctx context.Context, project, name, version string, session vaultmux.Session) (*vaultmux.Item, error) {
 	if !session.IsValid(ctx) {
 		return nil, vaultmux.ErrNotAuthenticated
 	}
+	if project == "" {
+		return nil, vaultmux.WrapError(b.Name(), "get", name, fmt.Errorf("project is required"))
+	}
 
 	secretName := b.secretName(name)
-	// GCP secret path format: projects/{project}/secrets/{secret}/versions/latest
-	versionName := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", b.projectID, secretName)
+	versionName := b.secretPath(project, secretName) + "/versions/" + version
 
 	req := &secretmanagerpb.AccessSecretVersionRequest{
 		Name: versionName,
@@ -189,7 +362,7 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 	}
 
 	// Get secret metadata for full item info
-	secretPath := fmt.Sprintf("projects/%s/secrets/%s", b.projectID, secretName)
+	secretPath := b.secretPath(project, secretName)
 	secret, err := b.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{
 		Name: secretPath,
 	})
@@ -197,12 +370,96 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 		return nil, b.handleGCPError(err, "get-metadata", name)
 	}
 
-	return &vaultmux.Item{
-		ID:    secret.Name, // Full resource name
-		Name:  name,        // User-provided name (without prefix)
-		Type:  vaultmux.ItemTypeSecureNote,
-		Notes: string(result.Payload.Data),
-	}, nil
+	item := &vaultmux.Item{
+		ID:          secret.Name, // Full resource name
+		Name:        name,        // User-provided name (without prefix)
+		Type:        vaultmux.ItemTypeSecureNote,
+		Notes:       string(result.Payload.Data),
+		Description: secret.Annotations[descriptionAnnotation],
+		Version:     resolvedVersion(result.Name),
+		Tags:        userLabels(secret.Labels),
+	}
+	if secret.Etag != "" {
+		item.Fields = map[string]string{EtagField: secret.Etag}
+	}
+	return item, nil
+}
+
+// SetVersionAlias points alias (e.g. "prod") at versionID of a secret, so
+// GetItemVersion(name, alias) reads that version. Other aliases are kept.
+// An empty versionID removes the alias.
+func (b *Backend) SetVersionAlias(ctx context.Context, name, alias, versionID string, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+	if alias == "" || alias == "latest" {
+		return vaultmux.WrapError(b.Name(), "set-alias", name, fmt.Errorf("invalid version alias %q", alias))
+	}
+
+	var version int64
+	if versionID != "" {
+		var err error
+		if version, err = strconv.ParseInt(versionID, 10, 64); err != nil || version < 1 {
+			return vaultmux.WrapError(b.Name(), "set-alias", name, fmt.Errorf("invalid version ID %q: must be a version number", versionID))
+		}
+	}
+
+	secretPath := b.secretPath(b.projectID, b.secretName(name))
+	secret, err := b.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: secretPath})
+	if err != nil {
+		return b.handleGCPError(err, "set-alias", name)
+	}
+
+	aliases := secret.GetVersionAliases()
+	if aliases == nil {
+		aliases = make(map[string]int64)
+	}
+	if version == 0 {
+		delete(aliases, alias)
+	} else {
+		aliases[alias] = version
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	_, err = b.client.UpdateSecret(ctx, &secretmanagerpb.UpdateSecretRequest{
+		Secret: &secretmanagerpb.Secret{
+			Name:           secretPath,
+			VersionAliases: aliases,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"version_aliases"}},
+	})
+	if err != nil {
+		return b.handleGCPError(err, "set-alias", name)
+	}
+	return nil
+}
+
+// Describe returns the secret's Secret Manager resource as a map, keyed by
+// its JSON field names: "createTime", "labels", "replication", "rotation",
+// "versionAliases" and so on. Secret values are not included.
+func (b *Backend) Describe(ctx context.Context, name string, session vaultmux.Session) (map[string]any, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	secretPath := b.secretPath(b.projectID, b.secretName(name))
+	secret, err := b.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: secretPath})
+	if err != nil {
+		return nil, b.handleGCPError(err, "describe", name)
+	}
+
+	data, err := protojson.Marshal(secret)
+	if err != nil {
+		return nil, vaultmux.WrapError(b.Name(), "describe", name, err)
+	}
+	var metadata map[string]any
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, vaultmux.WrapError(b.Name(), "describe", name, err)
+	}
+	return metadata, nil
 }
 
 // GetNotes retrieves only the notes field of a secret (convenience method).
@@ -229,17 +486,73 @@ func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.
 // ListItems returns all secrets matching the configured prefix.
 // GCP API supports simple iteration (no complex pagination like AWS).
 func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	return b.ListItemsWithOptions(ctx, session)
+}
+
+// ListItemsWithOptions returns all secrets matching the configured prefix,
+// applying any list options. Secrets from additional_projects are included
+// after those from project_id. Items record their source project in
+// Fields["project"], since names may repeat across projects.
+//
+// Listing stops once the result limit (WithMaxResults, or MaxListResults
+// from the Config) is reached; the items so far are returned with an error
+// wrapping vaultmux.ErrListTruncated if more secrets match.
+func (b *Backend) ListItemsWithOptions(ctx context.Context, session vaultmux.Session, opts ...vaultmux.ListOption) ([]*vaultmux.Item, error) {
 	if !session.IsValid(ctx) {
 		return nil, vaultmux.ErrNotAuthenticated
 	}
 
-	parent := fmt.Sprintf("projects/%s", b.projectID)
+	o := vaultmux.NewListOptions(opts...)
+	limit := cmp.Or(o.MaxResults, b.maxListResults)
+
+	var items []*vaultmux.Item
+	truncated := false
+	for _, project := range append([]string{b.projectID}, b.additionalProjects...) {
+		err := b.walkProject(ctx, project, o, func(item *vaultmux.Item) bool {
+			if limit > 0 && len(items) == limit {
+				truncated = true
+				return false
+			}
+			items = append(items, item)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		if truncated {
+			return items, vaultmux.WrapError(b.Name(), "list", "", vaultmux.ErrListTruncated)
+		}
+	}
+
+	return items, nil
+}
+
+// GroupByLabel lists all secrets matching the configured prefix and buckets
+// them by the value of the labelKey label. Secrets without the label are
+// grouped under the empty string.
+func (b *Backend) GroupByLabel(ctx context.Context, labelKey string, session vaultmux.Session) (map[string][]*vaultmux.Item, error) {
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]*vaultmux.Item)
+	for _, item := range items {
+		value := item.Tags[labelKey]
+		groups[value] = append(groups[value], item)
+	}
+	return groups, nil
+}
+
+// walkProject calls yield for each prefixed secret in one project as pages
+// are fetched, stopping early if yield returns false.
+func (b *Backend) walkProject(ctx context.Context, project string, o vaultmux.ListOptions, yield func(*vaultmux.Item) bool) error {
+	parent := b.parent(project)
 	req := &secretmanagerpb.ListSecretsRequest{
 		Parent:   parent,
 		PageSize: 100, // Max per page
 	}
 
-	var items []*vaultmux.Item
 	iter := b.client.ListSecrets(ctx, req)
 
 	for {
@@ -248,15 +561,13 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 			break
 		}
 		if err != nil {
-			return nil, b.handleGCPError(err, "list", "")
+			return b.handleGCPError(err, "list", "")
 		}
 
-		// Extract secret name from full path: projects/{project}/secrets/{name}
-		parts := strings.Split(secret.Name, "/")
-		if len(parts) < 4 {
-			continue
+		_, fullName, err := ParseSecretName(secret.Name)
+		if err != nil {
+			return b.handleGCPError(err, "list", secret.Name)
 		}
-		fullName := parts[3]
 
 		// Filter by prefix
 		if b.prefix != "" && !strings.HasPrefix(fullName, b.prefix) {
@@ -264,20 +575,161 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 		}
 
 		name := strings.TrimPrefix(fullName, b.prefix)
-		items = append(items, &vaultmux.Item{
-			ID:   secret.Name, // Full resource name
-			Name: name,
-			Type: vaultmux.ItemTypeSecureNote,
+		if o.FullNames {
+			name = fullName
+		}
+		item := &vaultmux.Item{
+			ID:          secret.Name, // Full resource name
+			Name:        name,
+			Type:        vaultmux.ItemTypeSecureNote,
+			Description: secret.Annotations[descriptionAnnotation],
+			Created:     secret.GetCreateTime().AsTime(),
+			Fields:      map[string]string{"project": project},
+			Tags:        userLabels(secret.Labels),
 			// Notes not included (requires separate AccessSecretVersion call)
+		}
+		if !yield(item) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ListItemsChan streams the items ListItems would return as each page of
+// secrets is fetched. See vaultmux.ListItemsChan.
+func (b *Backend) ListItemsChan(ctx context.Context, session vaultmux.Session) (<-chan vaultmux.ItemOrError, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	ch := make(chan vaultmux.ItemOrError)
+	go func() {
+		defer close(ch)
+
+		var o vaultmux.ListOptions
+		for _, project := range append([]string{b.projectID}, b.additionalProjects...) {
+			err := b.walkProject(ctx, project, o, func(item *vaultmux.Item) bool {
+				return vaultmux.SendItem(ctx, ch, vaultmux.ItemOrError{Item: item})
+			})
+			if err != nil {
+				vaultmux.SendItem(ctx, ch, vaultmux.ItemOrError{Err: err})
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// ListItemNames returns the names of all secrets matching the configured
+// prefix, including additional_projects, without building Item values.
+func (b *Backend) ListItemNames(ctx context.Context, session vaultmux.Session) ([]string, error) {
+	if !session.IsValid(ctx) {
+		return nil, vaultmux.ErrNotAuthenticated
+	}
+
+	var names []string
+	for _, project := range append([]string{b.projectID}, b.additionalProjects...) {
+		iter := b.client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+			Parent:   b.parent(project),
+			PageSize: 100,
 		})
+		for {
+			secret, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, b.handleGCPError(err, "list", "")
+			}
+
+			_, fullName, err := ParseSecretName(secret.Name)
+			if err != nil {
+				return nil, b.handleGCPError(err, "list", secret.Name)
+			}
+			if name, ok := strings.CutPrefix(fullName, b.prefix); ok {
+				names = append(names, name)
+			}
+		}
 	}
 
-	return items, nil
+	return names, nil
+}
+
+// ListItemsModifiedSince returns secrets whose latest version was created
+// after since. Adding a version doesn't change the secret itself, so this
+// reads each secret's latest version metadata (not its payload) and sets
+// Item.Modified to the version's create time.
+func (b *Backend) ListItemsModifiedSince(ctx context.Context, since time.Time, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []*vaultmux.Item
+	for _, item := range items {
+		version, err := b.client.GetSecretVersion(ctx, &secretmanagerpb.GetSecretVersionRequest{
+			Name: item.ID + "/versions/latest",
+		})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				continue // No enabled versions
+			}
+			return nil, b.handleGCPError(err, "get-version", item.Name)
+		}
+
+		item.Modified = version.GetCreateTime().AsTime()
+		if item.Modified.After(since) {
+			modified = append(modified, item)
+		}
+	}
+
+	return modified, nil
+}
+
+// EtagField is the Item.Fields key holding the secret's etag, as read by
+// GetItem. Pass it to DeleteItemIfMatch to delete the secret only if its
+// metadata hasn't changed since. The etag covers the secret resource only
+// (labels, annotations, version aliases); Secret Manager does not change
+// it when a version is added, so it does not detect new values.
+const EtagField = "etag"
+
+// descriptionAnnotation is the secret annotation key holding Item.Description.
+// Annotations are used rather than labels because label values are restricted
+// to lowercase letters, digits, underscores and dashes.
+const descriptionAnnotation = "vaultmux-description"
+
+// internalLabels are the labels vaultmux sets on every secret it creates.
+// They are not reported in Item.Tags and can't be overridden by WithTags.
+var internalLabels = []string{"vaultmux", "prefix"}
+
+// userLabels returns labels without the internal ones, or nil if none remain.
+func userLabels(labels map[string]string) map[string]string {
+	var tags map[string]string
+	for k, v := range labels {
+		if slices.Contains(internalLabels, k) {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[k] = v
+	}
+	return tags
 }
 
 // CreateItem creates a new secret in GCP Secret Manager.
 // GCP requires two operations: CreateSecret (metadata) + AddSecretVersion (content).
 func (b *Backend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	return b.CreateItemWithOptions(ctx, name, content, session)
+}
+
+// CreateItemWithOptions creates a new secret, applying any create options.
+// The description is stored as a secret annotation and tags as labels.
+func (b *Backend) CreateItemWithOptions(ctx context.Context, name, content string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
 	if !session.IsValid(ctx) {
 		return vaultmux.ErrNotAuthenticated
 	}
@@ -293,8 +745,12 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, session
 		return vaultmux.ErrAlreadyExists
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	// Step 1: Create secret (metadata only)
-	parent := fmt.Sprintf("projects/%s", b.projectID)
+	parent := b.parent(b.projectID)
 	createReq := &secretmanagerpb.CreateSecretRequest{
 		Parent:   parent,
 		SecretId: secretName,
@@ -303,13 +759,29 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, session
 				"vaultmux": "true",
 				"prefix":   b.prefix,
 			},
-			Replication: &secretmanagerpb.Replication{
-				Replication: &secretmanagerpb.Replication_Automatic_{
-					Automatic: &secretmanagerpb.Replication_Automatic{},
-				},
-			},
+			Topics: b.topics,
 		},
 	}
+	// Regional secrets live in their location and take no replication policy
+	if b.location == "" {
+		createReq.Secret.Replication = &secretmanagerpb.Replication{
+			Replication: &secretmanagerpb.Replication_Automatic_{
+				Automatic: &secretmanagerpb.Replication_Automatic{},
+			},
+		}
+	}
+
+	o := vaultmux.NewCreateOptions(opts...)
+	if o.Description != "" {
+		createReq.Secret.Annotations = map[string]string{
+			descriptionAnnotation: o.Description,
+		}
+	}
+	for k, v := range o.Tags {
+		if !slices.Contains(internalLabels, k) {
+			createReq.Secret.Labels[k] = v
+		}
+	}
 
 	secret, err := b.client.CreateSecret(ctx, createReq)
 	if err != nil {
@@ -335,23 +807,36 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, session
 // UpdateItem updates an existing secret in GCP Secret Manager.
 // GCP automatically creates a new version with each update (versioning is built-in).
 func (b *Backend) UpdateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	_, err := b.UpdateItemWithResult(ctx, name, content, session)
+	return err
+}
+
+// UpdateItemWithResult updates an existing secret and reports the version
+// it added. With the skip_noop_updates option, a value equal to the latest
+// version's adds no version and NewVersion is empty.
+func (b *Backend) UpdateItemWithResult(ctx context.Context, name, content string, session vaultmux.Session) (vaultmux.UpdateResult, error) {
 	if !session.IsValid(ctx) {
-		return vaultmux.ErrNotAuthenticated
+		return vaultmux.UpdateResult{}, vaultmux.ErrNotAuthenticated
 	}
 
-	secretName := b.secretName(name)
-
-	// Check if exists
-	exists, err := b.ItemExists(ctx, name, session)
+	// Reading the latest version also checks that the secret exists
+	current, err := b.getItem(ctx, b.projectID, name, "latest", session)
+	if errors.Is(err, vaultmux.ErrNotFound) {
+		return vaultmux.UpdateResult{}, vaultmux.ErrNotFound
+	}
 	if err != nil {
-		return err
+		return vaultmux.UpdateResult{}, err
 	}
-	if !exists {
-		return vaultmux.ErrNotFound
+
+	result := vaultmux.UpdateResult{
+		Changed: current.Notes != content,
+	}
+	if vaultmux.IsDryRun(ctx) || (!result.Changed && b.skipNoopUpdates) {
+		return result, nil
 	}
 
 	// Add new secret version (GCP's way of "updating")
-	secretPath := fmt.Sprintf("projects/%s/secrets/%s", b.projectID, secretName)
+	secretPath := b.secretPath(b.projectID, b.secretName(name))
 	req := &secretmanagerpb.AddSecretVersionRequest{
 		Parent: secretPath,
 		Payload: &secretmanagerpb.SecretPayload{
@@ -359,12 +844,37 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 		},
 	}
 
-	_, err = b.client.AddSecretVersion(ctx, req)
+	version, err := b.client.AddSecretVersion(ctx, req)
 	if err != nil {
-		return b.handleGCPError(err, "update", name)
+		return vaultmux.UpdateResult{}, b.handleGCPError(err, "update", name)
 	}
+	result.NewVersion = resolvedVersion(version.Name)
+	return result, nil
+}
 
-	return nil
+// SetItem creates the secret if missing, or adds a new version if present.
+// The version is added first, so existing secrets take a single API call.
+func (b *Backend) SetItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		_, err := b.ItemExists(ctx, name, session)
+		return err
+	}
+
+	secretPath := b.secretPath(b.projectID, b.secretName(name))
+	_, err := b.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent: secretPath,
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: []byte(content),
+		},
+	})
+	if status.Code(err) == codes.NotFound {
+		return b.CreateItem(ctx, name, content, session)
+	}
+	return b.handleGCPError(err, "set", name)
 }
 
 // DeleteItem deletes a secret from GCP Secret Manager.
@@ -385,7 +895,11 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 		return vaultmux.ErrNotFound
 	}
 
-	secretPath := fmt.Sprintf("projects/%s/secrets/%s", b.projectID, secretName)
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	secretPath := b.secretPath(b.projectID, secretName)
 	req := &secretmanagerpb.DeleteSecretRequest{
 		Name: secretPath,
 	}
@@ -398,6 +912,106 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 	return nil
 }
 
+// DestroyAllVersions permanently destroys every version of a secret while
+// keeping the secret itself, with its labels, annotations and IAM policy.
+// Versions already destroyed are skipped.
+//
+// Until a new version is added, the secret reads as missing: GetItem and
+// ItemExists report ErrNotFound and false, and so DeleteItem and UpdateItem
+// return ErrNotFound.
+func (b *Backend) DestroyAllVersions(ctx context.Context, name string, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+
+	secretPath := b.secretPath(b.projectID, b.secretName(name))
+	iter := b.client.ListSecretVersions(ctx, &secretmanagerpb.ListSecretVersionsRequest{
+		Parent: secretPath,
+	})
+
+	var versions []string
+	for {
+		version, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return b.handleGCPError(err, "destroy-versions", name)
+		}
+		if version.State != secretmanagerpb.SecretVersion_DESTROYED {
+			versions = append(versions, version.Name)
+		}
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	for _, version := range versions {
+		_, err := b.client.DestroySecretVersion(ctx, &secretmanagerpb.DestroySecretVersionRequest{
+			Name: version,
+		})
+		if err != nil {
+			return b.handleGCPError(err, "destroy-versions", name)
+		}
+	}
+	return nil
+}
+
+// GetItemRaw retrieves a secret by its full stored name, without adding
+// the prefix. It bypasses namespacing and can read any secret the
+// credentials allow; see vaultmux.RawItemAccessor.
+func (b *Backend) GetItemRaw(ctx context.Context, storedName string, session vaultmux.Session) (*vaultmux.Item, error) {
+	return b.unprefixed().GetItem(ctx, storedName, session)
+}
+
+// DeleteItemIfMatch deletes a secret only if its etag still equals etag,
+// as read from Item.Fields[EtagField]. If the secret's metadata changed
+// since, for example by SetVersionAlias, nothing is deleted and an error
+// wrapping vaultmux.ErrConflict is returned. A version added since does
+// not cause a conflict; see EtagField.
+func (b *Backend) DeleteItemIfMatch(ctx context.Context, name, etag string, session vaultmux.Session) error {
+	if !session.IsValid(ctx) {
+		return vaultmux.ErrNotAuthenticated
+	}
+	if etag == "" {
+		return vaultmux.WrapError(b.Name(), "delete", name, fmt.Errorf("etag is required"))
+	}
+	if vaultmux.IsDryRun(ctx) {
+		_, err := b.ItemExists(ctx, name, session)
+		return err
+	}
+
+	err := b.client.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{
+		Name: b.secretPath(b.projectID, b.secretName(name)),
+		Etag: etag,
+	})
+	switch status.Code(err) {
+	case codes.Aborted, codes.FailedPrecondition:
+		return vaultmux.WrapError(b.Name(), "delete", name, fmt.Errorf("%w: %w", vaultmux.ErrConflict, err))
+	}
+	return b.handleGCPError(err, "delete", name)
+}
+
+// DeleteItemRaw deletes a secret by its full stored name, without adding
+// the prefix. It bypasses namespacing and can delete any secret the
+// credentials allow; see vaultmux.RawItemAccessor.
+func (b *Backend) DeleteItemRaw(ctx context.Context, storedName string, session vaultmux.Session) error {
+	return b.unprefixed().DeleteItem(ctx, storedName, session)
+}
+
+// unprefixed returns a copy of b, sharing its client, with no prefix.
+func (b *Backend) unprefixed() *Backend {
+	raw := *b
+	raw.prefix = ""
+	return &raw
+}
+
+// Prefix returns the secret name prefix used for namespacing.
+func (b *Backend) Prefix() string {
+	return b.prefix
+}
+
 // secretName returns the full secret name with prefix applied.
 func (b *Backend) secretName(name string) string {
 	if b.prefix != "" {
@@ -406,6 +1020,31 @@ func (b *Backend) secretName(name string) string {
 	return name
 }
 
+// parent returns the resource name secrets of project are created and
+// listed under: projects/{project}, or projects/{project}/locations/{location}
+// for regional secrets.
+func (b *Backend) parent(project string) string {
+	if b.location != "" {
+		return fmt.Sprintf("projects/%s/locations/%s", project, b.location)
+	}
+	return fmt.Sprintf("projects/%s", project)
+}
+
+// secretPath returns the resource name of a secret in project.
+func (b *Backend) secretPath(project, secretName string) string {
+	return b.parent(project) + "/secrets/" + secretName
+}
+
+// resolvedVersion returns the version number from an accessed version's
+// resource name ("latest" resolves to a number), or "" if it can't be parsed.
+func resolvedVersion(versionName string) string {
+	_, _, version, err := ParseVersionName(versionName)
+	if err != nil {
+		return ""
+	}
+	return version
+}
+
 // handleGCPError maps GCP gRPC errors to vaultmux standard errors.
 func (b *Backend) handleGCPError(err error, operation, itemName string) error {
 	if err == nil {
@@ -438,6 +1077,15 @@ func (b *Backend) handleGCPError(err error, operation, itemName string) error {
 		return vaultmux.WrapError(b.Name(), operation, itemName,
 			fmt.Errorf("invalid argument: %w", err))
 
+	case codes.ResourceExhausted:
+		return &vaultmux.BackendError{
+			Backend:    b.Name(),
+			Op:         operation,
+			Item:       itemName,
+			Err:        fmt.Errorf("quota exhausted: %w", err),
+			RetryAfter: retryDelay(st),
+		}
+
 	default:
 		// Generic error with gRPC code context
 		return vaultmux.WrapError(b.Name(), operation, itemName,
@@ -445,30 +1093,54 @@ func (b *Backend) handleGCPError(err error, operation, itemName string) error {
 	}
 }
 
+// retryDelay returns the delay suggested by a RetryInfo status detail, or zero.
+func retryDelay(st *status.Status) time.Duration {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
 // Location management stubs (GCP doesn't have native "folders" like 1Password vaults).
-// These operations are not supported and return ErrNotSupported.
+// These operations are not supported and return a NotSupportedError.
 // Could be implemented using labels in the future, but not currently supported.
 
 func (b *Backend) ListLocations(ctx context.Context, session vaultmux.Session) ([]string, error) {
-	return nil, vaultmux.ErrNotSupported
+	return nil, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "ListLocations"}
 }
 
 func (b *Backend) LocationExists(ctx context.Context, name string, session vaultmux.Session) (bool, error) {
-	return false, vaultmux.ErrNotSupported
+	return false, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "LocationExists"}
 }
 
 func (b *Backend) CreateLocation(ctx context.Context, name string, session vaultmux.Session) error {
-	return vaultmux.ErrNotSupported
+	return &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "CreateLocation"}
 }
 
 func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue string, session vaultmux.Session) ([]*vaultmux.Item, error) {
-	return nil, vaultmux.ErrNotSupported
+	return nil, &vaultmux.NotSupportedError{Backend: b.Name(), Operation: "ListItemsInLocation"}
+}
+
+// validateOptions checks options as New does. New never dials; the gRPC
+// client is created by Init.
+func validateOptions(options map[string]string) error {
+	_, err := New(options, "")
+	return err
 }
 
 // init registers the GCP Secret Manager backend with vaultmux.
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendGCPSecretManager,
 		func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-			return New(cfg.Options, cfg.SessionFile)
+			b, err := New(cfg.Options, cfg.SessionFile)
+			if err != nil {
+				return nil, err
+			}
+			b.maxListResults = cfg.MaxListResults
+			b.initTimeout = cfg.InitTimeout
+			return b, nil
 		})
+	vaultmux.RegisterConfigValidator(vaultmux.BackendGCPSecretManager, validateOptions)
 }
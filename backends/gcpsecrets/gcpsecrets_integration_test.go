@@ -5,7 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/blackwell-systems/vaultmux"
 )
@@ -23,53 +30,13 @@ import (
 //	GCP_PROJECT_ID=my-project \
 //	go test -v ./backends/gcpsecrets/
 //
-// Note: Unlike AWS (LocalStack) or Windows (WSL2), GCP has no good local emulator.
-// These tests require a real GCP project or will skip gracefully.
+// Without GCP_PROJECT_ID the tests run against an in-process gcpmock server.
 func TestIntegration(t *testing.T) {
-	// Check for mock endpoint first (for testing without real GCP)
-	endpoint := os.Getenv("GCP_MOCK_ENDPOINT")
-	projectID := os.Getenv("GCP_PROJECT_ID")
-
-	if endpoint == "" && projectID == "" {
-		t.Skip("Neither GCP_MOCK_ENDPOINT nor GCP_PROJECT_ID set - skipping integration tests")
-	}
-
-	// Default project for mock testing
-	if projectID == "" {
-		projectID = "test-project"
-	}
-
-	options := map[string]string{
-		"project_id": projectID,
-		"prefix":     "test-vaultmux-",
-	}
-	if endpoint != "" {
-		options["endpoint"] = endpoint
-	}
-
-	backend, err := New(options, "")
-	if err != nil {
-		t.Fatal(err)
-	}
-
+	backend, session := newIntegrationBackend(t, "test-vaultmux-")
 	ctx := context.Background()
 
-	// Init
-	t.Run("Init", func(t *testing.T) {
-		if err := backend.Init(ctx); err != nil {
-			t.Fatalf("Init() error = %v", err)
-		}
-	})
-
 	// Authenticate
-	var session vaultmux.Session
 	t.Run("Authenticate", func(t *testing.T) {
-		var err error
-		session, err = backend.Authenticate(ctx)
-		if err != nil {
-			t.Fatalf("Authenticate() error = %v (check GOOGLE_APPLICATION_CREDENTIALS)", err)
-		}
-
 		if !session.IsValid(ctx) {
 			t.Error("Session is not valid after authentication")
 		}
@@ -252,65 +219,817 @@ func TestIntegration(t *testing.T) {
 
 // TestIntegration_Pagination tests that large secret collections are handled correctly.
 func TestIntegration_Pagination(t *testing.T) {
-	endpoint := os.Getenv("GCP_MOCK_ENDPOINT")
-	projectID := os.Getenv("GCP_PROJECT_ID")
+	backend, session := newIntegrationBackend(t, "pagination-test-")
+	ctx := context.Background()
+
+	// Create 5 test items (GCP is fast, but real API so keep count low)
+	itemCount := 5
+	for i := 0; i < itemCount; i++ {
+		itemName := fmt.Sprintf("item-%d", i)
+		if err := backend.CreateItem(ctx, itemName, "content", session); err != nil {
+			t.Fatalf("CreateItem(%q) error = %v", itemName, err)
+		}
+	}
+
+	// List all items
+	items, err := backend.ListItems(ctx, session)
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+
+	if len(items) < itemCount {
+		t.Errorf("ListItems() returned %d items, want at least %d", len(items), itemCount)
+	}
+
+	// Cleanup
+	for i := 0; i < itemCount; i++ {
+		itemName := fmt.Sprintf("item-%d", i)
+		_ = backend.DeleteItem(ctx, itemName, session)
+	}
+}
 
-	if endpoint == "" && projectID == "" {
-		t.Skip("Neither GCP_MOCK_ENDPOINT nor GCP_PROJECT_ID set - skipping pagination test")
+// newIntegrationBackend returns an initialized, authenticated backend with
+// the given prefix: on the project in GCP_PROJECT_ID when it is set, and on
+// an in-process gcpmock server otherwise.
+func newIntegrationBackend(t *testing.T, prefix string) (*Backend, vaultmux.Session) {
+	t.Helper()
+	if projectID := os.Getenv("GCP_PROJECT_ID"); projectID != "" {
+		return newEndpointBackend(t, "", map[string]string{"project_id": projectID, "prefix": prefix})
 	}
+	backend, session, _ := newMockBackend(t, map[string]string{"prefix": prefix})
+	return backend, session
+}
+
+// TestIntegration_PrefixIsolation verifies that VerifyPrefixIsolation reports
+// secrets visible to a backend whose prefix is broader than expected.
+func TestIntegration_PrefixIsolation(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "isolation-test-project",
+		"prefix":     "iso-",
+	})
+	ctx := context.Background()
 
-	if projectID == "" {
-		projectID = "pagination-test-project"
+	// Stored as iso-prod-db (in namespace) and iso-staging-db (leak)
+	for _, name := range []string{"prod-db", "staging-db"} {
+		if err := backend.CreateItem(ctx, name, "content", session); err != nil {
+			t.Fatalf("CreateItem(%q) error = %v", name, err)
+		}
 	}
 
-	options := map[string]string{
-		"project_id": projectID,
-		"prefix":     "pagination-test-",
+	err := vaultmux.VerifyPrefixIsolation(ctx, backend, session, "iso-prod-")
+	var isoErr *vaultmux.PrefixIsolationError
+	if !errors.As(err, &isoErr) {
+		t.Fatalf("VerifyPrefixIsolation() error = %v, want *PrefixIsolationError", err)
+	}
+	if len(isoErr.Names) != 1 || isoErr.Names[0] != "iso-staging-db" {
+		t.Errorf("Names = %v, want [iso-staging-db]", isoErr.Names)
 	}
-	if endpoint != "" {
-		options["endpoint"] = endpoint
+
+	if err := vaultmux.VerifyPrefixIsolation(ctx, backend, session, "iso-"); err != nil {
+		t.Errorf("VerifyPrefixIsolation() with matching prefix error = %v, want nil", err)
 	}
+}
+
+// TestIntegration_ListFullNames verifies ListItems strips the prefix by default
+// and returns stored names with WithFullNames.
+func TestIntegration_ListFullNames(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "fullnames-test-project",
+		"prefix":     "full-",
+	})
+	ctx := context.Background()
 
-	backend, err := New(options, "")
+	if err := backend.CreateItem(ctx, "api-key", "content", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	items, err := backend.ListItems(ctx, session)
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "api-key" {
+		t.Errorf("ListItems() names = %v, want [api-key]", itemNames(items))
 	}
 
+	items, err = backend.ListItemsWithOptions(ctx, session, vaultmux.WithFullNames())
+	if err != nil {
+		t.Fatalf("ListItemsWithOptions() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "full-api-key" {
+		t.Errorf("ListItemsWithOptions(WithFullNames) names = %v, want [full-api-key]", itemNames(items))
+	}
+}
+
+// TestIntegration_SetItem verifies SetItem creates missing secrets and adds
+// versions to existing ones.
+func TestIntegration_SetItem(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "setitem-test-project",
+		"prefix":     "set-",
+	})
 	ctx := context.Background()
 
-	err = backend.Init(ctx)
+	if err := backend.SetItem(ctx, "config", "v1", session); err != nil {
+		t.Fatalf("SetItem() on new name error = %v", err)
+	}
+
+	got, err := backend.GetNotes(ctx, "config", session)
 	if err != nil {
-		t.Fatalf("Init() error = %v", err)
+		t.Fatalf("GetNotes() error = %v", err)
+	}
+	if got != "v1" {
+		t.Errorf("GetNotes() after create = %q, want %q", got, "v1")
+	}
+
+	if err := backend.SetItem(ctx, "config", "v2", session); err != nil {
+		t.Fatalf("SetItem() on existing name error = %v", err)
 	}
 
-	session, err := backend.Authenticate(ctx)
+	got, err = backend.GetNotes(ctx, "config", session)
 	if err != nil {
-		t.Fatalf("Authenticate() error = %v", err)
+		t.Fatalf("GetNotes() error = %v", err)
 	}
+	if got != "v2" {
+		t.Errorf("GetNotes() after update = %q, want %q", got, "v2")
+	}
+}
 
-	// Create 5 test items (GCP is fast, but real API so keep count low)
-	itemCount := 5
-	for i := 0; i < itemCount; i++ {
-		itemName := fmt.Sprintf("item-%d", i)
-		err = backend.CreateItem(ctx, itemName, "content", session)
-		if err != nil {
-			t.Fatalf("CreateItem(%q) error = %v", itemName, err)
+// TestIntegration_Version verifies GetItem reports the resolved version number.
+func TestIntegration_Version(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "version-test-project",
+		"prefix":     "ver-",
+	})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "token", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	item, err := backend.GetItem(ctx, "token", session)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Version != "1" {
+		t.Errorf("Version after create = %q, want %q", item.Version, "1")
+	}
+
+	if err := backend.UpdateItem(ctx, "token", "v2", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+	item, err = backend.GetItem(ctx, "token", session)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Version != "2" {
+		t.Errorf("Version after update = %q, want %q", item.Version, "2")
+	}
+}
+
+// TestIntegration_AdditionalProjects verifies listing across projects and
+// reading from a specific project.
+func TestIntegration_AdditionalProjects(t *testing.T) {
+	endpoint, _ := startMockServer(t)
+	ctx := context.Background()
+
+	// Seed one secret in each project
+	shared, sharedSession := newEndpointBackend(t, endpoint, map[string]string{
+		"project_id": "shared-project",
+		"prefix":     "multi-",
+	})
+	if err := shared.CreateItem(ctx, "tls-cert", "cert", sharedSession); err != nil {
+		t.Fatalf("CreateItem(shared) error = %v", err)
+	}
+
+	backend, session := newEndpointBackend(t, endpoint, map[string]string{
+		"project_id":          "env-project",
+		"prefix":              "multi-",
+		"additional_projects": "shared-project",
+	})
+	if err := backend.CreateItem(ctx, "db-password", "pw", session); err != nil {
+		t.Fatalf("CreateItem(env) error = %v", err)
+	}
+
+	items, err := backend.ListItems(ctx, session)
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	projects := make(map[string]string)
+	for _, item := range items {
+		projects[item.Name] = item.Fields["project"]
+	}
+	want := map[string]string{"db-password": "env-project", "tls-cert": "shared-project"}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("ListItems() projects = %v, want %v", projects, want)
+	}
+
+	item, err := backend.GetItemFromProject(ctx, "shared-project", "tls-cert", session)
+	if err != nil {
+		t.Fatalf("GetItemFromProject() error = %v", err)
+	}
+	if item.Notes != "cert" {
+		t.Errorf("GetItemFromProject() Notes = %q, want %q", item.Notes, "cert")
+	}
+
+	// The primary project is unaffected
+	if _, err := backend.GetItem(ctx, "tls-cert", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetItem() from primary project error = %v, want ErrNotFound", err)
+	}
+}
+
+// newNamesBackend returns a backend on the mock server whose project holds
+// count secrets, for ListItemNames tests and benchmarks.
+func newNamesBackend(tb testing.TB, count int) (*Backend, vaultmux.Session) {
+	tb.Helper()
+	backend, session, _ := newMockBackend(tb, map[string]string{
+		"project_id": "names-test-project",
+		"prefix":     "names-",
+	})
+
+	ctx := context.Background()
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("secret-%03d", i)
+		if err := backend.CreateItem(ctx, name, "value", session); err != nil {
+			tb.Fatalf("CreateItem(%s) error = %v", name, err)
 		}
 	}
 
-	// List all items
+	return backend, session
+}
+
+// TestIntegration_ListItemNames verifies names-only listing.
+func TestIntegration_ListItemNames(t *testing.T) {
+	backend, session := newNamesBackend(t, 100)
+
+	names, err := vaultmux.ListItemNames(context.Background(), backend, session)
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	if len(names) != 100 {
+		t.Errorf("ListItemNames() returned %d names, want 100", len(names))
+	}
+	if !slices.Contains(names, "secret-000") {
+		t.Errorf("ListItemNames() missing %q (prefix should be stripped)", "secret-000")
+	}
+}
+
+// BenchmarkIntegration_ListItemNames and BenchmarkIntegration_ListItems
+// compare allocations of names-only listing against full listing.
+func BenchmarkIntegration_ListItemNames(b *testing.B) {
+	backend, session := newNamesBackend(b, 100)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := backend.ListItemNames(ctx, session); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIntegration_ListItems(b *testing.B) {
+	backend, session := newNamesBackend(b, 100)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := backend.ListItems(ctx, session); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestIntegration_ListModifiedSince verifies that adding a version marks a
+// secret as modified.
+func TestIntegration_ListModifiedSince(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "modified-test-project",
+		"prefix":     "mod-",
+	})
+	ctx := context.Background()
+
+	for _, name := range []string{"stable", "rotated"} {
+		if err := backend.CreateItem(ctx, name, "v1", session); err != nil {
+			t.Fatalf("CreateItem(%s) error = %v", name, err)
+		}
+	}
+
+	// The mock server timestamps versions with its own clock
+	time.Sleep(10 * time.Millisecond)
+	since := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := backend.UpdateItem(ctx, "rotated", "v2", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+
+	items, err := vaultmux.ListItemsModifiedSince(ctx, backend, since, session)
+	if err != nil {
+		t.Fatalf("ListItemsModifiedSince() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "rotated" {
+		t.Errorf("ListItemsModifiedSince() = %v, want [rotated]", itemNames(items))
+	}
+	if len(items) == 1 && !items[0].Modified.After(since) {
+		t.Errorf("Modified = %v, want after %v", items[0].Modified, since)
+	}
+}
+
+func itemNames(items []*vaultmux.Item) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names
+}
+
+// TestIntegration_ProxyURL verifies that Secret Manager connections go
+// through the proxy_url proxy.
+func TestIntegration_ProxyURL(t *testing.T) {
+	proxy := startConnectProxy(t)
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "proxy-test-project",
+		"prefix":     "proxy-",
+		"proxy_url":  proxy.URL(),
+	})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "token", "via-proxy", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	if got, err := backend.GetNotes(ctx, "token", session); err != nil || got != "via-proxy" {
+		t.Errorf("GetNotes() = %q, %v; want %q", got, err, "via-proxy")
+	}
+	if len(proxy.Targets()) == 0 {
+		t.Error("no connections went through the proxy")
+	}
+}
+
+// TestIntegration_ListItemsChan verifies streaming listing and early close
+// on cancellation.
+func TestIntegration_ListItemsChan(t *testing.T) {
+	backend, session := newNamesBackend(t, 50)
+
+	ch, err := vaultmux.ListItemsChan(context.Background(), backend, session)
+	if err != nil {
+		t.Fatalf("ListItemsChan() error = %v", err)
+	}
+	var names []string
+	for v := range ch {
+		if v.Err != nil {
+			t.Fatalf("ListItemsChan() element error = %v", v.Err)
+		}
+		names = append(names, v.Item.Name)
+	}
+	if len(names) != 50 {
+		t.Errorf("ListItemsChan() delivered %d items, want 50", len(names))
+	}
+	if !slices.Contains(names, "secret-049") {
+		t.Errorf("ListItemsChan() missing %q", "secret-049")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err = vaultmux.ListItemsChan(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("ListItemsChan() error = %v", err)
+	}
+	<-ch
+	cancel()
+
+	received := 1
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+			received++
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+	if received == 50 {
+		t.Error("cancelled stream still delivered every item")
+	}
+}
+
+// TestIntegration_VersionAliases verifies reading by version number and by
+// alias.
+func TestIntegration_VersionAliases(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "alias-test-project",
+		"prefix":     "alias-",
+	})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "token", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	for _, v := range []string{"v2", "v3"} {
+		if err := backend.UpdateItem(ctx, "token", v, session); err != nil {
+			t.Fatalf("UpdateItem(%s) error = %v", v, err)
+		}
+	}
+
+	item, err := backend.GetItemVersion(ctx, "token", "2", session)
+	if err != nil {
+		t.Fatalf("GetItemVersion(2) error = %v", err)
+	}
+	if item.Notes != "v2" || item.Version != "2" {
+		t.Errorf("GetItemVersion(2) = %q (version %s), want v2 (version 2)", item.Notes, item.Version)
+	}
+
+	if err := backend.SetVersionAlias(ctx, "token", "prod", "2", session); err != nil {
+		t.Fatalf("SetVersionAlias() error = %v", err)
+	}
+
+	item, err = backend.GetItemVersion(ctx, "token", "prod", session)
+	if err != nil {
+		t.Fatalf("GetItemVersion(prod) error = %v", err)
+	}
+	if item.Notes != "v2" || item.Version != "2" {
+		t.Errorf("GetItemVersion(prod) = %q (version %s), want v2 (version 2)", item.Notes, item.Version)
+	}
+}
+
+// TestIntegration_RawItems creates a secret outside the backend prefix
+// directly in storage, then reads and deletes it by its stored name.
+func TestIntegration_RawItems(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "raw-test-project",
+		"prefix":     "myapp-",
+	})
+	ctx := context.Background()
+
+	secret, err := backend.client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   "projects/raw-test-project",
+		SecretId: "other-team-db",
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+	if _, err := backend.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  secret.Name,
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte("raw-value")},
+	}); err != nil {
+		t.Fatalf("AddSecretVersion() error = %v", err)
+	}
+
+	if _, err := backend.GetItem(ctx, "other-team-db", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetItem() outside prefix error = %v, want ErrNotFound", err)
+	}
+
+	item, err := backend.GetItemRaw(ctx, "other-team-db", session)
+	if err != nil {
+		t.Fatalf("GetItemRaw() error = %v", err)
+	}
+	if item.Notes != "raw-value" || item.Name != "other-team-db" {
+		t.Errorf("GetItemRaw() = %s %q, want other-team-db %q", item.Name, item.Notes, "raw-value")
+	}
+
+	if err := backend.DeleteItemRaw(ctx, "other-team-db", session); err != nil {
+		t.Fatalf("DeleteItemRaw() error = %v", err)
+	}
+	if _, err := backend.GetItemRaw(ctx, "other-team-db", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetItemRaw() after delete error = %v, want ErrNotFound", err)
+	}
+	if backend.Prefix() != "myapp-" {
+		t.Errorf("Prefix() = %q after raw access, want myapp-", backend.Prefix())
+	}
+}
+
+func TestIntegration_Describe(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "describe-test-project",
+		"prefix":     "describe-",
+	})
+	ctx := context.Background()
+
+	if _, err := backend.client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   "projects/describe-test-project",
+		SecretId: "describe-db",
+		Secret: &secretmanagerpb.Secret{
+			Labels: map[string]string{"env": "prod"},
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+
+	metadata, err := backend.Describe(ctx, "db", session)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+
+	if _, ok := metadata["createTime"].(string); !ok {
+		t.Errorf("Describe() createTime = %v, want a timestamp", metadata["createTime"])
+	}
+	if labels, _ := metadata["labels"].(map[string]any); labels["env"] != "prod" {
+		t.Errorf("Describe() labels = %v, want env=prod", metadata["labels"])
+	}
+	if replication, _ := metadata["replication"].(map[string]any); replication["automatic"] == nil {
+		t.Errorf("Describe() replication = %v, want automatic", metadata["replication"])
+	}
+
+	if _, err := backend.Describe(ctx, "missing", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("Describe(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestIntegration_RegionalSecrets(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "regional-test-project",
+		"prefix":     "myapp-",
+		"location":   "europe-west3",
+	})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "db-password", "eu-only", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	item, err := backend.GetItem(ctx, "db-password", session)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Notes != "eu-only" {
+		t.Errorf("GetItem() notes = %q, want eu-only", item.Notes)
+	}
+	if want := "projects/regional-test-project/locations/europe-west3/secrets/myapp-db-password"; item.ID != want {
+		t.Errorf("GetItem() ID = %q, want %q", item.ID, want)
+	}
+
 	items, err := backend.ListItems(ctx, session)
 	if err != nil {
 		t.Fatalf("ListItems() error = %v", err)
 	}
+	if len(items) != 1 || items[0].Name != "db-password" {
+		t.Errorf("ListItems() = %v, want [db-password]", items)
+	}
 
-	if len(items) < itemCount {
-		t.Errorf("ListItems() returned %d items, want at least %d", len(items), itemCount)
+	if err := backend.DeleteItem(ctx, "db-password", session); err != nil {
+		t.Fatalf("DeleteItem() error = %v", err)
+	}
+	if exists, _ := backend.ItemExists(ctx, "db-password", session); exists {
+		t.Error("regional secret still exists after DeleteItem()")
 	}
+}
 
-	// Cleanup
-	for i := 0; i < itemCount; i++ {
-		itemName := fmt.Sprintf("item-%d", i)
-		_ = backend.DeleteItem(ctx, itemName, session)
+func TestIntegration_MaxListResults(t *testing.T) {
+	endpoint, _ := startMockServer(t)
+	ctx := context.Background()
+	backend, err := vaultmux.New(vaultmux.Config{
+		Backend: vaultmux.BackendGCPSecretManager,
+		Options: map[string]string{
+			"project_id": "limit-test-project",
+			"prefix":     "limit-",
+			"endpoint":   endpoint,
+		},
+		MaxListResults: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Init(ctx); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	session, _ := backend.Authenticate(ctx)
+
+	for i := range 50 {
+		name := fmt.Sprintf("secret-%02d", i)
+		if err := backend.CreateItem(ctx, name, "value", session); err != nil {
+			t.Fatalf("CreateItem(%s) error = %v", name, err)
+		}
+	}
+
+	items, err := backend.ListItems(ctx, session)
+	if !errors.Is(err, vaultmux.ErrListTruncated) {
+		t.Fatalf("ListItems() error = %v, want ErrListTruncated", err)
+	}
+	if len(items) != 10 {
+		t.Errorf("ListItems() returned %d items, want 10", len(items))
+	}
+
+	// A per-call limit above the number of secrets lists them all
+	items, err = backend.(vaultmux.ItemLister).ListItemsWithOptions(ctx, session, vaultmux.WithMaxResults(100))
+	if err != nil {
+		t.Fatalf("ListItemsWithOptions() error = %v", err)
+	}
+	if len(items) != 50 {
+		t.Errorf("ListItemsWithOptions() returned %d items, want 50", len(items))
+	}
+}
+
+func TestIntegration_PinnedSession(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "pinned-test-project",
+		"prefix":     "deploy-",
+	})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "db-password", "original", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	pinned, err := vaultmux.PinnedSession(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("PinnedSession() error = %v", err)
+	}
+
+	if err := backend.UpdateItem(ctx, "db-password", "rotated", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+
+	item, err := backend.GetItem(ctx, "db-password", pinned)
+	if err != nil {
+		t.Fatalf("pinned GetItem() error = %v", err)
+	}
+	if item.Notes != "original" || item.Version != "1" {
+		t.Errorf("pinned GetItem() = %q version %q, want original version 1", item.Notes, item.Version)
+	}
+	if notes, _ := backend.GetNotes(ctx, "db-password", session); notes != "rotated" {
+		t.Errorf("unpinned GetNotes() = %q, want rotated", notes)
+	}
+}
+
+func TestIntegration_DestroyAllVersions(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "destroy-test-project",
+		"prefix":     "wipe-",
+	})
+	ctx := context.Background()
+
+	secretPath := "projects/destroy-test-project/secrets/wipe-api-key"
+	if err := backend.CreateItem(ctx, "api-key", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	for _, value := range []string{"v2", "v3"} {
+		if err := backend.UpdateItem(ctx, "api-key", value, session); err != nil {
+			t.Fatalf("UpdateItem() error = %v", err)
+		}
+	}
+
+	if err := backend.DestroyAllVersions(ctx, "api-key", session); err != nil {
+		t.Fatalf("DestroyAllVersions() error = %v", err)
+	}
+
+	if _, err := backend.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: secretPath}); err != nil {
+		t.Errorf("GetSecret() after DestroyAllVersions error = %v, want the secret kept", err)
+	}
+	_, err := backend.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: secretPath + "/versions/latest"})
+	if code := status.Code(err); code != codes.NotFound && code != codes.FailedPrecondition {
+		t.Errorf("AccessSecretVersion(latest) code = %v, want NotFound or FailedPrecondition", code)
+	}
+	if _, err := backend.GetItem(ctx, "api-key", session); err == nil {
+		t.Error("GetItem() succeeded after all versions were destroyed")
+	}
+
+	// Destroyed versions are skipped on a second run
+	if err := backend.DestroyAllVersions(ctx, "api-key", session); err != nil {
+		t.Errorf("second DestroyAllVersions() error = %v", err)
+	}
+}
+
+func TestIntegration_GroupByLabel(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id": "labels-test-project",
+		"prefix":     "grouped-",
+	})
+	ctx := context.Background()
+
+	items := []struct {
+		name string
+		opts []vaultmux.CreateOption
+	}{
+		{"alpha-api", []vaultmux.CreateOption{vaultmux.WithTags(map[string]string{"team": "a"})}},
+		{"alpha-db", []vaultmux.CreateOption{vaultmux.WithTags(map[string]string{"team": "a", "env": "prod"})}},
+		{"beta-api", []vaultmux.CreateOption{vaultmux.WithTags(map[string]string{"team": "b"})}},
+		{"shared", nil},
+	}
+	for _, item := range items {
+		if err := backend.CreateItemWithOptions(ctx, item.name, "value", session, item.opts...); err != nil {
+			t.Fatalf("CreateItemWithOptions(%s) error = %v", item.name, err)
+		}
+	}
+
+	groups, err := backend.GroupByLabel(ctx, "team", session)
+	if err != nil {
+		t.Fatalf("GroupByLabel() error = %v", err)
+	}
+
+	got := make(map[string][]string)
+	for value, group := range groups {
+		for _, item := range group {
+			got[value] = append(got[value], item.Name)
+		}
+		slices.Sort(got[value])
+	}
+	want := map[string][]string{
+		"a": {"alpha-api", "alpha-db"},
+		"b": {"beta-api"},
+		"":  {"shared"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupByLabel() = %v, want %v", got, want)
+	}
+
+	for _, item := range groups[""] {
+		if item.Tags != nil {
+			t.Errorf("%s Tags = %v, want internal labels hidden", item.Name, item.Tags)
+		}
+	}
+}
+
+func TestIntegration_UpdateItemWithResult(t *testing.T) {
+	endpoint, _ := startMockServer(t)
+	newBackend := func(skipNoopUpdates string) (*Backend, vaultmux.Session) {
+		return newEndpointBackend(t, endpoint, map[string]string{
+			"project_id":        "update-test-project",
+			"prefix":            "upd-",
+			"skip_noop_updates": skipNoopUpdates,
+		})
+	}
+	skipping, session := newBackend("true")
+	always, _ := newBackend("false")
+
+	ctx := context.Background()
+	if err := skipping.CreateItem(ctx, "token", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	result, err := skipping.UpdateItemWithResult(ctx, "token", "v1", session)
+	if err != nil {
+		t.Fatalf("UpdateItemWithResult(same) error = %v", err)
+	}
+	if result != (vaultmux.UpdateResult{}) {
+		t.Errorf("UpdateItemWithResult(same) with skip_noop_updates = %+v, want no change and no version", result)
+	}
+
+	result, err = skipping.UpdateItemWithResult(ctx, "token", "v2", session)
+	if err != nil {
+		t.Fatalf("UpdateItemWithResult(new) error = %v", err)
+	}
+	if result != (vaultmux.UpdateResult{Changed: true, NewVersion: "2"}) {
+		t.Errorf("UpdateItemWithResult(new) = %+v, want changed with version 2", result)
+	}
+
+	result, err = always.UpdateItemWithResult(ctx, "token", "v2", session)
+	if err != nil {
+		t.Fatalf("UpdateItemWithResult(same) error = %v", err)
+	}
+	if result != (vaultmux.UpdateResult{NewVersion: "3"}) {
+		t.Errorf("UpdateItemWithResult(same) without skip_noop_updates = %+v, want unchanged with version 3", result)
+	}
+
+	if _, err := skipping.UpdateItemWithResult(ctx, "missing", "v1", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("UpdateItemWithResult(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestIntegration_SkipNoopUpdates(t *testing.T) {
+	backend, session, _ := newMockBackend(t, map[string]string{
+		"project_id":        "noop-test-project",
+		"prefix":            "noop-",
+		"skip_noop_updates": "true",
+	})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "token", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	for _, step := range []struct {
+		value, wantVersion string
+	}{
+		{"v2", "2"},
+		{"v2", "2"}, // Same content: no new version
+		{"v3", "3"},
+		{"v2", "4"},
+	} {
+		if err := backend.UpdateItem(ctx, "token", step.value, session); err != nil {
+			t.Fatalf("UpdateItem(%s) error = %v", step.value, err)
+		}
+		item, err := backend.GetItem(ctx, "token", session)
+		if err != nil {
+			t.Fatalf("GetItem() error = %v", err)
+		}
+		if item.Notes != step.value || item.Version != step.wantVersion {
+			t.Errorf("after UpdateItem(%s): %q version %s, want version %s", step.value, item.Notes, item.Version, step.wantVersion)
+		}
 	}
 }
@@ -3,11 +3,21 @@ package gcpsecrets
 import (
 	"context"
 	"errors"
+	"maps"
+	"net"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
 	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/internal/gcpmock"
 )
 
 func TestNew(t *testing.T) {
@@ -24,7 +34,7 @@ func TestNew(t *testing.T) {
 				"prefix": "test-",
 			},
 			wantErr:   true,
-			errString: "project_id is required",
+			errString: "invalid project_id: required",
 		},
 		{
 			name: "defaults",
@@ -59,6 +69,69 @@ func TestNew(t *testing.T) {
 				endpoint:  "localhost:8080",
 			},
 		},
+		{
+			name: "additional projects",
+			options: map[string]string{
+				"project_id":          "my-project",
+				"additional_projects": "shared-secrets, my-project,,prod-env",
+			},
+			want: &Backend{
+				projectID:          "my-project",
+				prefix:             "vaultmux-",
+				additionalProjects: []string{"shared-secrets", "prod-env"},
+			},
+		},
+		{
+			name: "skip connectivity check",
+			options: map[string]string{
+				"project_id":              "my-project",
+				"skip_connectivity_check": "true",
+			},
+			want: &Backend{
+				projectID:             "my-project",
+				prefix:                "vaultmux-",
+				skipConnectivityCheck: true,
+			},
+		},
+		{
+			name: "invalid skip connectivity check",
+			options: map[string]string{
+				"project_id":              "my-project",
+				"skip_connectivity_check": "yes please",
+			},
+			wantErr:   true,
+			errString: "invalid skip_connectivity_check",
+		},
+		{
+			name: "location",
+			options: map[string]string{
+				"project_id": "my-project",
+				"location":   "europe-west3",
+			},
+			want: &Backend{
+				projectID: "my-project",
+				prefix:    "vaultmux-",
+				location:  "europe-west3",
+			},
+		},
+		{
+			name: "malformed location",
+			options: map[string]string{
+				"project_id": "my-project",
+				"location":   "europe-west3/secrets",
+			},
+			wantErr:   true,
+			errString: "invalid location",
+		},
+		{
+			name: "malformed topic",
+			options: map[string]string{
+				"project_id": "my-project",
+				"topics":     "projects/my-project/subscriptions/rotation",
+			},
+			wantErr:   true,
+			errString: "invalid topics",
+		},
 	}
 
 	for _, tt := range tests {
@@ -73,6 +146,9 @@ func TestNew(t *testing.T) {
 				if !strings.Contains(err.Error(), tt.errString) {
 					t.Errorf("New() error = %q, want error containing %q", err.Error(), tt.errString)
 				}
+				if !errors.Is(err, vaultmux.ErrInvalidConfig) {
+					t.Errorf("New() error = %v, want ErrInvalidConfig", err)
+				}
 				return
 			}
 
@@ -89,6 +165,50 @@ func TestNew(t *testing.T) {
 			if got.endpoint != tt.want.endpoint {
 				t.Errorf("endpoint = %q, want %q", got.endpoint, tt.want.endpoint)
 			}
+			if got.location != tt.want.location {
+				t.Errorf("location = %q, want %q", got.location, tt.want.location)
+			}
+			if got.skipConnectivityCheck != tt.want.skipConnectivityCheck {
+				t.Errorf("skipConnectivityCheck = %v, want %v", got.skipConnectivityCheck, tt.want.skipConnectivityCheck)
+			}
+			if !reflect.DeepEqual(got.additionalProjects, tt.want.additionalProjects) {
+				t.Errorf("additionalProjects = %v, want %v", got.additionalProjects, tt.want.additionalProjects)
+			}
+		})
+	}
+}
+
+func TestParseTopics(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: nil},
+		{
+			name:  "two topics",
+			value: "projects/p/topics/rotation, projects/p/topics/audit",
+			want:  []string{"projects/p/topics/rotation", "projects/p/topics/audit"},
+		},
+		{name: "bare name", value: "rotation", wantErr: true},
+		{name: "extra segment", value: "projects/p/topics/a/b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topics, err := parseTopics(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTopics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, topic := range topics {
+				got = append(got, topic.GetName())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseTopics() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -151,6 +271,10 @@ func TestBackend_LocationManagement(t *testing.T) {
 		if !errors.Is(err, vaultmux.ErrNotSupported) {
 			t.Errorf("ListLocations() error = %v, want ErrNotSupported", err)
 		}
+		var notSupported *vaultmux.NotSupportedError
+		if !errors.As(err, &notSupported) || notSupported.Backend != "gcpsecrets" || notSupported.Operation != "ListLocations" {
+			t.Errorf("ListLocations() error = %#v, want NotSupportedError for gcpsecrets ListLocations", err)
+		}
 	})
 
 	t.Run("LocationExists", func(t *testing.T) {
@@ -262,8 +386,46 @@ func TestSession_IsValid(t *testing.T) {
 	}
 }
 
+func TestBackend_HandleGCPError_RetryInfo(t *testing.T) {
+	backend, _ := New(map[string]string{"project_id": "test"}, "")
+
+	st, err := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(7 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("WithDetails() error = %v", err)
+	}
+
+	got := backend.handleGCPError(st.Err(), "get", "api-key")
+
+	var be *vaultmux.BackendError
+	if !errors.As(got, &be) {
+		t.Fatalf("handleGCPError() = %T, want *vaultmux.BackendError", got)
+	}
+	if be.RetryAfter != 7*time.Second {
+		t.Errorf("RetryAfter = %v, want %v", be.RetryAfter, 7*time.Second)
+	}
+	if delay, ok := vaultmux.RetryAfter(got); !ok || delay != 7*time.Second {
+		t.Errorf("vaultmux.RetryAfter() = (%v, %v), want (7s, true)", delay, ok)
+	}
+
+	// Without RetryInfo the delay is zero
+	got = backend.handleGCPError(status.Error(codes.ResourceExhausted, "quota exceeded"), "get", "api-key")
+	if _, ok := vaultmux.RetryAfter(got); ok {
+		t.Error("RetryAfter() ok = true for status without RetryInfo, want false")
+	}
+}
+
 func TestBackend_InterfaceCompliance(t *testing.T) {
 	var _ vaultmux.Backend = (*Backend)(nil)
+	var _ vaultmux.ItemSetter = (*Backend)(nil)
+	var _ vaultmux.ModifiedSinceLister = (*Backend)(nil)
+	var _ vaultmux.ItemNameLister = (*Backend)(nil)
+	var _ vaultmux.RawItemAccessor = (*Backend)(nil)
+	var _ vaultmux.Describer = (*Backend)(nil)
+	var _ vaultmux.ItemStreamer = (*Backend)(nil)
+	var _ vaultmux.LimitsReporter = (*Backend)(nil)
+	var _ vaultmux.AccessPolicyReader = (*Backend)(nil)
 }
 
 // Integration test note:
@@ -274,3 +436,172 @@ func TestBackend_InterfaceCompliance(t *testing.T) {
 //
 // Alternatively, use mocked SDK for offline testing.
 // See gcpsecrets_integration_test.go for full CRUD integration tests.
+
+func TestNew_ConfigErrorField(t *testing.T) {
+	_, err := New(map[string]string{"prefix": "app-"}, "")
+
+	var cfgErr *vaultmux.ConfigError
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("New() error = %v, want *vaultmux.ConfigError", err)
+	}
+	if cfgErr.Field != "project_id" {
+		t.Errorf("ConfigError.Field = %q, want project_id", cfgErr.Field)
+	}
+}
+
+func TestBackend_Init_Timeout(t *testing.T) {
+	// A listener that accepts connections but never answers, like a
+	// black-holed endpoint
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	backend, err := vaultmux.New(vaultmux.Config{
+		Backend:     vaultmux.BackendGCPSecretManager,
+		Options:     map[string]string{"project_id": "timeout-project", "endpoint": ln.Addr().String()},
+		InitTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	start := time.Now()
+	err = backend.Init(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Init() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Init() took %v with a 200ms InitTimeout", elapsed)
+	}
+}
+
+// newMockBackend starts an in-process gcpmock server and returns a backend
+// connected to it, initialized and authenticated, with the server for
+// inspecting what the backend stored. options default project_id to
+// "test-project".
+func newMockBackend(t testing.TB, options map[string]string) (*Backend, vaultmux.Session, *gcpmock.Server) {
+	t.Helper()
+	endpoint, srv := startMockServer(t)
+	backend, session := newEndpointBackend(t, endpoint, options)
+	return backend, session, srv
+}
+
+// startMockServer starts an in-process gcpmock server, stopped when the test
+// ends, and returns its endpoint.
+func startMockServer(t testing.TB) (string, *gcpmock.Server) {
+	t.Helper()
+	srv := gcpmock.NewServer()
+	endpoint, stop, err := srv.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+	return endpoint, srv
+}
+
+// newEndpointBackend returns an initialized, authenticated backend for
+// endpoint, or for the real Secret Manager API if endpoint is empty.
+// options default project_id to "test-project".
+func newEndpointBackend(t testing.TB, endpoint string, options map[string]string) (*Backend, vaultmux.Session) {
+	t.Helper()
+	options = maps.Clone(options)
+	if options == nil {
+		options = make(map[string]string)
+	}
+	if endpoint != "" {
+		options["endpoint"] = endpoint
+	}
+	if options["project_id"] == "" {
+		options["project_id"] = "test-project"
+	}
+	backend, err := New(options, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := backend.Init(ctx); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() { _ = backend.Close() })
+	session, err := backend.Authenticate(ctx)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	return backend, session
+}
+
+func TestBackend_CreateItem_Topics(t *testing.T) {
+	topics := "projects/test-project/topics/rotation,projects/test-project/topics/audit"
+	backend, session, srv := newMockBackend(t, map[string]string{"topics": topics})
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "api-key", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	secret, err := srv.Storage().GetSecret("projects/test-project/secrets/vaultmux-api-key")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	var got []string
+	for _, topic := range secret.GetTopics() {
+		got = append(got, topic.GetName())
+	}
+	if want := strings.Split(topics, ","); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSecret() topics = %v, want %v", got, want)
+	}
+}
+
+func TestBackend_DeleteItemIfMatch(t *testing.T) {
+	backend, session, _ := newMockBackend(t, nil)
+	ctx := context.Background()
+
+	if err := backend.CreateItem(ctx, "api-key", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	etag := func() string {
+		t.Helper()
+		item, err := backend.GetItem(ctx, "api-key", session)
+		if err != nil {
+			t.Fatalf("GetItem() error = %v", err)
+		}
+		return item.Fields[EtagField]
+	}
+	stale := etag()
+
+	if err := backend.SetVersionAlias(ctx, "api-key", "prod", "1", session); err != nil {
+		t.Fatalf("SetVersionAlias() error = %v", err)
+	}
+	current := etag()
+	if err := backend.DeleteItemIfMatch(ctx, "api-key", stale, session); !errors.Is(err, vaultmux.ErrConflict) {
+		t.Fatalf("DeleteItemIfMatch(stale etag) error = %v, want ErrConflict", err)
+	}
+	if exists, _ := backend.ItemExists(ctx, "api-key", session); !exists {
+		t.Fatal("secret deleted despite a stale etag")
+	}
+
+	// A new version leaves the etag alone, so the delete still goes through
+	if err := backend.UpdateItem(ctx, "api-key", "v2", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+	if err := backend.DeleteItemIfMatch(ctx, "api-key", current, session); err != nil {
+		t.Fatalf("DeleteItemIfMatch(current etag) error = %v", err)
+	}
+	if exists, _ := backend.ItemExists(ctx, "api-key", session); exists {
+		t.Error("secret still exists after DeleteItemIfMatch with the current etag")
+	}
+	if err := backend.DeleteItemIfMatch(ctx, "api-key", current, session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("DeleteItemIfMatch(deleted) error = %v, want ErrNotFound", err)
+	}
+}
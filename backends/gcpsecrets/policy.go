@@ -0,0 +1,44 @@
+package gcpsecrets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// GetAccessPolicy returns the IAM policy set on the secret itself.
+// Project- and folder-level grants also apply but are not included.
+func (b *Backend) GetAccessPolicy(ctx context.Context, name string, session vaultmux.Session) (vaultmux.AccessPolicy, error) {
+	if !session.IsValid(ctx) {
+		return vaultmux.AccessPolicy{}, vaultmux.ErrNotAuthenticated
+	}
+
+	secretPath := b.secretPath(b.projectID, b.secretName(name))
+	policy, err := b.client.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{Resource: secretPath})
+	if err != nil {
+		return vaultmux.AccessPolicy{}, b.handleGCPError(err, "get-policy", name)
+	}
+	return accessPolicyFromIAM(policy), nil
+}
+
+// accessPolicyFromIAM normalizes an IAM policy. Conditional bindings keep
+// their role with the condition title appended, since the grant only
+// applies when the condition holds.
+func accessPolicyFromIAM(policy *iampb.Policy) vaultmux.AccessPolicy {
+	var out vaultmux.AccessPolicy
+	for _, binding := range policy.GetBindings() {
+		role := binding.GetRole()
+		if cond := binding.GetCondition(); cond != nil {
+			role = fmt.Sprintf("%s (condition: %s)", role, cond.GetTitle())
+		}
+		out.Bindings = append(out.Bindings, vaultmux.Binding{
+			Role:    role,
+			Members: slices.Clone(binding.GetMembers()),
+		})
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package gcpsecrets
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/genproto/googleapis/type/expr"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestAccessPolicyFromIAM(t *testing.T) {
+	policy := &iampb.Policy{
+		Version: 3,
+		Bindings: []*iampb.Binding{
+			{
+				Role:    "roles/secretmanager.secretAccessor",
+				Members: []string{"serviceAccount:app@proj.iam.gserviceaccount.com", "group:ops@example.com"},
+			},
+			{
+				Role:      "roles/secretmanager.viewer",
+				Members:   []string{"user:auditor@example.com"},
+				Condition: &expr.Expr{Title: "until-2027", Expression: `request.time < timestamp("2027-01-01T00:00:00Z")`},
+			},
+		},
+	}
+
+	want := vaultmux.AccessPolicy{Bindings: []vaultmux.Binding{
+		{
+			Role:    "roles/secretmanager.secretAccessor",
+			Members: []string{"serviceAccount:app@proj.iam.gserviceaccount.com", "group:ops@example.com"},
+		},
+		{
+			Role:    "roles/secretmanager.viewer (condition: until-2027)",
+			Members: []string{"user:auditor@example.com"},
+		},
+	}}
+
+	if got := accessPolicyFromIAM(policy); !reflect.DeepEqual(got, want) {
+		t.Errorf("accessPolicyFromIAM() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAccessPolicyFromIAM_Empty(t *testing.T) {
+	if got := accessPolicyFromIAM(&iampb.Policy{}); len(got.Bindings) != 0 {
+		t.Errorf("accessPolicyFromIAM(empty) = %+v, want no bindings", got)
+	}
+}
@@ -0,0 +1,81 @@
+package gcpsecrets
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/proxy"
+)
+
+// proxyDialer returns a gRPC context dialer that reaches addr through the
+// proxy at u: SOCKS5 for socks5/socks5h URLs, HTTP CONNECT otherwise.
+func proxyDialer(u *url.URL) func(ctx context.Context, addr string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		if u.Scheme == "socks5" || u.Scheme == "socks5h" {
+			d, err := proxy.FromURL(u, proxy.Direct)
+			if err != nil {
+				return nil, err
+			}
+			return d.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+		}
+		return dialConnect(ctx, u, addr)
+	}
+}
+
+// dialConnect opens a tunnel to addr with an HTTP CONNECT request.
+func dialConnect(ctx context.Context, u *url.URL, addr string) (net.Conn, error) {
+	proxyAddr := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		req.SetBasicAuth(u.User.Username(), password)
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	// The server sends nothing after the CONNECT response until the client
+	// speaks, so the buffered reader cannot swallow tunnel bytes.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT %s: %s", addr, resp.Status)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,126 @@
+package gcpsecrets
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// connectProxy is a stub HTTP CONNECT proxy that records tunnel targets.
+type connectProxy struct {
+	listener net.Listener
+	mu       sync.Mutex
+	targets  []string
+	auth     []string
+}
+
+func startConnectProxy(t testing.TB) *connectProxy {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &connectProxy{listener: l}
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p
+}
+
+func (p *connectProxy) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	br := bufio.NewReader(conn)
+	req, err := http.ReadRequest(br)
+	if err != nil || req.Method != http.MethodConnect {
+		return
+	}
+	p.mu.Lock()
+	p.targets = append(p.targets, req.Host)
+	p.auth = append(p.auth, req.Header.Get("Proxy-Authorization"))
+	p.mu.Unlock()
+
+	upstream, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer func() { _ = upstream.Close() }()
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+
+	go func() { _, _ = io.Copy(upstream, br) }()
+	_, _ = io.Copy(conn, upstream)
+}
+
+func (p *connectProxy) URL() string { return "http://" + p.listener.Addr().String() }
+
+func (p *connectProxy) Targets() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.targets...)
+}
+
+func TestProxyDialer_Connect(t *testing.T) {
+	// Upstream echoes one line back
+	upstream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = upstream.Close() }()
+	go func() {
+		conn, err := upstream.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		_, _ = io.WriteString(conn, line)
+	}()
+
+	proxy := startConnectProxy(t)
+	u, _ := url.Parse(proxy.URL())
+	u.User = url.UserPassword("user", "secret")
+
+	conn, err := proxyDialer(u)(context.Background(), upstream.Addr().String())
+	if err != nil {
+		t.Fatalf("dial error = %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := io.WriteString(conn, "ping\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := bufio.NewReader(conn).ReadString('\n'); got != "ping\n" {
+		t.Errorf("echo through tunnel = %q, want %q", got, "ping\n")
+	}
+
+	if targets := proxy.Targets(); len(targets) != 1 || targets[0] != upstream.Addr().String() {
+		t.Errorf("proxy targets = %v, want [%s]", targets, upstream.Addr())
+	}
+	if proxy.auth[0] != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("Proxy-Authorization = %q", proxy.auth[0])
+	}
+}
+
+func TestProxyDialer_ConnectRefused(t *testing.T) {
+	proxy := startConnectProxy(t)
+	u, _ := url.Parse(proxy.URL())
+
+	// Nothing listens on port 1, so the stub proxy answers 502
+	if _, err := proxyDialer(u)(context.Background(), "127.0.0.1:1"); err == nil {
+		t.Error("dial through proxy to closed port succeeded")
+	}
+}
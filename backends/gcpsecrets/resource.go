@@ -0,0 +1,23 @@
+package gcpsecrets
+
+import "github.com/blackwell-systems/vaultmux/internal/gcpresource"
+
+// ParseSecretName splits a secret resource name of the form
+// projects/{project}/secrets/{secret}, or
+// projects/{project}/locations/{location}/secrets/{secret} for a regional
+// secret. Malformed names return a gRPC InvalidArgument error, so servers
+// implementing the Secret Manager API can return it to clients unchanged.
+func ParseSecretName(name string) (project, secret string, err error) {
+	n, err := gcpresource.ParseSecretName(name)
+	return n.Project, n.Secret, err
+}
+
+// ParseVersionName splits a secret version resource name of the form
+// projects/{project}/secrets/{secret}/versions/{version}, where version is a
+// number or an alias such as "latest". Regional secret versions are accepted
+// as for ParseSecretName. Malformed names return a gRPC InvalidArgument
+// error.
+func ParseVersionName(name string) (project, secret, version string, err error) {
+	n, version, err := gcpresource.ParseVersionName(name)
+	return n.Project, n.Secret, version, err
+}
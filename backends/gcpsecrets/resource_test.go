@@ -0,0 +1,86 @@
+package gcpsecrets
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSecretName(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantProject string
+		wantSecret  string
+		wantErr     bool
+	}{
+		{input: "projects/my-proj/secrets/api-key", wantProject: "my-proj", wantSecret: "api-key"},
+		{input: "projects/123456/secrets/a_b", wantProject: "123456", wantSecret: "a_b"},
+		{input: "projects/my-proj/locations/europe-west3/secrets/api-key", wantProject: "my-proj", wantSecret: "api-key"},
+		{input: "projects/my-proj/locations//secrets/api-key", wantErr: true},
+		{input: "projects/my-proj/regions/europe-west3/secrets/api-key", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "projects/my-proj", wantErr: true},
+		{input: "projects/my-proj/secrets/", wantErr: true},
+		{input: "projects//secrets/api-key", wantErr: true},
+		{input: "folders/my-proj/secrets/api-key", wantErr: true},
+		{input: "projects/my-proj/topics/api-key", wantErr: true},
+		{input: "projects/my-proj/secrets/api-key/versions/1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			project, secret, err := ParseSecretName(tt.input)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("ParseSecretName(%q) error = %v, want InvalidArgument", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSecretName(%q) error = %v", tt.input, err)
+			}
+			if project != tt.wantProject || secret != tt.wantSecret {
+				t.Errorf("ParseSecretName(%q) = %q, %q; want %q, %q", tt.input, project, secret, tt.wantProject, tt.wantSecret)
+			}
+		})
+	}
+}
+
+func TestParseVersionName(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantProject string
+		wantSecret  string
+		wantVersion string
+		wantErr     bool
+	}{
+		{input: "projects/p/secrets/s/versions/3", wantProject: "p", wantSecret: "s", wantVersion: "3"},
+		{input: "projects/p/secrets/s/versions/latest", wantProject: "p", wantSecret: "s", wantVersion: "latest"},
+		{input: "projects/p/locations/us-east1/secrets/s/versions/2", wantProject: "p", wantSecret: "s", wantVersion: "2"},
+		{input: "projects/p/secrets/s", wantErr: true},
+		{input: "projects/p/secrets/s/versions/", wantErr: true},
+		{input: "projects/p/secrets/s/versions/1/extra", wantErr: true},
+		{input: "projects/p/versions/1", wantErr: true},
+		{input: "secrets/s/versions/1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			project, secret, version, err := ParseVersionName(tt.input)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("ParseVersionName(%q) error = %v, want InvalidArgument", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVersionName(%q) error = %v", tt.input, err)
+			}
+			if project != tt.wantProject || secret != tt.wantSecret || version != tt.wantVersion {
+				t.Errorf("ParseVersionName(%q) = %q, %q, %q; want %q, %q, %q",
+					tt.input, project, secret, version, tt.wantProject, tt.wantSecret, tt.wantVersion)
+			}
+		})
+	}
+}
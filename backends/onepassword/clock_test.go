@@ -0,0 +1,64 @@
+package onepassword
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestSession_ExpiryUsesClock(t *testing.T) {
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.setClock(clock)
+
+	var whoami int
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		whoami++
+		return []byte(`{}`), nil
+	}
+
+	sess := &opSession{token: "token", backend: backend, expires: clock.Now().Add(30 * time.Minute)}
+	ctx := context.Background()
+
+	clock.Advance(29 * time.Minute)
+	if !sess.IsValid(ctx) {
+		t.Error("IsValid() before expiry = false, want true")
+	}
+
+	clock.Advance(2 * time.Minute)
+	if sess.IsValid(ctx) {
+		t.Error("IsValid() after expiry = true, want false")
+	}
+	if whoami != 1 {
+		t.Errorf("op whoami ran %d times, want 1 (expired sessions skip the check)", whoami)
+	}
+}
+
+func TestBackend_CachedSessionExpiry(t *testing.T) {
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.setClock(clock)
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		return []byte(`{}`), nil
+	}
+
+	if err := backend.cache.Save("cached-token", "1password"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	clock.Advance(10 * time.Minute)
+	if !backend.IsAuthenticated(context.Background()) {
+		t.Error("IsAuthenticated() with fresh cached session = false, want true")
+	}
+
+	// Past the 30 minute session TTL and the 5 second status cache TTL
+	clock.Advance(30 * time.Minute)
+	if backend.IsAuthenticated(context.Background()) {
+		t.Error("IsAuthenticated() with expired cached session = true, want false")
+	}
+}
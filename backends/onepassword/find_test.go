@@ -0,0 +1,105 @@
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"sort"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_FindItems_DuplicateTitles(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		switch {
+		case args[0] == "item" && args[1] == "list":
+			return []byte(`[
+				{"id": "id-personal", "title": "api-key", "vault": {"name": "Personal"}},
+				{"id": "id-work", "title": "api-key", "vault": {"name": "Work"}},
+				{"id": "id-db", "title": "db-password", "vault": {"name": "Work"}}
+			]`), nil
+		case args[0] == "item" && args[1] == "get" && args[2] == "api-key":
+			return nil, &exec.ExitError{Stderr: []byte(`[ERROR] 2025/01/01 00:00:00 More than one item matches "api-key". Try again and specify the item by its ID`)}
+		}
+		return nil, errors.New("unexpected command")
+	}
+
+	found, err := vaultmux.FindItems(ctx, backend, "api-key", fakeSession{})
+	if err != nil {
+		t.Fatalf("FindItems() error = %v", err)
+	}
+	var vaults []string
+	for _, item := range found {
+		vaults = append(vaults, item.Location)
+	}
+	sort.Strings(vaults)
+	if len(vaults) != 2 || vaults[0] != "Personal" || vaults[1] != "Work" {
+		t.Errorf("FindItems() vaults = %v, want [Personal Work]", vaults)
+	}
+
+	_, err = backend.GetItem(ctx, "api-key", fakeSession{})
+	if !errors.Is(err, vaultmux.ErrAmbiguous) {
+		t.Fatalf("GetItem() error = %v, want ErrAmbiguous", err)
+	}
+	var ambiguous *vaultmux.AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Fatalf("GetItem() error = %v, want AmbiguousError with 2 candidates", err)
+	}
+	if ambiguous.Candidates[0].ID != "id-personal" || ambiguous.Candidates[1].ID != "id-work" {
+		t.Errorf("candidates = %v, %v", ambiguous.Candidates[0].ID, ambiguous.Candidates[1].ID)
+	}
+
+	if found, _ := vaultmux.FindItems(ctx, backend, "missing", fakeSession{}); len(found) != 0 {
+		t.Errorf("FindItems(missing) = %v, want none", found)
+	}
+}
+
+func TestBackend_GetItemByID(t *testing.T) {
+	ctx := context.Background()
+	const id = "ktvh5mqzx3rbwg7dn2yfj4ple6"
+
+	var calls [][]string
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		switch {
+		case args[0] == "item" && args[1] == "list":
+			return []byte(`[
+				{"id": "` + id + `", "title": "api-key", "vault": {"name": "Work"}},
+				{"id": "id-personal", "title": "api-key", "vault": {"name": "Personal"}}
+			]`), nil
+		case args[0] == "item" && args[1] == "get" && args[2] == id:
+			return []byte(`{"id": "` + id + `", "title": "api-key", "vault": {"name": "Work"},
+				"fields": [{"label": "notesPlain", "value": "work-secret"}]}`), nil
+		case args[0] == "item" && args[1] == "get" && args[2] == "api-key":
+			return nil, &exec.ExitError{Stderr: []byte(`[ERROR] 2025/01/01 00:00:00 More than one item matches "api-key". Try again and specify the item by its ID`)}
+		}
+		return nil, errors.New("unexpected command")
+	}
+
+	// GetItem detects the ID and reads it directly, without resolving titles
+	item, err := backend.GetItem(ctx, id, fakeSession{})
+	if err != nil {
+		t.Fatalf("GetItem(id) error = %v", err)
+	}
+	if item.ID != id || item.Name != "api-key" || item.Location != "Work" || item.Notes != "work-secret" {
+		t.Errorf("GetItem(id) = %+v", item)
+	}
+	if len(calls) != 1 || calls[0][2] != id {
+		t.Errorf("commands = %v, want a single op item get %s", calls, id)
+	}
+
+	if _, err := backend.GetItemByID(ctx, id, fakeSession{}); err != nil {
+		t.Errorf("GetItemByID() error = %v", err)
+	}
+	if _, err := backend.GetItemByID(ctx, "api-key", fakeSession{}); !errors.Is(err, errInvalidItemID) {
+		t.Errorf("GetItemByID(title) error = %v, want errInvalidItemID", err)
+	}
+	if _, err := backend.GetItem(ctx, "api-key", fakeSession{}); !errors.Is(err, vaultmux.ErrAmbiguous) {
+		t.Errorf("GetItem(title) error = %v, want ErrAmbiguous", err)
+	}
+}
@@ -0,0 +1,107 @@
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_CreateItemInLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []vaultmux.CreateOption
+		vaults   string
+		wantCmds []string
+		wantErr  error
+	}{
+		{
+			name:   "existing vault",
+			vaults: `[{"name":"Work"}]`,
+			wantCmds: []string{
+				"vault list --format json",
+				"item create --category Secure Note --title api-key notesPlain=secret --vault Work",
+			},
+		},
+		{
+			name:     "missing vault",
+			vaults:   `[]`,
+			wantCmds: []string{"vault list --format json"},
+			wantErr:  vaultmux.ErrNotFound,
+		},
+		{
+			name:   "missing vault created",
+			opts:   []vaultmux.CreateOption{vaultmux.WithCreateLocation()},
+			vaults: `[]`,
+			wantCmds: []string{
+				"vault list --format json",
+				"vault create Work",
+				"item create --category Secure Note --title api-key notesPlain=secret --vault Work",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cmds []string
+			backend, _ := New(nil, t.TempDir()+"/session")
+			backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+				cmds = append(cmds, strings.Join(args, " "))
+				if args[0] == "vault" && args[1] == "list" {
+					return []byte(tt.vaults), nil
+				}
+				return nil, nil
+			}
+
+			err := backend.CreateItemInLocation(context.Background(), "api-key", "secret", "vault", "Work", fakeSession{}, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateItemInLocation() error = %v, want %v", err, tt.wantErr)
+			}
+			if strings.Join(cmds, "\n") != strings.Join(tt.wantCmds, "\n") {
+				t.Errorf("commands =\n%s\nwant\n%s", strings.Join(cmds, "\n"), strings.Join(tt.wantCmds, "\n"))
+			}
+		})
+	}
+}
+
+func TestBackend_ListItemsInLocationPage(t *testing.T) {
+	// Listed out of ID order, as op returns them by title
+	var listed []string
+	for i := 49; i >= 0; i-- {
+		listed = append(listed, fmt.Sprintf(`{"id":"id%02d","title":"item-%d"}`, i, i))
+	}
+
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		return []byte("[" + strings.Join(listed, ",") + "]"), nil
+	}
+
+	ctx := context.Background()
+	first, token, err := backend.ListItemsInLocationPage(ctx, "vault", "Work", "", 25, fakeSession{})
+	if err != nil {
+		t.Fatalf("first page error = %v", err)
+	}
+	if len(first) != 25 || token == "" {
+		t.Fatalf("first page = %d items, token %q; want 25 items and a token", len(first), token)
+	}
+	second, token, err := backend.ListItemsInLocationPage(ctx, "vault", "Work", token, 25, fakeSession{})
+	if err != nil {
+		t.Fatalf("second page error = %v", err)
+	}
+	if len(second) != 25 || token != "" {
+		t.Fatalf("second page = %d items, token %q; want 25 items and no token", len(second), token)
+	}
+
+	for i, item := range append(first, second...) {
+		if want := fmt.Sprintf("id%02d", i); item.ID != want {
+			t.Errorf("item %d ID = %q, want %q", i, item.ID, want)
+		}
+		if item.Location != "Work" {
+			t.Errorf("item %d Location = %q, want Work", i, item.Location)
+		}
+	}
+}
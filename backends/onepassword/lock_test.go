@@ -0,0 +1,47 @@
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBackend_Lock(t *testing.T) {
+	ctx := context.Background()
+	sessionFile := filepath.Join(t.TempDir(), ".op-session")
+	backend, _ := New(nil, sessionFile)
+
+	var signedOut bool
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		if len(args) == 1 && args[0] == "signout" {
+			if !slices.Contains(env, "OP_SESSION_my=session-token") {
+				t.Error("op signout run without the cached session")
+			}
+			signedOut = true
+			return nil, nil
+		}
+		return nil, errors.New("unexpected command")
+	}
+
+	if err := backend.cache.Save("session-token", "1password"); err != nil {
+		t.Fatalf("cache.Save() error = %v", err)
+	}
+	backend.statusCache.set(true)
+
+	if err := backend.Lock(ctx); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if !signedOut {
+		t.Error("Lock() did not run op signout")
+	}
+	if _, err := os.Stat(sessionFile); !os.IsNotExist(err) {
+		t.Errorf("session file still present after Lock(): %v", err)
+	}
+	if backend.IsAuthenticated(ctx) {
+		t.Error("IsAuthenticated() = true after Lock()")
+	}
+}
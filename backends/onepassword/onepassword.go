@@ -4,10 +4,13 @@ package onepassword
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -17,7 +20,24 @@ import (
 
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendOnePassword, func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-		return New(cfg.Options, cfg.SessionFile)
+		b, err := New(cfg.Options, cfg.SessionFile)
+		if err != nil {
+			return nil, err
+		}
+		b.observer = cfg.Observer
+		b.scrubOnClose = cfg.ScrubSessionOnClose
+		if cfg.SessionStore != nil {
+			// Accounts default to the session file so distinct files stay distinct
+			account := cfg.Options["account"]
+			if account == "" {
+				account = b.sessionFile
+			}
+			b.cache = cfg.SessionStore.Cache("1password", account, b.sessionFile, 30*time.Minute)
+		}
+		if cfg.Clock != nil {
+			b.setClock(cfg.Clock)
+		}
+		return b, nil
 	})
 }
 
@@ -26,6 +46,7 @@ type statusCache struct {
 	authenticated bool
 	timestamp     time.Time
 	mu            sync.RWMutex
+	clock         vaultmux.Clock // Time source (nil means time.Now)
 }
 
 // get returns the cached status if still valid (within TTL).
@@ -33,7 +54,7 @@ func (s *statusCache) get(ttl time.Duration) (bool, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if time.Since(s.timestamp) < ttl {
+	if s.now().Sub(s.timestamp) < ttl {
 		return s.authenticated, true // cached result is valid
 	}
 	return false, false // cache expired
@@ -45,14 +66,46 @@ func (s *statusCache) set(authenticated bool) {
 	defer s.mu.Unlock()
 
 	s.authenticated = authenticated
-	s.timestamp = time.Now()
+	s.timestamp = s.now()
+}
+
+// now returns the current time from the cache's clock.
+func (s *statusCache) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// runFunc executes a CLI command and returns its stdout.
+// A nil env inherits the current process environment.
+type runFunc func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error)
+
+// execRun runs the command as a subprocess.
+func execRun(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.Stdin = stdin
+	return cmd.Output()
+}
+
+// command runs a op command through b.run and reports it to the observer.
+func (b *Backend) command(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	start := time.Now()
+	out, err := b.run(ctx, env, stdin, name, args...)
+	vaultmux.ReportSubprocess(b.observer, b.Name(), name, args, start, err)
+	return out, err
 }
 
 // Backend implements vaultmux.Backend for 1Password CLI (op).
 type Backend struct {
-	sessionFile string
-	cache       *vaultmux.SessionCache
-	statusCache statusCache // Caches IsAuthenticated results
+	sessionFile  string
+	cache        *vaultmux.SessionCache
+	scrubOnClose bool              // Close clears the cached session
+	statusCache  statusCache       // Caches IsAuthenticated results
+	run          runFunc           // Executes op commands (replaced in tests)
+	observer     vaultmux.Observer // Receives subprocess events (optional)
+	clock        vaultmux.Clock    // Time source for session expiry
 }
 
 // New creates a new 1Password backend.
@@ -65,12 +118,30 @@ func New(opts map[string]string, sessionFile string) (*Backend, error) {
 	return &Backend{
 		sessionFile: sessionFile,
 		cache:       vaultmux.NewSessionCache(sessionFile, 30*time.Minute),
+		run:         execRun,
+		clock:       vaultmux.SystemClock(),
 	}, nil
 }
 
+// setClock sets the time source for session expiry and caching.
+func (b *Backend) setClock(clock vaultmux.Clock) {
+	b.clock = clock
+	b.statusCache.clock = clock
+	b.cache.SetClock(clock)
+}
+
 // Name returns the backend name.
 func (b *Backend) Name() string { return "1password" }
 
+// AuthEnvVars returns the environment variables op reads for service
+// account auth and account selection.
+func (b *Backend) AuthEnvVars() []string {
+	return []string{"OP_SERVICE_ACCOUNT_TOKEN", "OP_ACCOUNT", "OP_CONNECT_HOST", "OP_CONNECT_TOKEN"}
+}
+
+// Limits reports vaultmux.DefaultLimits; 1Password has no tighter limits.
+func (b *Backend) Limits() vaultmux.Limits { return vaultmux.DefaultLimits }
+
 // Init checks if the 1Password CLI is installed.
 func (b *Backend) Init(ctx context.Context) error {
 	if _, err := exec.LookPath("op"); err != nil {
@@ -79,8 +150,30 @@ func (b *Backend) Init(ctx context.Context) error {
 	return nil
 }
 
-// Close is a no-op for 1Password.
-func (b *Backend) Close() error { return nil }
+// Close clears the cached session if Config.ScrubSessionOnClose was set,
+// and is otherwise a no-op.
+func (b *Backend) Close() error {
+	if b.scrubOnClose {
+		return b.cache.Clear()
+	}
+	return nil
+}
+
+// Lock signs out of the cached session with op signout and clears the
+// cached session and status, so the next operation has to sign in again.
+// The caches are cleared even if op signout fails.
+func (b *Backend) Lock(ctx context.Context) error {
+	var signoutErr error
+	if cached, err := b.cache.Load(); err == nil && cached != nil {
+		env := append(os.Environ(), fmt.Sprintf("OP_SESSION_%s=%s", "my", cached.Token))
+		_, signoutErr = b.command(ctx, env, nil, "op", "signout")
+	}
+	b.statusCache.set(false)
+	if err := b.cache.Clear(); err != nil {
+		return vaultmux.WrapError("1password", "lock", "", err)
+	}
+	return vaultmux.WrapError("1password", "lock", "", signoutErr)
+}
 
 // IsAuthenticated checks if there's a valid session.
 // Results are cached for 5 seconds to reduce subprocess overhead.
@@ -98,9 +191,9 @@ func (b *Backend) IsAuthenticated(ctx context.Context) bool {
 	}
 
 	// Verify with op whoami
-	cmd := exec.CommandContext(ctx, "op", "whoami", "--format", "json")
-	cmd.Env = append(os.Environ(), fmt.Sprintf("OP_SESSION_%s=%s", "my", cached.Token))
-	authenticated := cmd.Run() == nil
+	env := append(os.Environ(), fmt.Sprintf("OP_SESSION_%s=%s", "my", cached.Token))
+	_, err = b.command(ctx, env, nil, "op", "whoami", "--format", "json")
+	authenticated := err == nil
 
 	// Cache the result
 	b.statusCache.set(authenticated)
@@ -111,13 +204,13 @@ func (b *Backend) IsAuthenticated(ctx context.Context) bool {
 func (b *Backend) Authenticate(ctx context.Context) (vaultmux.Session, error) {
 	// Try cached session first
 	if cached, err := b.cache.Load(); err == nil && cached != nil {
-		sess := &opSession{token: cached.Token, backend: b}
+		sess := &opSession{token: cached.Token, backend: b, expires: cached.Expires}
 		if sess.IsValid(ctx) {
 			return sess, nil
 		}
 	}
 
-	// Run: op signin --raw
+	// Run: op signin --raw (interactive, so run directly against the terminal)
 	cmd := exec.CommandContext(ctx, "op", "signin", "--raw")
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = os.Stderr
@@ -138,7 +231,7 @@ func (b *Backend) Authenticate(ctx context.Context) (vaultmux.Session, error) {
 	return &opSession{
 		token:   token,
 		backend: b,
-		expires: time.Now().Add(30 * time.Minute),
+		expires: b.clock.Now().Add(30 * time.Minute),
 	}, nil
 }
 
@@ -147,23 +240,74 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 	return nil // 1Password syncs automatically
 }
 
-// GetItem retrieves a vault item by name.
+// GetItem retrieves a vault item by name. Titles need not be unique; if
+// name matches several items an error wrapping vaultmux.ErrAmbiguous lists
+// them. A name that looks like an item ID (26 lowercase letters and digits)
+// is read with GetItemByID.
 func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
 	if err := vaultmux.ValidateItemName(name); err != nil {
 		return nil, vaultmux.WrapError("1password", "get", name, err)
 	}
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
+	if isItemID(name) {
+		return b.GetItemByID(ctx, name, session)
+	}
 
-	cmd := exec.CommandContext(ctx, "op", "item", "get", name, "--format", "json")
-	cmd.Env = b.sessionEnv(session)
-
-	out, err := cmd.Output()
+	out, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "get", name, "--format", "json")
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if notFound(err) {
 			return nil, vaultmux.ErrNotFound
 		}
+		if ambiguousMatch(err) {
+			return nil, b.ambiguousError(ctx, name, session)
+		}
 		return nil, vaultmux.WrapError("1password", "get", name, err)
 	}
+	return parseItem(out, name)
+}
+
+// GetItemByID retrieves a vault item by its 1Password item ID, which,
+// unlike its title, always identifies exactly one item.
+func (b *Backend) GetItemByID(ctx context.Context, id string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if !isItemID(id) {
+		return nil, vaultmux.WrapError("1password", "get", id, errInvalidItemID)
+	}
+
+	out, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "get", id, "--format", "json")
+	if err != nil {
+		if notFound(err) {
+			return nil, vaultmux.ErrNotFound
+		}
+		return nil, vaultmux.WrapError("1password", "get", id, err)
+	}
+	return parseItem(out, id)
+}
+
+// itemIDLength is the length of a 1Password item ID.
+const itemIDLength = 26
+
+// errInvalidItemID is returned by GetItemByID for a malformed ID.
+var errInvalidItemID = errors.New("invalid 1Password item ID")
+
+// isItemID reports whether s has the form of a 1Password item ID: 26
+// lowercase letters and digits.
+func isItemID(s string) bool {
+	if len(s) != itemIDLength {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
 
+// parseItem converts the JSON output of op item get into an Item; name is
+// what the item was requested by, for errors.
+func parseItem(out []byte, name string) (*vaultmux.Item, error) {
 	var opItem struct {
 		ID    string `json:"id"`
 		Title string `json:"title"`
@@ -171,10 +315,13 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 			Name string `json:"name"`
 		} `json:"vault"`
 		Fields []struct {
-			ID    string `json:"id"`
-			Type  string `json:"type"`
-			Label string `json:"label"`
-			Value string `json:"value"`
+			ID      string `json:"id"`
+			Type    string `json:"type"`
+			Label   string `json:"label"`
+			Value   string `json:"value"`
+			Section struct {
+				Label string `json:"label"`
+			} `json:"section"`
 		} `json:"fields"`
 		CreatedAt time.Time `json:"created_at"`
 		UpdatedAt time.Time `json:"updated_at"`
@@ -193,14 +340,24 @@ func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Ses
 		}
 	}
 
+	// Extract description from the metadata section
+	var description string
+	for _, field := range opItem.Fields {
+		if field.Section.Label == metadataSection && field.Label == "description" {
+			description = field.Value
+			break
+		}
+	}
+
 	return &vaultmux.Item{
-		ID:       opItem.ID,
-		Name:     opItem.Title,
-		Type:     vaultmux.ItemTypeSecureNote,
-		Notes:    notes,
-		Location: opItem.Vault.Name,
-		Created:  opItem.CreatedAt,
-		Modified: opItem.UpdatedAt,
+		ID:          opItem.ID,
+		Name:        opItem.Title,
+		Type:        vaultmux.ItemTypeSecureNote,
+		Notes:       notes,
+		Description: description,
+		Location:    opItem.Vault.Name,
+		Created:     opItem.CreatedAt,
+		Modified:    opItem.UpdatedAt,
 	}, nil
 }
 
@@ -227,10 +384,7 @@ func (b *Backend) ItemExists(ctx context.Context, name string, session vaultmux.
 
 // ListItems lists all items in the vault.
 func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
-	cmd := exec.CommandContext(ctx, "op", "item", "list", "--format", "json")
-	cmd.Env = b.sessionEnv(session)
-
-	out, err := cmd.Output()
+	out, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "list", "--format", "json")
 	if err != nil {
 		return nil, vaultmux.WrapError("1password", "list", "", err)
 	}
@@ -260,19 +414,116 @@ func (b *Backend) ListItems(ctx context.Context, session vaultmux.Session) ([]*v
 	return items, nil
 }
 
+// FindItems returns every item titled name (or with ID name) across all
+// vaults. Use it to pick an item when GetItem returns vaultmux.ErrAmbiguous.
+func (b *Backend) FindItems(ctx context.Context, name string, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*vaultmux.Item
+	for _, item := range items {
+		if item.Name == name || item.ID == name {
+			matches = append(matches, item)
+		}
+	}
+	return matches, nil
+}
+
+// ambiguousError builds the error GetItem returns when name matches several
+// items, listing the candidates.
+func (b *Backend) ambiguousError(ctx context.Context, name string, session vaultmux.Session) error {
+	candidates, err := b.FindItems(ctx, name, session)
+	if err != nil {
+		return err
+	}
+	return vaultmux.WrapError("1password", "get", name, &vaultmux.AmbiguousError{Name: name, Candidates: candidates})
+}
+
+// ambiguousMatch reports whether op rejected a name because it matches
+// more than one item.
+func ambiguousMatch(err error) bool {
+	return errorContains(err, "More than one item matches")
+}
+
+// notFound reports whether op failed because no item has the given name.
+// op 2 reports `"name" isn't an item`; older versions say "not found".
+func notFound(err error) bool {
+	return errorContains(err, "isn't an item") || errorContains(err, "not found")
+}
+
+// errorContains reports whether err, or the stderr op wrote before exiting
+// with it, contains msg. op reports errors on stderr, so an *exec.ExitError
+// alone only says "exit status 1".
+func errorContains(err error, msg string) bool {
+	if strings.Contains(err.Error(), msg) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), msg)
+}
+
+// metadataSection is the item section holding vaultmux metadata fields.
+const metadataSection = "Metadata"
+
 // CreateItem creates a new secure note.
 func (b *Backend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	return b.CreateItemWithOptions(ctx, name, content, session)
+}
+
+// CreateItemWithOptions creates a new secure note, applying any create options.
+// The description is stored as a text field in a "Metadata" section.
+func (b *Backend) CreateItemWithOptions(ctx context.Context, name, content string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
+	return b.createItem(ctx, name, content, "", session, vaultmux.NewCreateOptions(opts...))
+}
+
+// CreateItemInLocation creates a new secure note directly in a vault.
+// locType is ignored; locValue names the vault.
+func (b *Backend) CreateItemInLocation(ctx context.Context, name, content, locType, locValue string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
+	if err := vaultmux.ValidateLocationName(locValue); err != nil {
+		return vaultmux.WrapError("1password", "create", locValue, err)
+	}
+
+	o := vaultmux.NewCreateOptions(opts...)
+	exists, err := b.LocationExists(ctx, locValue, session)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if !o.CreateLocation {
+			return vaultmux.WrapError("1password", "create", name, fmt.Errorf("vault %q: %w", locValue, vaultmux.ErrNotFound))
+		}
+		if err := b.CreateLocation(ctx, locValue, session); err != nil {
+			return err
+		}
+	}
+
+	return b.createItem(ctx, name, content, locValue, session, o)
+}
+
+// createItem creates a secure note, in the given vault if non-empty.
+func (b *Backend) createItem(ctx context.Context, name, content, vault string, session vaultmux.Session, o vaultmux.CreateOptions) error {
 	if err := vaultmux.ValidateItemName(name); err != nil {
 		return vaultmux.WrapError("1password", "create", name, err)
 	}
 
-	cmd := exec.CommandContext(ctx, "op", "item", "create",
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	args := []string{"item", "create",
 		"--category", "Secure Note",
 		"--title", name,
-		fmt.Sprintf("notesPlain=%s", content))
-	cmd.Env = b.sessionEnv(session)
+		fmt.Sprintf("notesPlain=%s", content)}
+	if vault != "" {
+		args = append(args, "--vault", vault)
+	}
+	if o.Description != "" {
+		args = append(args, fmt.Sprintf("%s.description[text]=%s", metadataSection, o.Description))
+	}
 
-	if err := cmd.Run(); err != nil {
+	if _, err := b.command(ctx, b.sessionEnv(session), nil, "op", args...); err != nil {
 		return vaultmux.WrapError("1password", "create", name, err)
 	}
 
@@ -285,11 +536,14 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, session
 		return vaultmux.WrapError("1password", "update", name, err)
 	}
 
-	cmd := exec.CommandContext(ctx, "op", "item", "edit", name,
-		fmt.Sprintf("notesPlain=%s", content))
-	cmd.Env = b.sessionEnv(session)
+	if vaultmux.IsDryRun(ctx) {
+		_, err := b.GetItem(ctx, name, session)
+		return err
+	}
 
-	if err := cmd.Run(); err != nil {
+	_, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "edit", name,
+		fmt.Sprintf("notesPlain=%s", content))
+	if err != nil {
 		return vaultmux.WrapError("1password", "update", name, err)
 	}
 
@@ -302,10 +556,12 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 		return vaultmux.WrapError("1password", "delete", name, err)
 	}
 
-	cmd := exec.CommandContext(ctx, "op", "item", "delete", name)
-	cmd.Env = b.sessionEnv(session)
+	if vaultmux.IsDryRun(ctx) {
+		_, err := b.GetItem(ctx, name, session)
+		return err
+	}
 
-	if err := cmd.Run(); err != nil {
+	if _, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "delete", name); err != nil {
 		return vaultmux.WrapError("1password", "delete", name, err)
 	}
 
@@ -314,10 +570,7 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, session vaultmux.
 
 // ListLocations lists vaults.
 func (b *Backend) ListLocations(ctx context.Context, session vaultmux.Session) ([]string, error) {
-	cmd := exec.CommandContext(ctx, "op", "vault", "list", "--format", "json")
-	cmd.Env = b.sessionEnv(session)
-
-	out, err := cmd.Output()
+	out, err := b.command(ctx, b.sessionEnv(session), nil, "op", "vault", "list", "--format", "json")
 	if err != nil {
 		return nil, vaultmux.WrapError("1password", "list-vaults", "", err)
 	}
@@ -360,10 +613,11 @@ func (b *Backend) CreateLocation(ctx context.Context, name string, session vault
 		return vaultmux.WrapError("1password", "create-vault", name, err)
 	}
 
-	cmd := exec.CommandContext(ctx, "op", "vault", "create", name)
-	cmd.Env = b.sessionEnv(session)
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
 
-	if err := cmd.Run(); err != nil {
+	if _, err := b.command(ctx, b.sessionEnv(session), nil, "op", "vault", "create", name); err != nil {
 		return vaultmux.WrapError("1password", "create-vault", name, err)
 	}
 
@@ -372,10 +626,7 @@ func (b *Backend) CreateLocation(ctx context.Context, name string, session vault
 
 // ListItemsInLocation lists items in a specific vault.
 func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue string, session vaultmux.Session) ([]*vaultmux.Item, error) {
-	cmd := exec.CommandContext(ctx, "op", "item", "list", "--vault", locValue, "--format", "json")
-	cmd.Env = b.sessionEnv(session)
-
-	out, err := cmd.Output()
+	out, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "list", "--vault", locValue, "--format", "json")
 	if err != nil {
 		return nil, vaultmux.WrapError("1password", "list-items-in-vault", locValue, err)
 	}
@@ -402,6 +653,90 @@ func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue str
 	return items, nil
 }
 
+// defaultPageSize is the page size ListItemsInLocationPage uses when
+// pageSize isn't positive.
+const defaultPageSize = 100
+
+// ListItemsInLocationPage returns up to pageSize items of a vault, ordered
+// by item ID, starting after pageToken ("" for the first page). The
+// returned token is passed to the next call and is "" after the last page.
+//
+// The op CLI can't page, so every call lists the whole vault and slices it;
+// paging lets callers process a large vault incrementally but doesn't make
+// listing it cheaper. Because the token is the last ID returned, items
+// added or removed between calls never cause duplicates or shifted pages.
+func (b *Backend) ListItemsInLocationPage(ctx context.Context, locType, locValue, pageToken string, pageSize int, session vaultmux.Session) ([]*vaultmux.Item, string, error) {
+	items, err := b.ListItemsInLocation(ctx, locType, locValue, session)
+	if err != nil {
+		return nil, "", err
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	slices.SortFunc(items, func(a, b *vaultmux.Item) int { return strings.Compare(a.ID, b.ID) })
+	start, _ := slices.BinarySearchFunc(items, pageToken, func(item *vaultmux.Item, id string) int {
+		return strings.Compare(item.ID, id)
+	})
+	for start < len(items) && pageToken != "" && items[start].ID == pageToken {
+		start++
+	}
+
+	end := min(start+pageSize, len(items))
+	page := items[start:end]
+	if end == len(items) {
+		return page, "", nil
+	}
+	return page, page[len(page)-1].ID, nil
+}
+
+// GetPassword retrieves the password field of a login item.
+func (b *Backend) GetPassword(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	if err := vaultmux.ValidateItemName(name); err != nil {
+		return "", vaultmux.WrapError("1password", "get-password", name, err)
+	}
+
+	out, err := b.command(ctx, b.sessionEnv(session), nil, "op", "item", "get", name,
+		"--fields", "label=password", "--reveal")
+	if err != nil {
+		if notFound(err) {
+			return "", vaultmux.ErrNotFound
+		}
+		return "", vaultmux.WrapError("1password", "get-password", name, err)
+	}
+
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// SetPassword sets the password field of a login item.
+// If the item doesn't exist, a new login item is created.
+func (b *Backend) SetPassword(ctx context.Context, name, password string, session vaultmux.Session) error {
+	if err := vaultmux.ValidateItemName(name); err != nil {
+		return vaultmux.WrapError("1password", "set-password", name, err)
+	}
+
+	exists, err := b.ItemExists(ctx, name, session)
+	if err != nil {
+		return err
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	args := []string{"item", "edit", name}
+	if !exists {
+		args = []string{"item", "create", "--category", "Login", "--title", name}
+	}
+	args = append(args, fmt.Sprintf("password=%s", password))
+
+	if _, err := b.command(ctx, b.sessionEnv(session), nil, "op", args...); err != nil {
+		return vaultmux.WrapError("1password", "set-password", name, err)
+	}
+
+	return nil
+}
+
 // sessionEnv returns environment with session token set.
 func (b *Backend) sessionEnv(session vaultmux.Session) []string {
 	env := os.Environ()
@@ -420,12 +755,11 @@ type opSession struct {
 func (s *opSession) Token() string { return s.token }
 
 func (s *opSession) IsValid(ctx context.Context) bool {
-	if time.Now().After(s.expires) {
+	if s.backend.clock.Now().After(s.expires) {
 		return false
 	}
-	cmd := exec.CommandContext(ctx, "op", "whoami", "--format", "json")
-	cmd.Env = s.backend.sessionEnv(s)
-	return cmd.Run() == nil
+	_, err := s.backend.command(ctx, s.backend.sessionEnv(s), nil, "op", "whoami", "--format", "json")
+	return err == nil
 }
 
 func (s *opSession) Refresh(ctx context.Context) error {
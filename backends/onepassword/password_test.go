@@ -0,0 +1,98 @@
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+type fakeSession struct{}
+
+func (fakeSession) Token() string                     { return "token" }
+func (fakeSession) IsValid(ctx context.Context) bool  { return true }
+func (fakeSession) Refresh(ctx context.Context) error { return nil }
+func (fakeSession) ExpiresAt() time.Time              { return time.Time{} }
+
+func TestBackend_GetPassword(t *testing.T) {
+	var gotArgs []string
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte("hunter2\n"), nil
+	}
+
+	got, err := backend.GetPassword(context.Background(), "github", fakeSession{})
+	if err != nil {
+		t.Fatalf("GetPassword() error = %v", err)
+	}
+	if got != "hunter2" {
+		t.Errorf("GetPassword() = %q, want %q", got, "hunter2")
+	}
+
+	cmdline := strings.Join(gotArgs, " ")
+	if !strings.Contains(cmdline, "--fields label=password") {
+		t.Errorf("GetPassword() ran %q, want --fields label=password", cmdline)
+	}
+}
+
+func TestBackend_SetPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		exists   bool
+		wantArgs string
+	}{
+		{
+			name:     "creates login item",
+			exists:   false,
+			wantArgs: "item create --category Login --title github password=hunter2",
+		},
+		{
+			name:     "edits existing item",
+			exists:   true,
+			wantArgs: "item edit github password=hunter2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutation string
+			backend, _ := New(nil, t.TempDir()+"/session")
+			backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+				if args[0] == "item" && args[1] == "get" {
+					if !tt.exists {
+						return nil, &exec.ExitError{Stderr: []byte(`[ERROR] 2025/01/01 00:00:00 "github" isn't an item. Specify the item with its UUID, name, or domain.`)}
+					}
+					return []byte(`{"id":"abc","title":"github"}`), nil
+				}
+				mutation = strings.Join(args, " ")
+				return nil, nil
+			}
+
+			if err := backend.SetPassword(context.Background(), "github", "hunter2", fakeSession{}); err != nil {
+				t.Fatalf("SetPassword() error = %v", err)
+			}
+			if mutation != tt.wantArgs {
+				t.Errorf("SetPassword() ran %q, want %q", mutation, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBackend_SetPassword_InvalidName(t *testing.T) {
+	backend, _ := New(nil, t.TempDir()+"/session")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		t.Fatalf("ran op %v for an invalid name", args)
+		return nil, nil
+	}
+
+	err := backend.SetPassword(context.Background(), "github; rm -rf ~", "hunter2", fakeSession{})
+	if !errors.Is(err, vaultmux.ErrInvalidItemName) {
+		t.Errorf("SetPassword() error = %v, want ErrInvalidItemName", err)
+	}
+}
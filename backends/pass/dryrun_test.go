@@ -0,0 +1,66 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_DryRun(t *testing.T) {
+	ctx := vaultmux.WithDryRun(context.Background())
+	store := t.TempDir()
+
+	backend, _ := New(store, "team")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		t.Errorf("unexpected command %v", args)
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(store, "team"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store, "team", "existing.gpg"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backend.CreateItem(ctx, "new", "x", nil); err != nil {
+		t.Errorf("CreateItem() error = %v", err)
+	}
+	if err := backend.UpdateItem(ctx, "existing", "x", nil); err != nil {
+		t.Errorf("UpdateItem() error = %v", err)
+	}
+	if err := backend.SetItem(ctx, "new", "x", nil); err != nil {
+		t.Errorf("SetItem() error = %v", err)
+	}
+	if err := backend.DeleteItem(ctx, "existing", nil); err != nil {
+		t.Errorf("DeleteItem() error = %v", err)
+	}
+	if err := backend.CreateItemInLocation(ctx, "key", "x", "directory", "prod", nil, vaultmux.WithCreateLocation()); err != nil {
+		t.Errorf("CreateItemInLocation() error = %v", err)
+	}
+	if err := backend.ReencryptStore(ctx, []string{"0xDEADBEEF"}); err != nil {
+		t.Errorf("ReencryptStore() error = %v", err)
+	}
+
+	if err := backend.UpdateItem(ctx, "missing", "x", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("UpdateItem(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := backend.DeleteItem(ctx, "missing", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("DeleteItem(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := backend.CreateItem(ctx, "../escape", "x", nil); !errors.Is(err, vaultmux.ErrInvalidItemName) {
+		t.Errorf("CreateItem(../escape) error = %v, want ErrInvalidItemName", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(store, "team", "prod")); !os.IsNotExist(err) {
+		t.Error("dry-run CreateItemInLocation created the location directory")
+	}
+	if _, err := os.Stat(filepath.Join(store, "team", "existing.gpg")); err != nil {
+		t.Errorf("dry-run DeleteItem removed the entry: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package pass
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// GitStatus describes the state of a git-backed password store.
+type GitStatus struct {
+	Dirty  bool // Uncommitted changes, including untracked files
+	Ahead  int  // Local commits not on the upstream branch
+	Behind int  // Upstream commits not pulled yet
+}
+
+// GitStatus reports whether the store has uncommitted changes and how far
+// it is ahead of or behind its upstream branch, from git status
+// --porcelain and git rev-list --count. Ahead and Behind are zero if the
+// branch has no upstream. A store that isn't a git repository returns an
+// error wrapping vaultmux.ErrNotSupported.
+func (b *Backend) GitStatus(ctx context.Context) (GitStatus, error) {
+	var status GitStatus
+	if _, err := os.Stat(filepath.Join(b.storePath, ".git")); os.IsNotExist(err) {
+		return status, vaultmux.WrapError("pass", "git-status", "", fmt.Errorf("%w: store is not a git repository", vaultmux.ErrNotSupported))
+	}
+
+	out, err := b.command(ctx, nil, nil, "git", "-C", b.storePath, "status", "--porcelain")
+	if err != nil {
+		return status, vaultmux.WrapError("pass", "git-status", "", err)
+	}
+	status.Dirty = len(strings.TrimSpace(string(out))) > 0
+
+	// Fails when there is no upstream to compare with
+	out, err = b.command(ctx, nil, nil, "git", "-C", b.storePath, "rev-list", "--count", "--left-right", "HEAD...@{upstream}")
+	if err != nil {
+		return status, nil
+	}
+	counts := strings.Fields(string(out))
+	if len(counts) != 2 {
+		return status, vaultmux.WrapError("pass", "git-status", "", fmt.Errorf("unexpected rev-list output %q", out))
+	}
+	if status.Ahead, err = strconv.Atoi(counts[0]); err != nil {
+		return status, vaultmux.WrapError("pass", "git-status", "", err)
+	}
+	if status.Behind, err = strconv.Atoi(counts[1]); err != nil {
+		return status, vaultmux.WrapError("pass", "git-status", "", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,76 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// git runs a git command in dir, failing the test on error.
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestBackend_GitStatus(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	store := filepath.Join(t.TempDir(), "store")
+	git(t, ".", "init", "--quiet", "--bare", remote)
+	git(t, ".", "clone", "--quiet", remote, store)
+
+	backend, _ := New(store, "")
+	if err := os.WriteFile(filepath.Join(store, "api-key.gpg"), []byte("v1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := backend.GitStatus(ctx)
+	if err != nil {
+		t.Fatalf("GitStatus() error = %v", err)
+	}
+	if !status.Dirty {
+		t.Error("GitStatus().Dirty = false with an uncommitted entry, want true")
+	}
+
+	git(t, store, "add", ".")
+	git(t, store, "commit", "--quiet", "-m", "Add api-key")
+	if status, _ := backend.GitStatus(ctx); status.Dirty {
+		t.Error("GitStatus().Dirty = true after commit, want false")
+	}
+
+	git(t, store, "push", "--quiet", "-u", "origin", "HEAD")
+	if err := os.WriteFile(filepath.Join(store, "db.gpg"), []byte("v1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	git(t, store, "add", ".")
+	git(t, store, "commit", "--quiet", "-m", "Add db")
+
+	status, err = backend.GitStatus(ctx)
+	if err != nil {
+		t.Fatalf("GitStatus() error = %v", err)
+	}
+	if status != (GitStatus{Ahead: 1}) {
+		t.Errorf("GitStatus() = %+v, want 1 commit ahead", status)
+	}
+
+	notGit, _ := New(t.TempDir(), "")
+	if _, err := notGit.GitStatus(ctx); !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("GitStatus() outside git error = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,117 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_CreateItemInLocation(t *testing.T) {
+	ctx := context.Background()
+	store := t.TempDir()
+
+	backend, _ := New(store, "team")
+	var calls [][]string
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		return nil, nil
+	}
+
+	// Missing location fails unless asked to create it
+	err := backend.CreateItemInLocation(ctx, "api-key", "secret", "directory", "prod", nil)
+	if !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Fatalf("CreateItemInLocation() error = %v, want ErrNotFound", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("unexpected commands %v", calls)
+	}
+
+	err = backend.CreateItemInLocation(ctx, "api-key", "secret", "directory", "prod", nil, vaultmux.WithCreateLocation())
+	if err != nil {
+		t.Fatalf("CreateItemInLocation() error = %v", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(store, "team", "prod")); err != nil || !info.IsDir() {
+		t.Errorf("location directory not created: %v", err)
+	}
+	want := [][]string{{"insert", "-m", filepath.Join("team", "prod", "api-key")}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("commands = %v, want %v", calls, want)
+	}
+}
+
+func TestBackend_ListItemNames(t *testing.T) {
+	store := t.TempDir()
+	for _, file := range []string{"team/api-key.gpg", "team/prod/db.gpg", "team/.gpg-id", "other/x.gpg"} {
+		path := filepath.Join(store, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backend, _ := New(store, "team")
+	names, err := backend.ListItemNames(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	if want := []string{"api-key", "prod/db"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListItemNames() = %v, want %v", names, want)
+	}
+}
+
+func TestBackend_CreateLocationWithRecipients(t *testing.T) {
+	ctx := context.Background()
+	store := t.TempDir()
+	backend, _ := New(store, "team")
+
+	ids := []string{"0xDEADBEEFCAFEBABE", "alice@example.com"}
+	if err := backend.CreateLocationWithRecipients(ctx, "prod", ids, nil); err != nil {
+		t.Fatalf("CreateLocationWithRecipients() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(store, "team", "prod", ".gpg-id"))
+	if err != nil {
+		t.Fatalf("reading .gpg-id: %v", err)
+	}
+	if want := "0xDEADBEEFCAFEBABE\nalice@example.com\n"; string(data) != want {
+		t.Errorf(".gpg-id = %q, want %q", data, want)
+	}
+
+	// Recipients file is not an item
+	names, err := backend.ListItemNames(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("ListItemNames() = %v, want none", names)
+	}
+
+	err = backend.CreateLocationWithRecipients(ctx, "prod", ids, nil)
+	if !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("CreateLocationWithRecipients() on existing location error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestBackend_CreateLocationWithRecipients_InvalidIDs(t *testing.T) {
+	ctx := context.Background()
+	store := t.TempDir()
+	backend, _ := New(store, "team")
+
+	for _, ids := range [][]string{nil, {"--clear"}} {
+		if err := backend.CreateLocationWithRecipients(ctx, "prod", ids, nil); !errors.Is(err, errInvalidGPGID) {
+			t.Errorf("CreateLocationWithRecipients(%q) error = %v, want errInvalidGPGID", ids, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(store, "team", "prod")); !os.IsNotExist(err) {
+		t.Errorf("location created despite invalid key IDs: %v", err)
+	}
+}
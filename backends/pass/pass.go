@@ -3,10 +3,14 @@ package pass
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -16,7 +20,14 @@ import (
 
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendPass, func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-		return New(cfg.StorePath, cfg.Prefix)
+		b, err := New(cfg.StorePath, cfg.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		b.observer = cfg.Observer
+		b.statusCache.clock = cfg.Clock
+		b.trash = cfg.Trash
+		return b, nil
 	})
 }
 
@@ -25,6 +36,7 @@ type statusCache struct {
 	authenticated bool
 	timestamp     time.Time
 	mu            sync.RWMutex
+	clock         vaultmux.Clock // Time source (nil means time.Now)
 }
 
 // get returns the cached status if still valid (within TTL).
@@ -32,7 +44,7 @@ func (s *statusCache) get(ttl time.Duration) (bool, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if time.Since(s.timestamp) < ttl {
+	if s.now().Sub(s.timestamp) < ttl {
 		return s.authenticated, true // cached result is valid
 	}
 	return false, false // cache expired
@@ -44,14 +56,44 @@ func (s *statusCache) set(authenticated bool) {
 	defer s.mu.Unlock()
 
 	s.authenticated = authenticated
-	s.timestamp = time.Now()
+	s.timestamp = s.now()
+}
+
+// now returns the current time from the cache's clock.
+func (s *statusCache) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// runFunc executes a CLI command and returns its stdout.
+type runFunc func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error)
+
+// execRun runs the command as a subprocess.
+func execRun(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.Stdin = stdin
+	return cmd.Output()
+}
+
+// command runs a pass command through b.run and reports it to the observer.
+func (b *Backend) command(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	start := time.Now()
+	out, err := b.run(ctx, env, stdin, name, args...)
+	vaultmux.ReportSubprocess(b.observer, b.Name(), name, args, start, err)
+	return out, err
 }
 
 // Backend implements vaultmux.Backend for pass.
 type Backend struct {
 	storePath   string
 	prefix      string
-	statusCache statusCache // Caches IsAuthenticated results
+	statusCache statusCache       // Caches IsAuthenticated results
+	run         runFunc           // Executes pass commands (replaced in tests)
+	observer    vaultmux.Observer // Receives subprocess events (optional)
+	trash       bool              // DeleteItem moves entries to the trash
 }
 
 // New creates a new pass backend.
@@ -69,12 +111,22 @@ func New(storePath, prefix string) (*Backend, error) {
 	return &Backend{
 		storePath: storePath,
 		prefix:    prefix,
+		run:       execRun,
 	}, nil
 }
 
 // Name returns the backend name.
 func (b *Backend) Name() string { return "pass" }
 
+// AuthEnvVars returns the environment variables pass and gpg read to find
+// the store and the keys that decrypt it.
+func (b *Backend) AuthEnvVars() []string {
+	return []string{"PASSWORD_STORE_DIR", "PASSWORD_STORE_GPG_OPTS", "GNUPGHOME"}
+}
+
+// Limits reports vaultmux.DefaultLimits; pass stores files of any size.
+func (b *Backend) Limits() vaultmux.Limits { return vaultmux.DefaultLimits }
+
 // Init checks if pass and gpg are installed and the store exists.
 func (b *Backend) Init(ctx context.Context) error {
 	// Check pass is installed
@@ -106,8 +158,8 @@ func (b *Backend) IsAuthenticated(ctx context.Context) bool {
 		return result
 	}
 
-	cmd := exec.CommandContext(ctx, "pass", "ls")
-	authenticated := cmd.Run() == nil
+	_, err := b.command(ctx, nil, nil, "pass", "ls")
+	authenticated := err == nil
 
 	// Cache the result
 	b.statusCache.set(authenticated)
@@ -136,8 +188,7 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 	}
 
 	// Run: pass git pull
-	cmd := exec.CommandContext(ctx, "pass", "git", "pull")
-	if err := cmd.Run(); err != nil {
+	if _, err := b.command(ctx, nil, nil, "pass", "git", "pull"); err != nil {
 		return vaultmux.WrapError("pass", "sync", "", err)
 	}
 
@@ -145,7 +196,10 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 }
 
 // GetItem retrieves a vault item by name.
-func (b *Backend) GetItem(ctx context.Context, name string, _ vaultmux.Session) (*vaultmux.Item, error) {
+func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
 	notes, err := b.GetNotes(ctx, name, nil)
 	if err != nil {
 		return nil, err
@@ -162,14 +216,19 @@ func (b *Backend) GetItem(ctx context.Context, name string, _ vaultmux.Session)
 }
 
 // GetNotes retrieves the content of an item.
-func (b *Backend) GetNotes(ctx context.Context, name string, _ vaultmux.Session) (string, error) {
-	if err := vaultmux.ValidateItemName(name); err != nil {
+func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
 		return "", vaultmux.WrapError("pass", "get", name, err)
 	}
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		if err != nil {
+			return "", err
+		}
+		return item.Notes, nil
+	}
 
 	path := b.itemPath(name)
-	cmd := exec.CommandContext(ctx, "pass", "show", path)
-	out, err := cmd.Output()
+	out, err := b.command(ctx, nil, nil, "pass", "show", path)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
 			return "", vaultmux.ErrNotFound
@@ -181,6 +240,10 @@ func (b *Backend) GetNotes(ctx context.Context, name string, _ vaultmux.Session)
 
 // ItemExists checks if an item exists in the store.
 func (b *Backend) ItemExists(ctx context.Context, name string, _ vaultmux.Session) (bool, error) {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return false, vaultmux.WrapError("pass", "exists", name, err)
+	}
+
 	gpgPath := filepath.Join(b.storePath, b.prefix, name+".gpg")
 	_, err := os.Stat(gpgPath)
 	if os.IsNotExist(err) {
@@ -202,6 +265,9 @@ func (b *Backend) ListItems(ctx context.Context, _ vaultmux.Session) ([]*vaultmu
 			return err
 		}
 		if info.IsDir() {
+			if path == filepath.Join(prefixPath, trashDir) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		if !strings.HasSuffix(path, ".gpg") {
@@ -228,8 +294,40 @@ func (b *Backend) ListItems(ctx context.Context, _ vaultmux.Session) ([]*vaultmu
 	return items, nil
 }
 
+// ListItemNames lists the names of all items under the prefix. Unlike
+// ListItems it doesn't stat each entry for its modification time.
+func (b *Backend) ListItemNames(ctx context.Context, _ vaultmux.Session) ([]string, error) {
+	prefixPath := filepath.Join(b.storePath, b.prefix)
+
+	var names []string
+	err := filepath.WalkDir(prefixPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path == filepath.Join(prefixPath, trashDir) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".gpg") {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(prefixPath, path)
+		names = append(names, filepath.ToSlash(strings.TrimSuffix(rel, ".gpg")))
+		return nil
+	})
+	if err != nil {
+		return nil, vaultmux.WrapError("pass", "list", "", err)
+	}
+
+	return names, nil
+}
+
 // CreateItem creates a new item.
 func (b *Backend) CreateItem(ctx context.Context, name, content string, _ vaultmux.Session) error {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return vaultmux.WrapError("pass", "create", name, err)
+	}
+
 	exists, err := b.ItemExists(ctx, name, nil)
 	if err != nil {
 		return err
@@ -238,18 +336,47 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, _ vaultm
 		return vaultmux.ErrAlreadyExists
 	}
 
-	path := b.itemPath(name)
-	cmd := exec.CommandContext(ctx, "pass", "insert", "-m", path)
-	cmd.Stdin = strings.NewReader(content)
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
 
-	if err := cmd.Run(); err != nil {
+	path := b.itemPath(name)
+	if _, err := b.command(ctx, nil, strings.NewReader(content), "pass", "insert", "-m", path); err != nil {
 		return vaultmux.WrapError("pass", "create", name, err)
 	}
 	return nil
 }
 
+// CreateItemInLocation creates a new item directly in a location directory.
+// locType is ignored; locValue names the directory under the prefix, and the
+// item is stored as <prefix>/<locValue>/<name>.
+func (b *Backend) CreateItemInLocation(ctx context.Context, name, content, locType, locValue string, session vaultmux.Session, opts ...vaultmux.CreateOption) error {
+	if err := vaultmux.ValidateItemNameNoTraversal(locValue); err != nil {
+		return vaultmux.WrapError("pass", "create", locValue, err)
+	}
+
+	exists, err := b.LocationExists(ctx, locValue, session)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if !vaultmux.NewCreateOptions(opts...).CreateLocation {
+			return vaultmux.WrapError("pass", "create", name, fmt.Errorf("location %q: %w", locValue, vaultmux.ErrNotFound))
+		}
+		if err := b.CreateLocation(ctx, locValue, session); err != nil {
+			return err
+		}
+	}
+
+	return b.CreateItem(ctx, filepath.Join(locValue, name), content, session)
+}
+
 // UpdateItem updates an existing item.
 func (b *Backend) UpdateItem(ctx context.Context, name, content string, _ vaultmux.Session) error {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return vaultmux.WrapError("pass", "update", name, err)
+	}
+
 	exists, err := b.ItemExists(ctx, name, nil)
 	if err != nil {
 		return err
@@ -258,25 +385,56 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, _ vaultm
 		return vaultmux.ErrNotFound
 	}
 
-	path := b.itemPath(name)
-	cmd := exec.CommandContext(ctx, "pass", "insert", "-m", "-f", path)
-	cmd.Stdin = strings.NewReader(content)
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
 
-	if err := cmd.Run(); err != nil {
+	path := b.itemPath(name)
+	if _, err := b.command(ctx, nil, strings.NewReader(content), "pass", "insert", "-m", "-f", path); err != nil {
 		return vaultmux.WrapError("pass", "update", name, err)
 	}
 	return nil
 }
 
-// DeleteItem removes an item.
+// SetItem creates or updates an item. "pass insert -f" overwrites, so no
+// existence check is made.
+func (b *Backend) SetItem(ctx context.Context, name, content string, _ vaultmux.Session) error {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return vaultmux.WrapError("pass", "set", name, err)
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	path := b.itemPath(name)
+	if _, err := b.command(ctx, nil, strings.NewReader(content), "pass", "insert", "-m", "-f", path); err != nil {
+		return vaultmux.WrapError("pass", "set", name, err)
+	}
+	return nil
+}
+
+// DeleteItem removes an item, or moves it to the trash when the backend
+// was configured with Config.Trash.
 func (b *Backend) DeleteItem(ctx context.Context, name string, _ vaultmux.Session) error {
-	if err := vaultmux.ValidateItemName(name); err != nil {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
 		return vaultmux.WrapError("pass", "delete", name, err)
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		exists, err := b.ItemExists(ctx, name, nil)
+		if err == nil && !exists {
+			err = vaultmux.ErrNotFound
+		}
+		return err
+	}
+
+	if b.trash {
+		return b.moveToTrash(name)
+	}
+
 	path := b.itemPath(name)
-	cmd := exec.CommandContext(ctx, "pass", "rm", "-f", path)
-	if err := cmd.Run(); err != nil {
+	if _, err := b.command(ctx, nil, nil, "pass", "rm", "-f", path); err != nil {
 		return vaultmux.WrapError("pass", "delete", name, err)
 	}
 	return nil
@@ -293,7 +451,7 @@ func (b *Backend) ListLocations(ctx context.Context, _ vaultmux.Session) ([]stri
 
 	var locations []string
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() && entry.Name() != trashDir {
 			locations = append(locations, entry.Name())
 		}
 	}
@@ -303,6 +461,10 @@ func (b *Backend) ListLocations(ctx context.Context, _ vaultmux.Session) ([]stri
 
 // LocationExists checks if a location (directory) exists.
 func (b *Backend) LocationExists(ctx context.Context, name string, _ vaultmux.Session) (bool, error) {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return false, vaultmux.WrapError("pass", "location-exists", name, err)
+	}
+
 	path := filepath.Join(b.storePath, b.prefix, name)
 	info, err := os.Stat(path)
 	if os.IsNotExist(err) {
@@ -316,20 +478,67 @@ func (b *Backend) LocationExists(ctx context.Context, name string, _ vaultmux.Se
 
 // CreateLocation creates a new location (directory).
 func (b *Backend) CreateLocation(ctx context.Context, name string, _ vaultmux.Session) error {
-	if err := vaultmux.ValidateLocationName(name); err != nil {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return vaultmux.WrapError("pass", "create-location", name, err)
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	path := filepath.Join(b.storePath, b.prefix, name)
+	if err := os.MkdirAll(path, 0755); err != nil {
 		return vaultmux.WrapError("pass", "create-location", name, err)
 	}
+	return nil
+}
+
+// CreateLocationWithRecipients creates a new location (directory) whose
+// entries are encrypted to gpgIDs instead of the store's root recipients, by
+// writing a .gpg-id file into it. pass uses the nearest .gpg-id when
+// inserting, so items created in the location afterwards use these keys.
+//
+// The location must not exist yet, since entries already in it would stay
+// encrypted to their old recipients.
+func (b *Backend) CreateLocationWithRecipients(ctx context.Context, name string, gpgIDs []string, _ vaultmux.Session) error {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return vaultmux.WrapError("pass", "create-location", name, err)
+	}
+	if len(gpgIDs) == 0 {
+		return vaultmux.WrapError("pass", "create-location", name,
+			fmt.Errorf("%w: at least one key ID is required", errInvalidGPGID))
+	}
+	for _, id := range gpgIDs {
+		if !gpgIDPattern.MatchString(id) {
+			return vaultmux.WrapError("pass", "create-location", name, fmt.Errorf("%w: %q", errInvalidGPGID, id))
+		}
+	}
 
 	path := filepath.Join(b.storePath, b.prefix, name)
+	if _, err := os.Stat(path); err == nil {
+		return vaultmux.WrapError("pass", "create-location", name, vaultmux.ErrAlreadyExists)
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return vaultmux.WrapError("pass", "create-location", name, err)
 	}
+	gpgID := strings.Join(gpgIDs, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(path, ".gpg-id"), []byte(gpgID), 0644); err != nil {
+		return vaultmux.WrapError("pass", "create-location", name, err)
+	}
 	return nil
 }
 
 // ListItemsInLocation lists items within a specific location.
 func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue string, _ vaultmux.Session) ([]*vaultmux.Item, error) {
 	// For pass, locType is ignored (always directory-based)
+	if err := vaultmux.ValidateItemNameNoTraversal(locValue); err != nil {
+		return nil, vaultmux.WrapError("pass", "list-items-in-location", locValue, err)
+	}
 	locationPath := filepath.Join(b.storePath, b.prefix, locValue)
 
 	var items []*vaultmux.Item
@@ -364,6 +573,38 @@ func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue str
 	return items, nil
 }
 
+// errInvalidGPGID indicates a GPG key ID passed to ReencryptStore is malformed.
+var errInvalidGPGID = errors.New("invalid GPG key ID")
+
+// gpgIDPattern matches key IDs and fingerprints (hex, optional 0x) or email
+// addresses, the forms pass accepts for recipients.
+var gpgIDPattern = regexp.MustCompile(`^((0x)?[0-9A-Fa-f]{8,40}|[^\s@<>"'-][^\s@<>"']*@[^\s@<>"']+)$`)
+
+// ReencryptStore re-encrypts every entry under the prefix for newGPGIDs by
+// running "pass init -p <prefix> <gpg-ids...>". Use it when rotating GPG keys;
+// gpg must be able to decrypt the existing entries.
+func (b *Backend) ReencryptStore(ctx context.Context, newGPGIDs []string) error {
+	if len(newGPGIDs) == 0 {
+		return vaultmux.WrapError("pass", "reencrypt", b.prefix,
+			fmt.Errorf("%w: at least one key ID is required", errInvalidGPGID))
+	}
+	for _, id := range newGPGIDs {
+		if !gpgIDPattern.MatchString(id) {
+			return vaultmux.WrapError("pass", "reencrypt", b.prefix, fmt.Errorf("%w: %q", errInvalidGPGID, id))
+		}
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	args := append([]string{"init", "-p", b.prefix}, newGPGIDs...)
+	if _, err := b.command(ctx, nil, nil, "pass", args...); err != nil {
+		return vaultmux.WrapError("pass", "reencrypt", b.prefix, err)
+	}
+	return nil
+}
+
 // itemPath returns the full path for an item.
 func (b *Backend) itemPath(name string) string {
 	return filepath.Join(b.prefix, name)
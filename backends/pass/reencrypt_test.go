@@ -0,0 +1,63 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestBackend_ReencryptStore(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := New(t.TempDir(), "team")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var calls [][]string
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{name}, args...))
+		return nil, nil
+	}
+
+	ids := []string{"0xDEADBEEFCAFEBABE", "alice@example.com"}
+	if err := backend.ReencryptStore(ctx, ids); err != nil {
+		t.Fatalf("ReencryptStore() error = %v", err)
+	}
+
+	want := [][]string{{"pass", "init", "-p", "team", "0xDEADBEEFCAFEBABE", "alice@example.com"}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("commands = %v, want %v", calls, want)
+	}
+}
+
+func TestBackend_ReencryptStore_InvalidIDs(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		ids  []string
+	}{
+		{name: "none", ids: nil},
+		{name: "empty", ids: []string{""}},
+		{name: "flag", ids: []string{"--clear"}},
+		{name: "short hex", ids: []string{"ABCD"}},
+		{name: "whitespace", ids: []string{"alice @example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, _ := New(t.TempDir(), "team")
+			backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+				t.Errorf("unexpected command %s %v", name, args)
+				return nil, nil
+			}
+
+			if err := backend.ReencryptStore(ctx, tt.ids); !errors.Is(err, errInvalidGPGID) {
+				t.Errorf("ReencryptStore() error = %v, want errInvalidGPGID", err)
+			}
+		})
+	}
+}
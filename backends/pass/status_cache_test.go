@@ -4,6 +4,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/blackwell-systems/vaultmux/mock"
 )
 
 func TestStatusCache_GetSet(t *testing.T) {
@@ -110,3 +112,20 @@ func TestStatusCache_AlternatingStates(t *testing.T) {
 		t.Error("Expected true")
 	}
 }
+
+func TestStatusCache_Clock(t *testing.T) {
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	sc := statusCache{clock: clock}
+
+	sc.set(true)
+
+	clock.Advance(4 * time.Second)
+	if result, valid := sc.get(5 * time.Second); !valid || !result {
+		t.Errorf("get() within TTL = %v, %v; want true, true", result, valid)
+	}
+
+	clock.Advance(2 * time.Second)
+	if _, valid := sc.get(5 * time.Second); valid {
+		t.Error("get() after TTL should return invalid")
+	}
+}
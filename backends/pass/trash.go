@@ -0,0 +1,169 @@
+package pass
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// trashDir is the directory under the prefix that holds deleted entries
+// when the trash is enabled. Listings skip it.
+const trashDir = ".trash"
+
+// trashStampLayout names trashed copies so they sort by deletion time. The
+// time is kept in the file name because renaming preserves the entry's
+// original modification time.
+const trashStampLayout = "20060102T150405.000000000Z"
+
+// trashItemDir returns the directory holding trashed copies of name.
+func (b *Backend) trashItemDir(name string) string {
+	return filepath.Join(b.storePath, b.prefix, trashDir, filepath.FromSlash(name))
+}
+
+// moveToTrash moves name's encrypted file to
+// <prefix>/.trash/<name>/<timestamp>.gpg. The file is renamed as is, so it
+// stays encrypted for the same recipients.
+func (b *Backend) moveToTrash(name string) error {
+	src := filepath.Join(b.storePath, b.prefix, name+".gpg")
+	if _, err := os.Stat(src); err != nil {
+		if os.IsNotExist(err) {
+			err = vaultmux.ErrNotFound
+		}
+		return vaultmux.WrapError("pass", "delete", name, err)
+	}
+
+	dir := b.trashItemDir(name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return vaultmux.WrapError("pass", "delete", name, err)
+	}
+	stamp := b.statusCache.now().UTC().Format(trashStampLayout)
+	if err := os.Rename(src, filepath.Join(dir, stamp+".gpg")); err != nil {
+		return vaultmux.WrapError("pass", "delete", name, err)
+	}
+	return nil
+}
+
+// trashedCopies returns the timestamps of name's trashed copies, oldest
+// first. Subdirectories (trashed items nested under name) are ignored.
+func (b *Backend) trashedCopies(name string) ([]string, error) {
+	entries, err := os.ReadDir(b.trashItemDir(name))
+	if err != nil {
+		return nil, err
+	}
+	var stamps []string
+	for _, entry := range entries {
+		stamp, ok := strings.CutSuffix(entry.Name(), ".gpg")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		if _, err := time.Parse(trashStampLayout, stamp); err == nil {
+			stamps = append(stamps, stamp)
+		}
+	}
+	sort.Strings(stamps)
+	return stamps, nil
+}
+
+// RestoreItem moves the most recently trashed copy of name back into the
+// store. It returns ErrNotFound when the trash holds no copy of name and
+// ErrAlreadyExists when an entry with that name has since been created.
+func (b *Backend) RestoreItem(ctx context.Context, name string, _ vaultmux.Session) error {
+	if err := vaultmux.ValidateItemNameNoTraversal(name); err != nil {
+		return vaultmux.WrapError("pass", "restore", name, err)
+	}
+
+	stamps, err := b.trashedCopies(name)
+	if err != nil && !os.IsNotExist(err) {
+		return vaultmux.WrapError("pass", "restore", name, err)
+	}
+	if len(stamps) == 0 {
+		return vaultmux.WrapError("pass", "restore", name, vaultmux.ErrNotFound)
+	}
+
+	exists, err := b.ItemExists(ctx, name, nil)
+	if err != nil {
+		return vaultmux.WrapError("pass", "restore", name, err)
+	}
+	if exists {
+		return vaultmux.WrapError("pass", "restore", name, vaultmux.ErrAlreadyExists)
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	dst := filepath.Join(b.storePath, b.prefix, name+".gpg")
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return vaultmux.WrapError("pass", "restore", name, err)
+	}
+	src := filepath.Join(b.trashItemDir(name), stamps[len(stamps)-1]+".gpg")
+	if err := os.Rename(src, dst); err != nil {
+		return vaultmux.WrapError("pass", "restore", name, err)
+	}
+	b.pruneTrashDirs()
+	return nil
+}
+
+// PurgeTrash permanently removes trashed copies deleted more than olderThan
+// ago and returns how many were removed. Pass 0 to empty the trash.
+func (b *Backend) PurgeTrash(ctx context.Context, olderThan time.Duration, _ vaultmux.Session) (int, error) {
+	root := filepath.Join(b.storePath, b.prefix, trashDir)
+	cutoff := b.statusCache.now().Add(-olderThan)
+
+	var expired []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		stamp, ok := strings.CutSuffix(d.Name(), ".gpg")
+		if d.IsDir() || !ok {
+			return nil
+		}
+		deleted, err := time.Parse(trashStampLayout, stamp)
+		if err == nil && !deleted.After(cutoff) {
+			expired = append(expired, path)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, vaultmux.WrapError("pass", "purge-trash", "", err)
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return len(expired), nil
+	}
+
+	for i, path := range expired {
+		if err := os.Remove(path); err != nil {
+			return i, vaultmux.WrapError("pass", "purge-trash", "", err)
+		}
+	}
+	b.pruneTrashDirs()
+	return len(expired), nil
+}
+
+// pruneTrashDirs removes directories left empty in the trash, deepest
+// first. Failures are ignored; a leftover empty directory is harmless.
+func (b *Backend) pruneTrashDirs() {
+	root := filepath.Join(b.storePath, b.prefix, trashDir)
+
+	var dirs []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i]) // fails unless empty
+	}
+}
@@ -0,0 +1,125 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestBackend_Trash(t *testing.T) {
+	ctx := context.Background()
+	store := t.TempDir()
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	backend, _ := New(store, "team")
+	backend.trash = true
+	backend.statusCache.clock = clock
+
+	for _, name := range []string{"api-key", "db/password"} {
+		path := filepath.Join(store, "team", name+".gpg")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := backend.DeleteItem(ctx, "db/password", nil); err != nil {
+		t.Fatalf("DeleteItem() error = %v", err)
+	}
+	trashed := filepath.Join(store, "team", ".trash", "db", "password", "20250101T000000.000000000Z.gpg")
+	if _, err := os.Stat(trashed); err != nil {
+		t.Errorf("trashed copy missing: %v", err)
+	}
+
+	names, err := backend.ListItemNames(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	if !slices.Equal(names, []string{"api-key"}) {
+		t.Errorf("ListItemNames() = %v, want [api-key]", names)
+	}
+	items, _ := backend.ListItems(ctx, nil)
+	if len(items) != 1 {
+		t.Errorf("ListItems() returned %d items, want 1", len(items))
+	}
+	locations, _ := backend.ListLocations(ctx, nil)
+	if slices.Contains(locations, ".trash") {
+		t.Errorf("ListLocations() = %v, should not include .trash", locations)
+	}
+
+	if err := backend.DeleteItem(ctx, "missing", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("DeleteItem(missing) error = %v, want ErrNotFound", err)
+	}
+
+	if err := backend.RestoreItem(ctx, "db/password", nil); err != nil {
+		t.Fatalf("RestoreItem() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(store, "team", "db", "password.gpg"))
+	if err != nil || string(data) != "db/password" {
+		t.Errorf("restored entry = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(store, "team", ".trash")); !os.IsNotExist(err) {
+		t.Errorf("empty trash directories left behind: %v", err)
+	}
+	if err := backend.RestoreItem(ctx, "db/password", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("RestoreItem() with empty trash error = %v, want ErrNotFound", err)
+	}
+
+	// Two deletions a week apart; only the older one is purged
+	if err := backend.DeleteItem(ctx, "api-key", nil); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(7 * 24 * time.Hour)
+	if err := backend.DeleteItem(ctx, "db/password", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := backend.PurgeTrash(ctx, 24*time.Hour, nil)
+	if err != nil {
+		t.Fatalf("PurgeTrash() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeTrash() = %d, want 1", purged)
+	}
+	if err := backend.RestoreItem(ctx, "api-key", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("RestoreItem(api-key) after purge error = %v, want ErrNotFound", err)
+	}
+	if err := backend.RestoreItem(ctx, "db/password", nil); err != nil {
+		t.Errorf("RestoreItem(db/password) after purge error = %v", err)
+	}
+}
+
+func TestBackend_RestoreItemExisting(t *testing.T) {
+	ctx := context.Background()
+	store := t.TempDir()
+
+	backend, _ := New(store, "team")
+	backend.trash = true
+
+	path := filepath.Join(store, "team", "token.gpg")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.DeleteItem(ctx, "token", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backend.RestoreItem(ctx, "token", nil); !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("RestoreItem() error = %v, want ErrAlreadyExists", err)
+	}
+}
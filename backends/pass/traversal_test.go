@@ -0,0 +1,71 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestBackend_RejectsPathTraversal(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := filepath.Join(root, "store")
+
+	backend, _ := New(store, "team")
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		t.Errorf("unexpected command %v", args)
+		return nil, nil
+	}
+
+	const evil = "../../evil"
+	checks := map[string]func() error{
+		"GetNotes":   func() error { _, err := backend.GetNotes(ctx, evil, nil); return err },
+		"ItemExists": func() error { _, err := backend.ItemExists(ctx, evil, nil); return err },
+		"CreateItem": func() error { return backend.CreateItem(ctx, evil, "x", nil) },
+		"UpdateItem": func() error { return backend.UpdateItem(ctx, evil, "x", nil) },
+		"SetItem":    func() error { return backend.SetItem(ctx, evil, "x", nil) },
+		"DeleteItem": func() error { return backend.DeleteItem(ctx, evil, nil) },
+		"CreateItemInLocation": func() error {
+			return backend.CreateItemInLocation(ctx, "key", "x", "directory", evil, nil, vaultmux.WithCreateLocation())
+		},
+		"CreateLocation": func() error { return backend.CreateLocation(ctx, evil, nil) },
+		"LocationExists": func() error { _, err := backend.LocationExists(ctx, evil, nil); return err },
+		"ListItemsInLocation": func() error {
+			_, err := backend.ListItemsInLocation(ctx, "directory", evil, nil)
+			return err
+		},
+	}
+
+	for name, check := range checks {
+		if err := check(); !errors.Is(err, vaultmux.ErrInvalidItemName) {
+			t.Errorf("%s(%q) error = %v, want ErrInvalidItemName", name, evil, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "evil")); !os.IsNotExist(err) {
+		t.Errorf("path outside the prefix directory was created")
+	}
+}
+
+func TestBackend_NestedNameAllowed(t *testing.T) {
+	ctx := context.Background()
+
+	backend, _ := New(t.TempDir(), "team")
+	var got []string
+	backend.run = func(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		got = args
+		return nil, nil
+	}
+
+	if err := backend.CreateItem(ctx, "a/b", "x", nil); err != nil {
+		t.Fatalf("CreateItem(a/b) error = %v", err)
+	}
+	if want := filepath.Join("team", "a", "b"); len(got) == 0 || got[len(got)-1] != want {
+		t.Errorf("insert path = %v, want %s", got, want)
+	}
+}
@@ -27,6 +27,14 @@ func New(prefix string) (*Backend, error) {
 // Name returns the backend name.
 func (b *Backend) Name() string { return "wincred" }
 
+// CaseSensitive reports false, matching the Windows implementation.
+func (b *Backend) CaseSensitive() bool { return false }
+
+// Limits reports the same limits as the Windows implementation.
+func (b *Backend) Limits() vaultmux.Limits {
+	return vaultmux.Limits{MaxSecretBytes: 2560, MaxNameLength: 256}
+}
+
 // Init returns an error.
 func (b *Backend) Init(ctx context.Context) error {
 	return errors.New("Windows Credential Manager is only available on Windows")
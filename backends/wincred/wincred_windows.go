@@ -9,19 +9,26 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/blackwell-systems/vaultmux"
 )
 
 func init() {
 	vaultmux.RegisterBackend(vaultmux.BackendWindowsCredentialManager, func(cfg vaultmux.Config) (vaultmux.Backend, error) {
-		return New(cfg.Prefix)
+		b, err := New(cfg.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		b.observer = cfg.Observer
+		return b, nil
 	})
 }
 
 // Backend implements vaultmux.Backend for Windows Credential Manager.
 type Backend struct {
-	prefix string
+	prefix   string
+	observer vaultmux.Observer // Receives subprocess events (optional)
 }
 
 // New creates a new Windows Credential Manager backend.
@@ -37,6 +44,16 @@ func New(prefix string) (*Backend, error) {
 // Name returns the backend name.
 func (b *Backend) Name() string { return "wincred" }
 
+// CaseSensitive reports false: Credential Manager target names are
+// case-insensitive, so names are stored in canonical case.
+func (b *Backend) CaseSensitive() bool { return false }
+
+// Limits reports Credential Manager's 2560 byte credential blob limit
+// (CRED_MAX_CREDENTIAL_BLOB_SIZE).
+func (b *Backend) Limits() vaultmux.Limits {
+	return vaultmux.Limits{MaxSecretBytes: 2560, MaxNameLength: 256}
+}
+
 // Init checks if PowerShell is available.
 func (b *Backend) Init(ctx context.Context) error {
 	// Check if powershell.exe is available
@@ -66,7 +83,10 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 }
 
 // GetItem retrieves a vault item by name.
-func (b *Backend) GetItem(ctx context.Context, name string, _ vaultmux.Session) (*vaultmux.Item, error) {
+func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
 	notes, err := b.GetNotes(ctx, name, nil)
 	if err != nil {
 		return nil, err
@@ -83,7 +103,14 @@ func (b *Backend) GetItem(ctx context.Context, name string, _ vaultmux.Session)
 }
 
 // GetNotes retrieves the content of an item from Windows Credential Manager.
-func (b *Backend) GetNotes(ctx context.Context, name string, _ vaultmux.Session) (string, error) {
+func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		if err != nil {
+			return "", err
+		}
+		return item.Notes, nil
+	}
+
 	target := b.credentialTarget(name)
 
 	// PowerShell script to get credential
@@ -99,8 +126,7 @@ if ($cred) {
 }
 `, target)
 
-	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script)
-	out, err := cmd.Output()
+	out, err := b.powershell(ctx, script)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
 			return "", vaultmux.ErrNotFound
@@ -120,8 +146,7 @@ $cred = Get-StoredCredential -Target '%s' -ErrorAction SilentlyContinue
 if ($cred) { exit 0 } else { exit 1 }
 `, target)
 
-	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script)
-	err := cmd.Run()
+	_, err := b.powershell(ctx, script)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
 			return false, nil
@@ -140,12 +165,12 @@ $creds | ForEach-Object {
     [PSCustomObject]@{
         Name = $_.TargetName.Substring(%d)
         Target = $_.TargetName
+        UserName = $_.UserName
     }
 } | ConvertTo-Json -Compress
 `, b.prefix, len(b.prefix)+1) // +1 for the colon
 
-	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script)
-	out, err := cmd.Output()
+	out, err := b.powershell(ctx, script)
 	if err != nil {
 		return nil, vaultmux.WrapError("wincred", "list", "", err)
 	}
@@ -156,22 +181,25 @@ $creds | ForEach-Object {
 
 	// Parse JSON output
 	var results []struct {
-		Name   string `json:"Name"`
-		Target string `json:"Target"`
+		Name     string `json:"Name"`
+		Target   string `json:"Target"`
+		UserName string `json:"UserName"`
 	}
 
 	// Handle single item (not an array)
 	if !strings.HasPrefix(strings.TrimSpace(string(out)), "[") {
 		var single struct {
-			Name   string `json:"Name"`
-			Target string `json:"Target"`
+			Name     string `json:"Name"`
+			Target   string `json:"Target"`
+			UserName string `json:"UserName"`
 		}
 		if err := json.Unmarshal(out, &single); err != nil {
 			return nil, vaultmux.WrapError("wincred", "list", "", fmt.Errorf("parse credential list: %w", err))
 		}
 		results = []struct {
-			Name   string `json:"Name"`
-			Target string `json:"Target"`
+			Name     string `json:"Name"`
+			Target   string `json:"Target"`
+			UserName string `json:"UserName"`
 		}{single}
 	} else {
 		if err := json.Unmarshal(out, &results); err != nil {
@@ -181,13 +209,19 @@ $creds | ForEach-Object {
 
 	items := make([]*vaultmux.Item, 0, len(results))
 	for _, r := range results {
-		items = append(items, &vaultmux.Item{
+		item := &vaultmux.Item{
 			Name: r.Name,
 			Type: vaultmux.ItemTypeSecureNote,
-		})
+		}
+		// The credential user name holds the original name for display
+		if r.UserName != "" && r.UserName != "vaultmux" {
+			item.Fields = map[string]string{vaultmux.FieldDisplayName: r.UserName}
+		}
+		items = append(items, item)
 	}
 
-	return items, nil
+	// Entries created before names were normalized may differ only by case
+	return vaultmux.DedupeCaseVariants(ctx, items, nil), nil
 }
 
 // CreateItem creates a new item in Windows Credential Manager.
@@ -200,6 +234,10 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, _ vaultm
 		return vaultmux.ErrAlreadyExists
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	target := b.credentialTarget(name)
 
 	// PowerShell script to create credential
@@ -207,10 +245,9 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, _ vaultm
 $password = ConvertTo-SecureString -String '%s' -AsPlainText -Force
 $cred = New-Object System.Management.Automation.PSCredential('%s', $password)
 New-StoredCredential -Target '%s' -Credential $cred -Type Generic -Persist LocalMachine
-`, escapePowerShellString(content), "vaultmux", target)
+`, escapePowerShellString(content), escapePowerShellString(name), target)
 
-	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script)
-	if err := cmd.Run(); err != nil {
+	if _, err := b.powershell(ctx, script); err != nil {
 		return vaultmux.WrapError("wincred", "create", name, err)
 	}
 
@@ -227,6 +264,10 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, _ vaultm
 		return vaultmux.ErrNotFound
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	target := b.credentialTarget(name)
 
 	// PowerShell script to update credential (remove and recreate)
@@ -235,10 +276,9 @@ Remove-StoredCredential -Target '%s' -ErrorAction SilentlyContinue
 $password = ConvertTo-SecureString -String '%s' -AsPlainText -Force
 $cred = New-Object System.Management.Automation.PSCredential('%s', $password)
 New-StoredCredential -Target '%s' -Credential $cred -Type Generic -Persist LocalMachine
-`, target, escapePowerShellString(content), "vaultmux", target)
+`, target, escapePowerShellString(content), escapePowerShellString(name), target)
 
-	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script)
-	if err := cmd.Run(); err != nil {
+	if _, err := b.powershell(ctx, script); err != nil {
 		return vaultmux.WrapError("wincred", "update", name, err)
 	}
 
@@ -247,14 +287,17 @@ New-StoredCredential -Target '%s' -Credential $cred -Type Generic -Persist Local
 
 // DeleteItem removes an item from Windows Credential Manager.
 func (b *Backend) DeleteItem(ctx context.Context, name string, _ vaultmux.Session) error {
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	target := b.credentialTarget(name)
 
 	script := fmt.Sprintf(`
 Remove-StoredCredential -Target '%s' -ErrorAction SilentlyContinue
 `, target)
 
-	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script)
-	if err := cmd.Run(); err != nil {
+	if _, err := b.powershell(ctx, script); err != nil {
 		return vaultmux.WrapError("wincred", "delete", name, err)
 	}
 
@@ -281,9 +324,20 @@ func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue str
 	return []*vaultmux.Item{}, nil // No folder concept
 }
 
+// powershell runs a script and reports it to the observer. The script is not
+// reported since it may embed secret values.
+func (b *Backend) powershell(ctx context.Context, script string) ([]byte, error) {
+	start := time.Now()
+	out, err := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-Command", script).Output()
+	vaultmux.ReportSubprocess(b.observer, b.Name(), "powershell.exe", nil, start, err)
+	return out, err
+}
+
 // credentialTarget returns the Windows Credential Manager target name.
+// Names are stored in canonical case; the original is kept as the
+// credential's user name.
 func (b *Backend) credentialTarget(name string) string {
-	return fmt.Sprintf("%s:%s", b.prefix, name)
+	return fmt.Sprintf("%s:%s", b.prefix, vaultmux.CanonicalName(name))
 }
 
 // escapePowerShellString escapes single quotes in PowerShell strings.
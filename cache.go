@@ -0,0 +1,218 @@
+package vaultmux
+
+import (
+	"context"
+	"crypto/sha256"
+	"maps"
+	"sync"
+	"time"
+)
+
+// CachingBackend wraps a Backend and caches the items and notes it reads
+// for a TTL, so repeated reads of a name don't reach the backend. Cached
+// values are deduplicated by content hash: names holding identical values
+// share one string, which keeps large fan-outs of the same secret (e.g. a
+// shared token stored under every service's name) from multiplying memory.
+//
+// Creating, updating or deleting an item through the wrapper drops its
+// cached entry; changes made elsewhere are seen once the entry expires.
+// Missing items and errors are not cached. Reads made with a session from
+// PinnedSession bypass the cache.
+//
+// CreateItemWithOptions and SetItem go to the wrapped backend's ItemCreator
+// and ItemSetter when it has them, and also drop the cached entry. Other
+// optional interfaces are reached through Unwrap, uncached.
+type CachingBackend struct {
+	Backend
+
+	ttl   time.Duration
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry              // Name -> cached read
+	values  map[[sha256.Size]byte]*sharedValue // Content hash -> value
+}
+
+// cacheEntry is the cached read of one name.
+type cacheEntry struct {
+	item    *Item // Item with empty Notes; nil if only GetNotes was cached
+	value   *sharedValue
+	expires time.Time
+}
+
+// sharedValue is a cached value and the number of entries holding it.
+type sharedValue struct {
+	notes string
+	refs  int
+}
+
+// CacheStats reports the size of a CachingBackend's cache.
+type CacheStats struct {
+	Names  int // Cached names
+	Values int // Distinct values held for them
+}
+
+// NewCachingBackend wraps b so that reads are cached for ttl. A nil clock
+// uses SystemClock.
+func NewCachingBackend(b Backend, ttl time.Duration, clock Clock) *CachingBackend {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &CachingBackend{
+		Backend: b,
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[string]cacheEntry),
+		values:  make(map[[sha256.Size]byte]*sharedValue),
+	}
+}
+
+// GetItem retrieves an item, from the cache if it holds one.
+func (c *CachingBackend) GetItem(ctx context.Context, name string, session Session) (*Item, error) {
+	if isPinned(session) {
+		return c.Backend.GetItem(ctx, name, session)
+	}
+	if entry, ok := c.lookup(name); ok && entry.item != nil {
+		return cachedItem(entry), nil
+	}
+
+	item, err := c.Backend.GetItem(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+	return cachedItem(c.store(name, item, item.Notes)), nil
+}
+
+// GetNotes retrieves the notes of an item, from the cache if it holds them.
+func (c *CachingBackend) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	if isPinned(session) {
+		return c.Backend.GetNotes(ctx, name, session)
+	}
+	if entry, ok := c.lookup(name); ok {
+		return entry.value.notes, nil
+	}
+
+	notes, err := c.Backend.GetNotes(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	return c.store(name, nil, notes).value.notes, nil
+}
+
+// CreateItem creates an item and drops any cached entry for it.
+func (c *CachingBackend) CreateItem(ctx context.Context, name, content string, session Session) error {
+	defer c.Invalidate(name)
+	return c.Backend.CreateItem(ctx, name, content, session)
+}
+
+// CreateItemWithOptions creates an item with opts and drops any cached
+// entry for it. Options are ignored if the wrapped backend is not an
+// ItemCreator.
+func (c *CachingBackend) CreateItemWithOptions(ctx context.Context, name, content string, session Session, opts ...CreateOption) error {
+	defer c.Invalidate(name)
+	return createItemWithOptions(ctx, c.Backend, name, content, session, opts...)
+}
+
+// SetItem creates or updates an item and drops its cached entry.
+func (c *CachingBackend) SetItem(ctx context.Context, name, content string, session Session) error {
+	defer c.Invalidate(name)
+	return SetItem(ctx, c.Backend, name, content, session)
+}
+
+// UpdateItem updates an item and drops its cached entry.
+func (c *CachingBackend) UpdateItem(ctx context.Context, name, content string, session Session) error {
+	defer c.Invalidate(name)
+	return c.Backend.UpdateItem(ctx, name, content, session)
+}
+
+// DeleteItem deletes an item and drops its cached entry.
+func (c *CachingBackend) DeleteItem(ctx context.Context, name string, session Session) error {
+	defer c.Invalidate(name)
+	return c.Backend.DeleteItem(ctx, name, session)
+}
+
+// Unwrap returns the wrapped backend.
+func (c *CachingBackend) Unwrap() Backend {
+	return c.Backend
+}
+
+// Invalidate drops the cached entry for name, if any.
+func (c *CachingBackend) Invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(name)
+}
+
+// Stats reports the number of cached names and distinct values.
+func (c *CachingBackend) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Names: len(c.entries), Values: len(c.values)}
+}
+
+// lookup returns the unexpired entry for name, dropping an expired one.
+func (c *CachingBackend) lookup(name string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[name]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if !c.clock.Now().Before(entry.expires) {
+		c.remove(name)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store caches notes, and item if not nil, for name, sharing the value
+// with other names that hold the same content.
+func (c *CachingBackend) store(name string, item *Item, notes string) cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(name)
+
+	sum := sha256.Sum256([]byte(notes))
+	value, ok := c.values[sum]
+	if !ok {
+		value = &sharedValue{notes: notes}
+		c.values[sum] = value
+	}
+	value.refs++
+
+	entry := cacheEntry{value: value, expires: c.clock.Now().Add(c.ttl)}
+	if item != nil {
+		entry.item = cloneItem(item)
+		entry.item.Notes = ""
+	}
+	c.entries[name] = entry
+	return entry
+}
+
+// remove drops name's entry and releases its value. c.mu must be held.
+func (c *CachingBackend) remove(name string) {
+	entry, ok := c.entries[name]
+	if !ok {
+		return
+	}
+	delete(c.entries, name)
+	if entry.value.refs--; entry.value.refs == 0 {
+		delete(c.values, sha256.Sum256([]byte(entry.value.notes)))
+	}
+}
+
+// cachedItem returns a copy of entry's item with the shared notes.
+func cachedItem(entry cacheEntry) *Item {
+	item := cloneItem(entry.item)
+	item.Notes = entry.value.notes
+	return item
+}
+
+// cloneItem returns a copy of item that shares no maps with it.
+func cloneItem(item *Item) *Item {
+	c := *item
+	c.Fields = maps.Clone(item.Fields)
+	c.Tags = maps.Clone(item.Tags)
+	return &c
+}
@@ -0,0 +1,117 @@
+package vaultmux_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestCachingBackend_DedupesValues(t *testing.T) {
+	ctx := context.Background()
+	inner := mock.New()
+	names := []string{"svc-a/token", "svc-b/token", "svc-c/token"}
+	for _, name := range names {
+		inner.SetItem(name, "shared-token")
+	}
+	inner.SetItem("svc-a/db", "other")
+	backend := vaultmux.NewCachingBackend(inner, time.Minute, nil)
+
+	var ptrs []*byte
+	for _, name := range names {
+		notes, err := backend.GetNotes(ctx, name, nil)
+		if err != nil || notes != "shared-token" {
+			t.Fatalf("GetNotes(%s) = %q, %v", name, notes, err)
+		}
+		ptrs = append(ptrs, unsafe.StringData(notes))
+	}
+	if item, _ := backend.GetItem(ctx, "svc-a/db", nil); item.Notes != "other" || item.Name != "svc-a/db" {
+		t.Errorf("GetItem(svc-a/db) = %+v", item)
+	}
+
+	if stats := backend.Stats(); stats != (vaultmux.CacheStats{Names: 4, Values: 2}) {
+		t.Errorf("Stats() = %+v, want 4 names sharing 2 values", stats)
+	}
+	if ptrs[0] != ptrs[1] || ptrs[1] != ptrs[2] {
+		t.Error("identical values are not backed by one buffer")
+	}
+}
+
+func TestCachingBackend_Expiry(t *testing.T) {
+	ctx := context.Background()
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	inner := mock.New()
+	inner.SetItem("db", "v1")
+	backend := vaultmux.NewCachingBackend(inner, time.Minute, clock)
+
+	_, _ = backend.GetNotes(ctx, "db", nil)
+	inner.SetItem("db", "v2") // changed behind the cache's back
+
+	if notes, _ := backend.GetNotes(ctx, "db", nil); notes != "v1" {
+		t.Errorf("cached GetNotes() = %q, want v1", notes)
+	}
+	clock.Advance(time.Minute)
+	if notes, _ := backend.GetNotes(ctx, "db", nil); notes != "v2" {
+		t.Errorf("GetNotes() after expiry = %q, want v2", notes)
+	}
+
+	if err := backend.UpdateItem(ctx, "db", "v3", nil); err != nil {
+		t.Fatal(err)
+	}
+	if notes, _ := backend.GetNotes(ctx, "db", nil); notes != "v3" {
+		t.Errorf("GetNotes() after UpdateItem = %q, want v3", notes)
+	}
+
+	if err := backend.DeleteItem(ctx, "db", nil); err != nil {
+		t.Fatal(err)
+	}
+	if stats := backend.Stats(); stats != (vaultmux.CacheStats{}) {
+		t.Errorf("Stats() after DeleteItem = %+v, want empty", stats)
+	}
+}
+
+func TestCachingBackend_OptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	inner := mock.New()
+	backend := vaultmux.NewCachingBackend(inner, time.Minute, clock)
+
+	if err := backend.CreateItemWithOptions(ctx, "db", "v1", nil, vaultmux.WithDescription("primary")); err != nil {
+		t.Fatal(err)
+	}
+	if item, _ := backend.GetItem(ctx, "db", nil); item.Description != "primary" {
+		t.Errorf("Description = %q, want primary", item.Description)
+	}
+	if err := vaultmux.SetItem(ctx, backend, "db", "v2", nil); err != nil {
+		t.Fatal(err)
+	}
+	if notes, _ := backend.GetNotes(ctx, "db", nil); notes != "v2" {
+		t.Errorf("GetNotes() after SetItem = %q, want v2", notes)
+	}
+
+	if backend.Unwrap() != vaultmux.Backend(inner) {
+		t.Error("Unwrap() did not return the wrapped backend")
+	}
+}
+
+func TestInnermost(t *testing.T) {
+	vaultmux.RegisterBackend("mock-innermost", func(cfg vaultmux.Config) (vaultmux.Backend, error) {
+		return mock.New(), nil
+	})
+	backend, err := vaultmux.New(vaultmux.Config{Backend: "mock-innermost", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := backend.(*vaultmux.CachingBackend); !ok {
+		t.Fatalf("New() = %T, want *CachingBackend", backend)
+	}
+	if _, ok := vaultmux.Innermost(backend).(*mock.Backend); !ok {
+		t.Errorf("Innermost() = %T, want *mock.Backend", vaultmux.Innermost(backend))
+	}
+	if _, ok := backend.(vaultmux.ItemCreator); !ok {
+		t.Error("New() result does not implement ItemCreator")
+	}
+}
@@ -0,0 +1,56 @@
+package vaultmux
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// FieldDisplayName is the Item.Fields key holding the name an item was
+// created with, on backends that store names in canonical case.
+const FieldDisplayName = "displayName"
+
+// CaseSensitivity is implemented by backends that report whether item names
+// are case-sensitive. Backends that don't implement it are assumed to be.
+type CaseSensitivity interface {
+	CaseSensitive() bool
+}
+
+// IsCaseSensitive reports whether b treats names differing only by case as
+// different items.
+func IsCaseSensitive(b Backend) bool {
+	if cs, ok := b.(CaseSensitivity); ok {
+		return cs.CaseSensitive()
+	}
+	return true
+}
+
+// CanonicalName returns the canonical (lower) case under which
+// case-insensitive backends store name.
+func CanonicalName(name string) string {
+	return strings.ToLower(name)
+}
+
+// DedupeCaseVariants drops items whose names differ only by case from an
+// earlier item in the list, logging a warning for each one dropped. A nil
+// logger uses slog.Default(). Case-insensitive backends use it in ListItems
+// so entries stored before names were normalized don't appear twice.
+func DedupeCaseVariants(ctx context.Context, items []*Item, logger *slog.Logger) []*Item {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	seen := make(map[string]string, len(items))
+	deduped := items[:0:0]
+	for _, item := range items {
+		canonical := CanonicalName(item.Name)
+		if first, ok := seen[canonical]; ok {
+			logger.WarnContext(ctx, "skipping item whose name differs only by case from another item",
+				"name", item.Name, "conflicts_with", first)
+			continue
+		}
+		seen[canonical] = item.Name
+		deduped = append(deduped, item)
+	}
+	return deduped
+}
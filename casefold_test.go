@@ -0,0 +1,67 @@
+package vaultmux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestIsCaseSensitive(t *testing.T) {
+	backend := mock.New()
+	if !vaultmux.IsCaseSensitive(backend) {
+		t.Error("IsCaseSensitive() = false for default mock")
+	}
+	backend.CaseInsensitive = true
+	if vaultmux.IsCaseSensitive(backend) {
+		t.Error("IsCaseSensitive() = true for case-insensitive mock")
+	}
+}
+
+func TestCaseInsensitiveBackend_Conflict(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.CaseInsensitive = true
+	session, _ := backend.Authenticate(ctx)
+
+	if err := backend.CreateItem(ctx, "MySecret", "v1", session); err != nil {
+		t.Fatalf("CreateItem(MySecret) error = %v", err)
+	}
+	if err := backend.CreateItem(ctx, "mysecret", "v2", session); !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("CreateItem(mysecret) error = %v, want ErrAlreadyExists", err)
+	}
+
+	item, err := backend.GetItem(ctx, "MYSECRET", session)
+	if err != nil {
+		t.Fatalf("GetItem(MYSECRET) error = %v", err)
+	}
+	if item.Name != "mysecret" {
+		t.Errorf("Name = %q, want canonical %q", item.Name, "mysecret")
+	}
+	if got := item.Fields[vaultmux.FieldDisplayName]; got != "MySecret" {
+		t.Errorf("display name = %q, want %q", got, "MySecret")
+	}
+}
+
+func TestDedupeCaseVariants(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	items := []*vaultmux.Item{{Name: "MySecret"}, {Name: "other"}, {Name: "mysecret"}}
+	got := vaultmux.DedupeCaseVariants(context.Background(), items, logger)
+
+	if len(got) != 2 || got[0].Name != "MySecret" || got[1].Name != "other" {
+		t.Errorf("DedupeCaseVariants() = %v, want [MySecret other]", got)
+	}
+	if !strings.Contains(logs.String(), "name=mysecret") {
+		t.Errorf("expected warning for mysecret, got logs %q", logs.String())
+	}
+	if len(items) != 3 || items[2].Name != "mysecret" {
+		t.Error("DedupeCaseVariants() modified its input")
+	}
+}
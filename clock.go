@@ -0,0 +1,18 @@
+package vaultmux
+
+import "time"
+
+// Clock tells the current time. Components that compare against TTLs or
+// expiry times use a Clock so tests can control time instead of sleeping;
+// see Config.Clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock returns the Clock backed by time.Now, used when no Clock is
+// configured.
+func SystemClock() Clock { return systemClock{} }
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
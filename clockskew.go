@@ -0,0 +1,45 @@
+package vaultmux
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// MaxClockSkew is the skew beyond which CheckClockSkew logs a warning. It
+// matches the tolerance of AWS request signing; larger skew also shifts
+// when cached sessions are considered expired.
+const MaxClockSkew = 5 * time.Minute
+
+// ServerTimer is implemented by backends that can tell the provider's
+// current time, e.g. from the Date header of an API response.
+type ServerTimer interface {
+	ServerTime(ctx context.Context) (time.Time, error)
+}
+
+// CheckClockSkew returns how far the local clock is ahead of the backend's
+// server clock; a negative skew means the local clock is behind. If the
+// skew exceeds MaxClockSkew in either direction, a warning is logged to the
+// default slog logger, since credential and session expiry then fail in
+// confusing ways. Server times usually have one-second resolution, so the
+// result is only accurate to about a second.
+//
+// Backends that don't implement ServerTimer return ErrNotSupported.
+func CheckClockSkew(ctx context.Context, b Backend) (time.Duration, error) {
+	st, ok := b.(ServerTimer)
+	if !ok {
+		return 0, WrapError(b.Name(), "clock-skew", "", ErrNotSupported)
+	}
+
+	server, err := st.ServerTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	skew := time.Now().Sub(server)
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		slog.Default().WarnContext(ctx, "local clock differs from backend server time; sessions may expire early or late",
+			"backend", b.Name(), "skew", skew.Round(time.Second))
+	}
+	return skew, nil
+}
@@ -0,0 +1,41 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+// offsetClockBackend reports a server time offset from the local clock.
+type offsetClockBackend struct {
+	*mock.Backend
+	offset time.Duration
+}
+
+func (b offsetClockBackend) ServerTime(ctx context.Context) (time.Time, error) {
+	return time.Now().Add(b.offset), nil
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	ctx := context.Background()
+
+	// Server 10 minutes ahead means the local clock is 10 minutes behind
+	skew, err := vaultmux.CheckClockSkew(ctx, offsetClockBackend{mock.New(), 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("CheckClockSkew() error = %v", err)
+	}
+	if want := -10 * time.Minute; skew > want+time.Second || skew < want-time.Second {
+		t.Errorf("CheckClockSkew() = %v, want about %v", skew, want)
+	}
+}
+
+func TestCheckClockSkew_NotSupported(t *testing.T) {
+	_, err := vaultmux.CheckClockSkew(context.Background(), mock.New())
+	if !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("CheckClockSkew() error = %v, want ErrNotSupported", err)
+	}
+}
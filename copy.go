@@ -0,0 +1,56 @@
+package vaultmux
+
+import "context"
+
+// CopyOptions holds optional settings for CopyItemAcross.
+type CopyOptions struct {
+	// Overwrite replaces the destination item if it already exists instead
+	// of failing with ErrAlreadyExists.
+	Overwrite bool
+}
+
+// CopyOption configures CopyOptions.
+type CopyOption func(*CopyOptions)
+
+// WithOverwrite makes CopyItemAcross replace an existing destination item.
+func WithOverwrite() CopyOption {
+	return func(o *CopyOptions) {
+		o.Overwrite = true
+	}
+}
+
+// CopyItemAcross copies a single item from src to dst, storing it under
+// dstName. The backends may differ, e.g. to promote a secret from a pass
+// store to AWS Secrets Manager under a production name.
+//
+// If dstName already exists in dst, ErrAlreadyExists is returned unless
+// WithOverwrite is given, in which case the value is replaced via SetItem.
+// The item description is carried over when dst implements ItemCreator and
+// the item is newly created.
+func CopyItemAcross(ctx context.Context, src, dst Backend, srcSession, dstSession Session, srcName, dstName string, opts ...CopyOption) error {
+	var o CopyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	item, err := src.GetItem(ctx, srcName, srcSession)
+	if err != nil {
+		return err
+	}
+
+	exists, err := dst.ItemExists(ctx, dstName, dstSession)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if !o.Overwrite {
+			return WrapError(dst.Name(), "copy", dstName, ErrAlreadyExists)
+		}
+		return SetItem(ctx, dst, dstName, item.Notes, dstSession)
+	}
+
+	if creator, ok := dst.(ItemCreator); ok && item.Description != "" {
+		return creator.CreateItemWithOptions(ctx, dstName, item.Notes, dstSession, WithDescription(item.Description))
+	}
+	return dst.CreateItem(ctx, dstName, item.Notes, dstSession)
+}
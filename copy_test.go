@@ -0,0 +1,60 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestCopyItemAcross(t *testing.T) {
+	ctx := context.Background()
+	dev := mock.New()
+	prod := mock.New()
+	devSession, _ := dev.Authenticate(ctx)
+	prodSession, _ := prod.Authenticate(ctx)
+
+	dev.SetItem("dev/db-password", "s3cret")
+
+	if err := vaultmux.CopyItemAcross(ctx, dev, prod, devSession, prodSession, "dev/db-password", "prod/db-password"); err != nil {
+		t.Fatalf("CopyItemAcross() error = %v", err)
+	}
+	got, err := prod.GetNotes(ctx, "prod/db-password", prodSession)
+	if err != nil || got != "s3cret" {
+		t.Errorf("copied value = %q, %v; want %q", got, err, "s3cret")
+	}
+
+	// An existing destination is left alone without WithOverwrite
+	dev.SetItem("dev/db-password", "rotated")
+	err = vaultmux.CopyItemAcross(ctx, dev, prod, devSession, prodSession, "dev/db-password", "prod/db-password")
+	if !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Fatalf("CopyItemAcross() error = %v, want ErrAlreadyExists", err)
+	}
+	if got, _ := prod.GetNotes(ctx, "prod/db-password", prodSession); got != "s3cret" {
+		t.Errorf("destination changed without overwrite: %q", got)
+	}
+
+	err = vaultmux.CopyItemAcross(ctx, dev, prod, devSession, prodSession, "dev/db-password", "prod/db-password", vaultmux.WithOverwrite())
+	if err != nil {
+		t.Fatalf("CopyItemAcross() with overwrite error = %v", err)
+	}
+	if got, _ := prod.GetNotes(ctx, "prod/db-password", prodSession); got != "rotated" {
+		t.Errorf("overwritten value = %q, want %q", got, "rotated")
+	}
+}
+
+func TestCopyItemAcross_SourceMissing(t *testing.T) {
+	ctx := context.Background()
+	src, dst := mock.New(), mock.New()
+	session, _ := src.Authenticate(ctx)
+
+	err := vaultmux.CopyItemAcross(ctx, src, dst, session, session, "missing", "copy")
+	if !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("CopyItemAcross() error = %v, want ErrNotFound", err)
+	}
+	if exists, _ := dst.ItemExists(ctx, "copy", session); exists {
+		t.Error("destination item created for missing source")
+	}
+}
@@ -0,0 +1,50 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidEncoding indicates a secret value is not valid in the requested
+// encoding, or the encoding is unknown.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// Encodings accepted by GetDecoded.
+const (
+	EncodingBase64    = "base64"    // Standard alphabet, padding optional
+	EncodingBase64URL = "base64url" // URL-safe alphabet, padding optional
+	EncodingHex       = "hex"
+	EncodingNone      = "none" // Raw value bytes
+)
+
+// GetDecoded reads a secret stored in a text encoding and returns the
+// decoded bytes. Surrounding whitespace, such as the trailing newline pass
+// adds, is ignored.
+func GetDecoded(ctx context.Context, b Backend, name, encoding string, session Session) ([]byte, error) {
+	value, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded []byte
+	switch encoding {
+	case EncodingBase64:
+		decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(value), "="))
+	case EncodingBase64URL:
+		decoded, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(value), "="))
+	case EncodingHex:
+		decoded, err = hex.DecodeString(strings.TrimSpace(value))
+	case EncodingNone:
+		return []byte(value), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown encoding %q (want base64, base64url, hex or none)", ErrInvalidEncoding, encoding)
+	}
+	if err != nil {
+		return nil, WrapError(b.Name(), "decode", name, fmt.Errorf("%w: value is not valid %s: %v", ErrInvalidEncoding, encoding, err))
+	}
+	return decoded, nil
+}
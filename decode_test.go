@@ -0,0 +1,71 @@
+package vaultmux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestGetDecoded(t *testing.T) {
+	ctx := context.Background()
+	raw := []byte{0x00, 0xfb, 0xff, 0x10, 'k', 'e', 'y'}
+
+	backend := mock.New()
+	backend.SetItem("std", "APv/EGtleQ==\n")
+	backend.SetItem("std-unpadded", "APv/EGtleQ")
+	backend.SetItem("url", "APv_EGtleQ")
+	backend.SetItem("hex", "00fbff106b6579")
+	backend.SetItem("plain", "as-is")
+	session, _ := backend.Authenticate(ctx)
+
+	tests := []struct {
+		name     string
+		encoding string
+		want     []byte
+	}{
+		{"std", vaultmux.EncodingBase64, raw},
+		{"std-unpadded", vaultmux.EncodingBase64, raw},
+		{"url", vaultmux.EncodingBase64URL, raw},
+		{"hex", vaultmux.EncodingHex, raw},
+		{"plain", vaultmux.EncodingNone, []byte("as-is")},
+	}
+	for _, tt := range tests {
+		got, err := vaultmux.GetDecoded(ctx, backend, tt.name, tt.encoding, session)
+		if err != nil {
+			t.Errorf("GetDecoded(%s, %s) error = %v", tt.name, tt.encoding, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("GetDecoded(%s, %s) = %x, want %x", tt.name, tt.encoding, got, tt.want)
+		}
+	}
+}
+
+func TestGetDecoded_Invalid(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("not-base64", "this is *not* base64!")
+	session, _ := backend.Authenticate(ctx)
+
+	_, err := vaultmux.GetDecoded(ctx, backend, "not-base64", vaultmux.EncodingBase64, session)
+	if !errors.Is(err, vaultmux.ErrInvalidEncoding) {
+		t.Fatalf("GetDecoded() error = %v, want ErrInvalidEncoding", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "not-base64") || !strings.Contains(msg, "not valid base64") {
+		t.Errorf("error %q should name the item and the encoding", msg)
+	}
+
+	_, err = vaultmux.GetDecoded(ctx, backend, "not-base64", "rot13", session)
+	if !errors.Is(err, vaultmux.ErrInvalidEncoding) || !strings.Contains(err.Error(), `unknown encoding "rot13"`) {
+		t.Errorf("GetDecoded(rot13) error = %v, want unknown encoding", err)
+	}
+
+	if _, err := vaultmux.GetDecoded(ctx, backend, "missing", vaultmux.EncodingHex, session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetDecoded(missing) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,24 @@
+package vaultmux
+
+import "context"
+
+// Describer is implemented by backends that can report everything the
+// provider knows about a secret.
+type Describer interface {
+	// Describe returns the provider's own metadata for the named secret,
+	// never its value. Keys and value shapes follow the provider's API
+	// (e.g. GCP "createTime", AWS "RotationEnabled") rather than a
+	// normalized schema, so nothing is lost in translation.
+	Describe(ctx context.Context, name string, session Session) (map[string]any, error)
+}
+
+// Describe returns the provider-native metadata of the named secret, such
+// as timestamps, rotation settings, replication and tags. Backends that
+// don't implement Describer return ErrNotSupported.
+func Describe(ctx context.Context, b Backend, name string, session Session) (map[string]any, error) {
+	d, ok := b.(Describer)
+	if !ok {
+		return nil, WrapError(b.Name(), "describe", name, ErrNotSupported)
+	}
+	return d.Describe(ctx, name, session)
+}
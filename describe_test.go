@@ -0,0 +1,17 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestDescribe_NotSupported(t *testing.T) {
+	_, err := vaultmux.Describe(context.Background(), mock.New(), "db", nil)
+	if !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("Describe() error = %v, want ErrNotSupported", err)
+	}
+}
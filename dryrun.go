@@ -0,0 +1,25 @@
+package vaultmux
+
+import "context"
+
+// dryRunKey is the context key for the dry-run flag.
+type dryRunKey struct{}
+
+// WithDryRun returns a context that asks backends not to mutate anything.
+// Mutating methods (create, update, set, delete, create location, and
+// backend-specific ones like SetPassword) still validate their inputs and
+// check existence, returning the errors a real call would, but return nil
+// instead of making the change.
+//
+// Use it when the backend can't be rewrapped, e.g. one shared across
+// callers where only some requests are dry runs.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx was marked with WithDryRun.
+// Backend implementations check it before performing a mutation.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
@@ -0,0 +1,53 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestIsDryRun(t *testing.T) {
+	ctx := context.Background()
+	if vaultmux.IsDryRun(ctx) {
+		t.Error("IsDryRun() = true for plain context")
+	}
+	if !vaultmux.IsDryRun(vaultmux.WithDryRun(ctx)) {
+		t.Error("IsDryRun() = false after WithDryRun")
+	}
+}
+
+func TestDryRun_Mock(t *testing.T) {
+	backend := mock.New()
+	backend.SetItem("existing", "value")
+	session, _ := backend.Authenticate(context.Background())
+	ctx := vaultmux.WithDryRun(context.Background())
+
+	if err := backend.CreateItem(ctx, "new", "value", session); err != nil {
+		t.Errorf("CreateItem() error = %v", err)
+	}
+	if err := backend.UpdateItem(ctx, "existing", "changed", session); err != nil {
+		t.Errorf("UpdateItem() error = %v", err)
+	}
+	if err := backend.DeleteItem(ctx, "existing", session); err != nil {
+		t.Errorf("DeleteItem() error = %v", err)
+	}
+
+	// Checks still run and report what the real call would fail with
+	if err := backend.CreateItem(ctx, "existing", "value", session); !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("CreateItem(existing) error = %v, want ErrAlreadyExists", err)
+	}
+	if err := backend.DeleteItem(ctx, "missing", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("DeleteItem(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// The store is unchanged
+	if exists, _ := backend.ItemExists(ctx, "new", session); exists {
+		t.Error("dry-run CreateItem stored an item")
+	}
+	if got, err := backend.GetNotes(ctx, "existing", session); err != nil || got != "value" {
+		t.Errorf("GetNotes(existing) = %q, %v; want unchanged %q", got, err, "value")
+	}
+}
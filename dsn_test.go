@@ -0,0 +1,102 @@
+package vaultmux_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestOpen(t *testing.T) {
+	var got vaultmux.Config
+	vaultmux.RegisterBackend("mock", func(cfg vaultmux.Config) (vaultmux.Backend, error) {
+		got = cfg
+		return mock.New(), nil
+	})
+
+	backend, err := vaultmux.Open("mock://?prefix=app/&region=us-west-2")
+	if err != nil {
+		t.Fatalf("Open(mock) error = %v", err)
+	}
+	if backend.Name() != "mock" {
+		t.Errorf("Open(mock) backend = %q, want mock", backend.Name())
+	}
+	if got.Backend != "mock" || got.Prefix != "app/" || got.Options["region"] != "us-west-2" {
+		t.Errorf("Open(mock) config = %+v", got)
+	}
+
+	backend, err = vaultmux.Open("pass://?prefix=x")
+	if err != nil {
+		t.Fatalf("Open(pass) error = %v", err)
+	}
+	if backend.Name() != "pass" {
+		t.Errorf("Open(pass) backend = %q, want pass", backend.Name())
+	}
+
+	if _, err := vaultmux.Open("nosuchvault://?prefix=x"); err == nil || !strings.Contains(err.Error(), "unknown backend") {
+		t.Errorf("Open(unknown) error = %v, want unknown backend", err)
+	}
+}
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		name        string
+		dsn         string
+		wantBackend vaultmux.BackendType
+		wantPrefix  string
+		wantOptions map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "pass with prefix",
+			dsn:         "pass://?prefix=x",
+			wantBackend: vaultmux.BackendPass,
+			wantPrefix:  "x",
+			wantOptions: map[string]string{"prefix": "x"},
+		},
+		{
+			name:        "aws with options",
+			dsn:         "awssecrets://?region=us-west-2&prefix=app/",
+			wantBackend: vaultmux.BackendAWSSecretsManager,
+			wantPrefix:  "app/",
+			wantOptions: map[string]string{"region": "us-west-2", "prefix": "app/"},
+		},
+		{
+			name:        "non-URL scheme",
+			dsn:         "1password://",
+			wantBackend: vaultmux.BackendOnePassword,
+			wantOptions: map[string]string{},
+		},
+		{name: "missing scheme", dsn: "pass", wantErr: true},
+		{name: "host not allowed", dsn: "pass://store?prefix=x", wantErr: true},
+		{name: "bad query", dsn: "pass://?prefix=%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := vaultmux.ParseDSN(tt.dsn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDSN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if cfg.Backend != tt.wantBackend {
+				t.Errorf("Backend = %q, want %q", cfg.Backend, tt.wantBackend)
+			}
+			if cfg.Prefix != tt.wantPrefix {
+				t.Errorf("Prefix = %q, want %q", cfg.Prefix, tt.wantPrefix)
+			}
+			if len(cfg.Options) != len(tt.wantOptions) {
+				t.Errorf("Options = %v, want %v", cfg.Options, tt.wantOptions)
+			}
+			for k, v := range tt.wantOptions {
+				if cfg.Options[k] != v {
+					t.Errorf("Options[%q] = %q, want %q", k, cfg.Options[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package vaultmux
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// maskedNamePrefix marks an item name replaced by MaskName.
+const maskedNamePrefix = "sha256:"
+
+// MaskName returns a short, stable token standing in for name, such as
+// "sha256:3f2a9c1d0b7e". The same name always gives the same token, so
+// masked errors can still be correlated with each other and, by whoever
+// knows the name, with the item.
+func MaskName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return maskedNamePrefix + hex.EncodeToString(sum[:6])
+}
+
+// ErrorNameMasker wraps a Backend and replaces item names in the errors it
+// returns with MaskName tokens, for deployments where names are themselves
+// sensitive (e.g. they encode customer IDs). The names in BackendError.Item
+// and AmbiguousError.Name are masked, and so is every occurrence of them in
+// the text of the provider or CLI errors they wrap, such as a GCP resource
+// path or an AWS ARN. errors.Is and errors.As work as before, but the
+// original errors they reach still carry the raw names in their own fields
+// and messages.
+//
+// CreateItemWithOptions and SetItem mask their errors too. Errors from the
+// backend returned by Unwrap are not masked.
+type ErrorNameMasker struct {
+	Backend
+}
+
+// NewErrorNameMasker wraps b so that item names are masked in its errors.
+func NewErrorNameMasker(b Backend) *ErrorNameMasker {
+	return &ErrorNameMasker{Backend: b}
+}
+
+// GetItem retrieves an item, masking its name in any error.
+func (m *ErrorNameMasker) GetItem(ctx context.Context, name string, session Session) (*Item, error) {
+	item, err := m.Backend.GetItem(ctx, name, session)
+	return item, maskNames(err, name)
+}
+
+// GetNotes retrieves the notes of an item, masking its name in any error.
+func (m *ErrorNameMasker) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	notes, err := m.Backend.GetNotes(ctx, name, session)
+	return notes, maskNames(err, name)
+}
+
+// ItemExists checks for an item, masking its name in any error.
+func (m *ErrorNameMasker) ItemExists(ctx context.Context, name string, session Session) (bool, error) {
+	exists, err := m.Backend.ItemExists(ctx, name, session)
+	return exists, maskNames(err, name)
+}
+
+// ListItems lists all items, masking names in any error.
+func (m *ErrorNameMasker) ListItems(ctx context.Context, session Session) ([]*Item, error) {
+	items, err := m.Backend.ListItems(ctx, session)
+	return items, maskNames(err)
+}
+
+// CreateItem creates an item, masking its name in any error.
+func (m *ErrorNameMasker) CreateItem(ctx context.Context, name, content string, session Session) error {
+	return maskNames(m.Backend.CreateItem(ctx, name, content, session), name)
+}
+
+// UpdateItem updates an item, masking its name in any error.
+func (m *ErrorNameMasker) UpdateItem(ctx context.Context, name, content string, session Session) error {
+	return maskNames(m.Backend.UpdateItem(ctx, name, content, session), name)
+}
+
+// DeleteItem deletes an item, masking its name in any error.
+func (m *ErrorNameMasker) DeleteItem(ctx context.Context, name string, session Session) error {
+	return maskNames(m.Backend.DeleteItem(ctx, name, session), name)
+}
+
+// ListItemsInLocation lists items in a location, masking names in any error.
+func (m *ErrorNameMasker) ListItemsInLocation(ctx context.Context, locType, locValue string, session Session) ([]*Item, error) {
+	items, err := m.Backend.ListItemsInLocation(ctx, locType, locValue, session)
+	return items, maskNames(err)
+}
+
+// CreateItemWithOptions creates an item with opts, masking its name in any
+// error. Options are ignored if the wrapped backend is not an ItemCreator.
+func (m *ErrorNameMasker) CreateItemWithOptions(ctx context.Context, name, content string, session Session, opts ...CreateOption) error {
+	return maskNames(createItemWithOptions(ctx, m.Backend, name, content, session, opts...), name)
+}
+
+// SetItem creates or updates an item, masking its name in any error.
+func (m *ErrorNameMasker) SetItem(ctx context.Context, name, content string, session Session) error {
+	return maskNames(SetItem(ctx, m.Backend, name, content, session), name)
+}
+
+// Unwrap returns the wrapped backend.
+func (m *ErrorNameMasker) Unwrap() Backend {
+	return m.Backend
+}
+
+// maskNames replaces the item names carried by err's chain with MaskName
+// tokens, in place, and scrubs them and names from the messages of the
+// errors they wrap. It returns err, or a maskedError in its place if err is
+// not a BackendError and its own message held a name.
+func maskNames(err error, names ...string) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e := e.(type) {
+		case *BackendError:
+			names = append(names, e.Item)
+		case *AmbiguousError:
+			names = append(names, e.Name)
+		}
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e := e.(type) {
+		case *BackendError:
+			e.Item = maskOnce(e.Item)
+			e.Err = scrubNames(e.Err, names)
+		case *AmbiguousError:
+			e.Name = maskOnce(e.Name)
+		}
+	}
+	return scrubNames(err, names)
+}
+
+// maskedError stands in for an error whose message contained item names.
+// It keeps the original in the chain for errors.Is and errors.As.
+type maskedError struct {
+	msg string
+	err error
+}
+
+func (e *maskedError) Error() string { return e.msg }
+func (e *maskedError) Unwrap() error { return e.err }
+
+// scrubNames returns err, or a maskedError in its place if its message
+// contains any of names. Longer names are replaced first, so a name that
+// contains another is masked as a whole.
+func scrubNames(err error, names []string) error {
+	switch err.(type) {
+	case nil, *BackendError, *AmbiguousError:
+		return err // Masked by maskNames itself
+	}
+
+	names = slices.Clone(names)
+	slices.SortFunc(names, func(a, b string) int { return len(b) - len(a) })
+	msg := err.Error()
+	for _, name := range names {
+		if name != "" && !strings.HasPrefix(name, maskedNamePrefix) {
+			msg = strings.ReplaceAll(msg, name, MaskName(name))
+		}
+	}
+	if msg == err.Error() {
+		return err
+	}
+	return &maskedError{msg: msg, err: err}
+}
+
+// maskOnce masks name unless it is empty or already masked.
+func maskOnce(name string) string {
+	if name == "" || strings.HasPrefix(name, maskedNamePrefix) {
+		return name
+	}
+	return MaskName(name)
+}
@@ -0,0 +1,100 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+// wrappingBackend wraps mock errors the way real backends do.
+type wrappingBackend struct {
+	*mock.Backend
+}
+
+func (b wrappingBackend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	notes, err := b.Backend.GetNotes(ctx, name, session)
+	return notes, vaultmux.WrapError(b.Name(), "get", name, err)
+}
+
+func TestErrorNameMasker(t *testing.T) {
+	ctx := context.Background()
+	backend := vaultmux.NewErrorNameMasker(wrappingBackend{mock.New()})
+	session, _ := backend.Authenticate(ctx)
+
+	_, err := backend.GetNotes(ctx, "customer-4711-api-key", session)
+	if !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Fatalf("GetNotes() error = %v, want ErrNotFound", err)
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "customer-4711") {
+		t.Errorf("error %q contains the item name", msg)
+	}
+	if token := vaultmux.MaskName("customer-4711-api-key"); !strings.Contains(msg, token) {
+		t.Errorf("error %q does not contain masked name %q", msg, token)
+	}
+
+	var be *vaultmux.BackendError
+	if !errors.As(err, &be) || be.Item != vaultmux.MaskName("customer-4711-api-key") {
+		t.Errorf("BackendError.Item = %q, want masked name", be.Item)
+	}
+}
+
+func TestMaskName(t *testing.T) {
+	a, b := vaultmux.MaskName("customer-1"), vaultmux.MaskName("customer-2")
+	if a != vaultmux.MaskName("customer-1") {
+		t.Error("MaskName() is not stable")
+	}
+	if a == b {
+		t.Errorf("MaskName() = %q for different names", a)
+	}
+	if !strings.HasPrefix(a, "sha256:") {
+		t.Errorf("MaskName() = %q, want sha256: prefix", a)
+	}
+}
+
+// arnBackend fails the way the AWS backend does, with the secret's ARN in
+// the provider message and a prefixed name that differs from the item name.
+type arnBackend struct {
+	*mock.Backend
+}
+
+func (b arnBackend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	err := fmt.Errorf("AccessDeniedException: not authorized to access arn:aws:secretsmanager:us-east-1:1:secret:app/%s-Ab12: %w", name, vaultmux.ErrPermissionDenied)
+	return "", vaultmux.WrapError(b.Name(), "get", name, err)
+}
+
+func (b arnBackend) DeleteItem(ctx context.Context, name string, session vaultmux.Session) error {
+	return fmt.Errorf("bw delete %s: exit status 1", name)
+}
+
+func TestErrorNameMasker_ProviderMessages(t *testing.T) {
+	ctx := context.Background()
+	backend := vaultmux.NewErrorNameMasker(arnBackend{mock.New()})
+
+	_, err := backend.GetNotes(ctx, "customer-4711-api-key", nil)
+	if !errors.Is(err, vaultmux.ErrPermissionDenied) {
+		t.Fatalf("GetNotes() error = %v, want ErrPermissionDenied", err)
+	}
+	if msg := err.Error(); strings.Contains(msg, "customer-4711") {
+		t.Errorf("error %q contains the item name", msg)
+	}
+	token := vaultmux.MaskName("customer-4711-api-key")
+	if msg := err.Error(); !strings.Contains(msg, "secret:app/"+token+"-Ab12") {
+		t.Errorf("error %q does not mask the name inside the ARN", msg)
+	}
+
+	err = backend.DeleteItem(ctx, "customer-4711-api-key", nil)
+	if err == nil || strings.Contains(err.Error(), "customer-4711") {
+		t.Errorf("DeleteItem() error = %v, want the name masked", err)
+	}
+
+	if _, ok := backend.Unwrap().(arnBackend); !ok {
+		t.Errorf("Unwrap() = %T, want the wrapped backend", backend.Unwrap())
+	}
+}
@@ -3,14 +3,16 @@ package vaultmux
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // BackendError wraps errors with backend context.
 type BackendError struct {
-	Backend string
-	Op      string // Operation: "get", "create", "delete", etc.
-	Item    string // Item name (if applicable)
-	Err     error
+	Backend    string
+	Op         string // Operation: "get", "create", "delete", etc.
+	Item       string // Item name (if applicable)
+	Err        error
+	RetryAfter time.Duration // Provider-suggested retry delay (zero if none)
 }
 
 // Error returns the error message.
@@ -44,3 +46,71 @@ func WrapError(backend, op, item string, err error) error {
 		Err:     err,
 	}
 }
+
+// AmbiguousError is returned when a name matches more than one item. It
+// matches ErrAmbiguous with errors.Is; use errors.As to get the candidates
+// and pick one by ID or location.
+type AmbiguousError struct {
+	Name       string
+	Candidates []*Item
+}
+
+// Error returns the error message.
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%v: %d items named %q", ErrAmbiguous, len(e.Candidates), e.Name)
+}
+
+// Is reports whether target is ErrAmbiguous.
+func (e *AmbiguousError) Is(target error) bool {
+	return target == ErrAmbiguous
+}
+
+// ConfigError is returned by backend constructors for a missing or invalid
+// option. It matches ErrInvalidConfig with errors.Is; use errors.As to get
+// the offending option name.
+type ConfigError struct {
+	Field  string // Option name, e.g. "project_id"
+	Reason string
+}
+
+// Error returns the error message.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidConfig.
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// NotSupportedError is returned for an operation a backend doesn't
+// support, such as locations in the cloud backends. It matches
+// ErrNotSupported with errors.Is; use errors.As to get the backend and
+// operation.
+type NotSupportedError struct {
+	Backend   string
+	Operation string // Method name, e.g. "ListLocations"
+}
+
+// Error returns the error message.
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Backend, e.Operation, ErrNotSupported)
+}
+
+// Is reports whether target is ErrNotSupported.
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// RetryAfter returns the provider-suggested retry delay carried by err,
+// if any BackendError in its chain has one.
+func RetryAfter(err error) (time.Duration, bool) {
+	var be *BackendError
+	for errors.As(err, &be) {
+		if be.RetryAfter > 0 {
+			return be.RetryAfter, true
+		}
+		err = be.Err
+	}
+	return 0, false
+}
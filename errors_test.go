@@ -3,6 +3,7 @@ package vaultmux
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestBackendError_Is(t *testing.T) {
@@ -112,6 +113,56 @@ func TestWrapError_Nil(t *testing.T) {
 	}
 }
 
+func TestConfigError(t *testing.T) {
+	err := error(&ConfigError{Field: "project_id", Reason: "required for GCP Secret Manager"})
+
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Error("errors.Is(ConfigError, ErrInvalidConfig) = false")
+	}
+	if want := "invalid project_id: required for GCP Secret Manager"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name: "no retry hint",
+			err:  WrapError("test", "get", "item", errors.New("boom")),
+		},
+		{
+			name:   "direct retry hint",
+			err:    &BackendError{Backend: "test", Op: "get", Err: errors.New("quota"), RetryAfter: 3 * time.Second},
+			want:   3 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "nested retry hint",
+			err:    WrapError("outer", "get", "item", &BackendError{Backend: "inner", Op: "get", Err: errors.New("quota"), RetryAfter: time.Second}),
+			want:   time.Second,
+			wantOK: true,
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RetryAfter(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RetryAfter() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
@@ -0,0 +1,156 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+)
+
+// ExportOptions holds optional settings for Export.
+type ExportOptions struct {
+	// Concurrency is the number of items fetched in parallel. Values below
+	// 2 fetch items one at a time.
+	Concurrency int
+
+	// AgeRecipients encrypts the snapshot to these age public keys
+	// ("age1..."), so it can be stored somewhere untrusted. Any matching
+	// identity can decrypt it; see ImportOptions.AgeIdentity.
+	AgeRecipients []string
+}
+
+// ExportOption configures ExportOptions.
+type ExportOption func(*ExportOptions)
+
+// WithConcurrency makes Export fetch up to n item values in parallel. This
+// matters for cloud backends, where each value is a separate API call.
+func WithConcurrency(n int) ExportOption {
+	return func(o *ExportOptions) {
+		o.Concurrency = n
+	}
+}
+
+// WithAgeRecipients makes Export encrypt the snapshot to the given age
+// public keys.
+func WithAgeRecipients(recipients ...string) ExportOption {
+	return func(o *ExportOptions) {
+		o.AgeRecipients = append(o.AgeRecipients, recipients...)
+	}
+}
+
+// Export writes every item in b, including its value, to w as JSON Lines:
+// one Item object per line, ordered by name. The output order does not
+// depend on Concurrency; fetched items are buffered and written in order.
+//
+// With AgeRecipients set, the JSON Lines are age-encrypted and w receives
+// only ciphertext.
+//
+// The first error from listing or fetching stops the export before anything
+// is written.
+func Export(ctx context.Context, b Backend, session Session, w io.Writer, opts ...ExportOption) error {
+	var o ExportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var recipients []age.Recipient
+	if len(o.AgeRecipients) > 0 {
+		var err error
+		recipients, err = age.ParseRecipients(strings.NewReader(strings.Join(o.AgeRecipients, "\n")))
+		if err != nil {
+			return fmt.Errorf("invalid age recipient: %w", err)
+		}
+	}
+
+	names, err := ListItemNames(ctx, b, session)
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	items, err := fetchItems(ctx, b, session, names, max(o.Concurrency, 1))
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) == 0 {
+		return writeItems(w, items)
+	}
+
+	encrypted, err := age.Encrypt(w, recipients...)
+	if err != nil {
+		return err
+	}
+	if err := writeItems(encrypted, items); err != nil {
+		return err
+	}
+	return encrypted.Close()
+}
+
+// writeItems writes items to w as JSON Lines.
+func writeItems(w io.Writer, items []*Item) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchItems gets names with up to workers concurrent GetItem calls and
+// returns the items in the order of names.
+func fetchItems(ctx context.Context, b Backend, session Session, names []string, workers int) ([]*Item, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make([]*Item, len(names))
+	indexes := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range min(workers, len(names)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item, err := b.GetItem(ctx, names[i], session)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				items[i] = item
+			}
+		}()
+	}
+
+send:
+	for i := range names {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,95 @@
+package vaultmux_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func newExportMock(count int) *mock.Backend {
+	backend := mock.New()
+	for i := range count {
+		backend.SetItem(fmt.Sprintf("item-%03d", i), fmt.Sprintf("value-%03d", i))
+	}
+	return backend
+}
+
+func TestExport_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	backend := newExportMock(50)
+
+	var buf bytes.Buffer
+	if err := vaultmux.Export(ctx, backend, nil, &buf, vaultmux.WithConcurrency(8)); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var item vaultmux.Item
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			t.Fatalf("invalid export line %q: %v", scanner.Text(), err)
+		}
+		if want := "value-" + strings.TrimPrefix(item.Name, "item-"); item.Notes != want {
+			t.Errorf("item %s Notes = %q, want %q", item.Name, item.Notes, want)
+		}
+		names = append(names, item.Name)
+	}
+
+	if len(names) != 50 {
+		t.Fatalf("Export() wrote %d items, want 50", len(names))
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Export() items not in sorted order: %v", names)
+	}
+}
+
+func TestExport_GetError(t *testing.T) {
+	ctx := context.Background()
+	backend := newExportMock(10)
+	backend.GetError = errors.New("boom")
+
+	var buf bytes.Buffer
+	err := vaultmux.Export(ctx, backend, nil, &buf, vaultmux.WithConcurrency(4))
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("Export() error = %v, want boom", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Export() wrote %d bytes after failing fetch", buf.Len())
+	}
+}
+
+// slowBackend adds a fixed latency to every GetItem, like a cloud API call.
+type slowBackend struct {
+	*mock.Backend
+	latency time.Duration
+}
+
+func (b slowBackend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
+	time.Sleep(b.latency)
+	return b.Backend.GetItem(ctx, name, session)
+}
+
+func benchmarkExport(b *testing.B, concurrency int) {
+	ctx := context.Background()
+	backend := slowBackend{newExportMock(50), time.Millisecond}
+
+	for b.Loop() {
+		if err := vaultmux.Export(ctx, backend, nil, &bytes.Buffer{}, vaultmux.WithConcurrency(concurrency)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExport_Serial(b *testing.B)     { benchmarkExport(b, 1) }
+func BenchmarkExport_Concurrent(b *testing.B) { benchmarkExport(b, 8) }
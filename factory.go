@@ -1,8 +1,15 @@
 package vaultmux
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // BackendType identifies a vault backend.
@@ -25,6 +32,48 @@ const (
 	BackendAzureKeyVault BackendType = "azurekeyvault"
 )
 
+// String returns the string representation of BackendType.
+func (t BackendType) String() string {
+	return string(t)
+}
+
+// Valid reports whether a factory is registered for this backend type.
+// Built-in backends are only valid once their package has been imported.
+func (t BackendType) Valid() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := backendFactories[t]
+	return ok
+}
+
+// BuiltinBackends returns the backend types shipped with vaultmux,
+// regardless of whether their packages have been imported.
+func BuiltinBackends() []BackendType {
+	return []BackendType{
+		BackendBitwarden,
+		BackendOnePassword,
+		BackendPass,
+		BackendWindowsCredentialManager,
+		BackendAWSSecretsManager,
+		BackendGCPSecretManager,
+		BackendAzureKeyVault,
+	}
+}
+
+// RegisteredBackends returns the backend types with a registered factory,
+// sorted by name.
+func RegisteredBackends() []BackendType {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	types := make([]BackendType, 0, len(backendFactories))
+	for t := range backendFactories {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
 // Config holds vault configuration.
 type Config struct {
 	// Backend type: "bitwarden", "1password", "pass", "wincred", "awssecrets", "gcpsecrets", "azurekeyvault"
@@ -33,11 +82,86 @@ type Config struct {
 	// Pass-specific
 	StorePath string // Default: ~/.password-store
 	Prefix    string // Default: "dotfiles"
+	Trash     bool   // Move deleted entries to <prefix>/.trash (see pass.Backend.RestoreItem)
 
 	// Session management
 	SessionFile string // Where to cache session token
 	SessionTTL  int    // How long to cache in seconds (default: 1800 / 30m)
 
+	// ScrubSessionOnClose clears the cached session when the backend is
+	// closed, for short-lived processes on shared machines (CLI backends
+	// with a session cache only; default: keep the session cached).
+	ScrubSessionOnClose bool
+
+	// SessionStore shares sessions in memory between backends built from
+	// configs that reference the same store (optional, CLI backends only).
+	SessionStore *SessionStore
+
+	// PasswordPrompt supplies the master password when a CLI backend has to
+	// unlock the vault, including re-unlocking after the vault locks
+	// mid-operation (optional, Bitwarden only; default: bw prompts on the
+	// terminal).
+	PasswordPrompt func(ctx context.Context) (string, error)
+
+	// Observer receives backend events such as subprocess runs (optional)
+	Observer Observer
+
+	// Logger receives diagnostics such as slow operations (optional)
+	Logger *slog.Logger
+
+	// Clock overrides the time source for session and status cache expiry
+	// (optional, default: SystemClock)
+	Clock Clock
+
+	// NameTransform enforces a naming convention, such as lowercasing, by
+	// rewriting every item name before it reaches the backend (optional).
+	// New wraps the backend in a NameTransformer when it is set.
+	NameTransform func(name string) string
+
+	// NameInverse maps stored names back for display in returned items
+	// (optional, used only with NameTransform).
+	NameInverse func(name string) string
+
+	// ValueTransform encodes secret values before they are stored and
+	// decodes them on read, e.g. GzipTransform or an AESGCMTransform
+	// (optional). New wraps the backend in a ValueTransformer when it is set.
+	ValueTransform ValueTransform
+
+	// RequireValueTransform rejects stored values that were not written
+	// through ValueTransform instead of returning them unchanged (optional,
+	// see RequireMarker).
+	RequireValueTransform bool
+
+	// MaskNamesInErrors replaces item names in returned errors with hashed
+	// tokens (see MaskName), for deployments where names are sensitive.
+	// New wraps the backend in an ErrorNameMasker when it is set.
+	MaskNamesInErrors bool
+
+	// MissingSecretPolicy selects what GetNotes returns for missing items:
+	// an error wrapping ErrNotFound (MissingSecretError, the default) or ""
+	// with a nil error (MissingSecretReturnEmpty). New wraps the backend in
+	// an EmptyOnMissing for MissingSecretReturnEmpty.
+	MissingSecretPolicy MissingSecretPolicy
+
+	// CacheTTL caches the items and notes read through the backend for
+	// this long, sharing one copy between names with identical values
+	// (optional, default: no caching). New wraps the backend in a
+	// CachingBackend, using Clock for expiry, when it is set; entries are
+	// keyed on names after NameTransform.
+	CacheTTL time.Duration
+
+	// InitTimeout bounds the connectivity check cloud backends make in Init,
+	// independently of the context passed to Init (optional, default:
+	// DefaultInitTimeout; AWS, GCP and Azure only).
+	InitTimeout time.Duration
+
+	// MaxListResults caps the items returned by ListItems, guarding
+	// against a misconfigured prefix listing an entire cloud vault. When
+	// more items match, the first MaxListResults are returned together with
+	// an error wrapping ErrListTruncated (optional, AWS and GCP only;
+	// default: no limit).
+	MaxListResults int
+
 	// Backend-specific options
 	Options map[string]string
 }
@@ -45,8 +169,13 @@ type Config struct {
 // BackendFactory creates a backend from configuration.
 type BackendFactory func(cfg Config) (Backend, error)
 
+// ConfigValidator checks a backend's options without creating clients,
+// reading credentials or touching the network.
+type ConfigValidator func(options map[string]string) error
+
 var (
 	backendFactories = make(map[BackendType]BackendFactory)
+	configValidators = make(map[BackendType]ConfigValidator)
 	mu               sync.RWMutex
 )
 
@@ -58,10 +187,81 @@ func RegisterBackend(backendType BackendType, factory BackendFactory) {
 	backendFactories[backendType] = factory
 }
 
-// New creates a new vault backend based on configuration.
+// RegisterConfigValidator registers the option validation used by
+// ValidateConfig. Backends with options to check should call this in their
+// init() function, next to RegisterBackend.
+func RegisterConfigValidator(backendType BackendType, validate ConfigValidator) {
+	mu.Lock()
+	defer mu.Unlock()
+	configValidators[backendType] = validate
+}
+
+// ValidateConfig checks that cfg names a registered backend and that its
+// options are well-formed, without constructing the backend. It needs no
+// credentials or network access, so it suits CI checks of configuration.
+// Backends that don't register a ConfigValidator have no options to check.
+func ValidateConfig(cfg Config) error {
+	mu.RLock()
+	_, ok := backendFactories[cfg.Backend]
+	validate := configValidators[cfg.Backend]
+	mu.RUnlock()
+
+	if !ok {
+		return &ConfigError{Field: "backend", Reason: fmt.Sprintf("unknown backend %q (did you import the backend package?)", cfg.Backend)}
+	}
+	if validate == nil {
+		return nil
+	}
+	return validate(cfg.Options)
+}
+
+// ConfigOption sets a Config field or backend option, so programmatic
+// callers don't have to build the Options map by hand. Options passed to
+// New override conflicting values in the Config.
+type ConfigOption func(*Config)
+
+// WithOption sets the backend option key to value, as in Config.Options.
+func WithOption(key, value string) ConfigOption {
+	return func(c *Config) {
+		c.Options[key] = value
+	}
+}
+
+// WithPrefix sets the item name prefix, both Config.Prefix (pass, wincred)
+// and the "prefix" option (cloud backends).
+func WithPrefix(prefix string) ConfigOption {
+	return func(c *Config) {
+		c.Prefix = prefix
+		c.Options["prefix"] = prefix
+	}
+}
+
+// WithRegion sets the "region" option (AWS).
+func WithRegion(region string) ConfigOption {
+	return WithOption("region", region)
+}
+
+// WithEndpoint sets the "endpoint" option, a custom service endpoint such
+// as LocalStack or an emulator (AWS, GCP).
+func WithEndpoint(endpoint string) ConfigOption {
+	return WithOption("endpoint", endpoint)
+}
+
+// New creates a new vault backend based on configuration, with opts
+// applied over cfg. cfg.Options is copied, not modified.
 // The backend package must be imported for the backend to be available.
 // Example: import _ "github.com/blackwell-systems/vaultmux/backends/pass"
-func New(cfg Config) (Backend, error) {
+func New(cfg Config, opts ...ConfigOption) (Backend, error) {
+	if len(opts) > 0 {
+		cfg.Options = maps.Clone(cfg.Options)
+		if cfg.Options == nil {
+			cfg.Options = make(map[string]string)
+		}
+		for _, opt := range opts {
+			opt(&cfg)
+		}
+	}
+
 	// Apply defaults
 	if cfg.SessionTTL == 0 {
 		cfg.SessionTTL = 1800 // 30 minutes
@@ -75,14 +275,90 @@ func New(cfg Config) (Backend, error) {
 		return nil, fmt.Errorf("unknown backend: %s (did you import the backend package?)", cfg.Backend)
 	}
 
-	return factory(cfg)
+	b, err := factory(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ValueTransform != nil {
+		var opts []ValueTransformerOption
+		if cfg.RequireValueTransform {
+			opts = append(opts, RequireMarker())
+		}
+		b = NewValueTransformer(b, cfg.ValueTransform, opts...)
+	}
+	// The cache sits inside the name transformer, so names that map to
+	// the same stored name share one entry and invalidate it together
+	if cfg.CacheTTL > 0 {
+		b = NewCachingBackend(b, cfg.CacheTTL, cfg.Clock)
+	}
+	if cfg.NameTransform != nil {
+		b = NewNameTransformer(b, cfg.NameTransform, cfg.NameInverse)
+	}
+	if cfg.MissingSecretPolicy == MissingSecretReturnEmpty {
+		b = NewEmptyOnMissing(b)
+	}
+	if cfg.MaskNamesInErrors {
+		b = NewErrorNameMasker(b)
+	}
+	return b, nil
 }
 
 // MustNew creates a backend or panics. Use in init() only.
-func MustNew(cfg Config) Backend {
-	b, err := New(cfg)
+func MustNew(cfg Config, opts ...ConfigOption) Backend {
+	b, err := New(cfg, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return b
 }
+
+// ParseDSN parses a connection string of the form "<backend>://?key=value&..."
+// into a Config. The scheme names the backend type and query parameters
+// become Options; "prefix", "store_path" and "session_file" also set the
+// matching Config fields.
+//
+// Example: awssecrets://?region=us-west-2&prefix=app/
+func ParseDSN(dsn string) (Config, error) {
+	scheme, rest, ok := strings.Cut(dsn, "://")
+	if !ok || scheme == "" {
+		return Config{}, fmt.Errorf("invalid DSN %q: expected <backend>://?options", dsn)
+	}
+
+	// Backend names such as "1password" are not valid URL schemes,
+	// so only the query part is parsed as a URL.
+	location, rawQuery, _ := strings.Cut(rest, "?")
+	if location != "" {
+		return Config{}, fmt.Errorf("invalid DSN %q: unexpected host or path %q", dsn, location)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid DSN %q: %w", dsn, err)
+	}
+
+	cfg := Config{
+		Backend: BackendType(strings.ToLower(scheme)),
+		Options: make(map[string]string, len(query)),
+	}
+	for key, values := range query {
+		cfg.Options[key] = values[len(values)-1]
+	}
+	cfg.Prefix = cfg.Options["prefix"]
+	cfg.StorePath = cfg.Options["store_path"]
+	cfg.SessionFile = cfg.Options["session_file"]
+
+	return cfg, nil
+}
+
+// Open creates a backend from a connection string; see ParseDSN.
+// The backend package must be imported for its scheme to be recognized.
+func Open(dsn string) (Backend, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Backend.Valid() {
+		return nil, fmt.Errorf("unknown backend: %s (registered: %v)", cfg.Backend, RegisteredBackends())
+	}
+	return New(cfg)
+}
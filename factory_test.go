@@ -129,3 +129,56 @@ func TestBackendType_Constants(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendType_Valid(t *testing.T) {
+	// The pass backend is registered by example_test.go's import.
+	if !BackendPass.Valid() {
+		t.Error("BackendPass.Valid() = false, want true")
+	}
+	if BackendType("nope").Valid() {
+		t.Error(`BackendType("nope").Valid() = true, want false`)
+	}
+}
+
+func TestBackendType_String(t *testing.T) {
+	if got := BackendAzureKeyVault.String(); got != "azurekeyvault" {
+		t.Errorf("String() = %q, want %q", got, "azurekeyvault")
+	}
+}
+
+func TestBuiltinBackends(t *testing.T) {
+	builtins := BuiltinBackends()
+	if len(builtins) != 7 {
+		t.Fatalf("BuiltinBackends() returned %d types, want 7", len(builtins))
+	}
+
+	seen := make(map[BackendType]bool)
+	for _, bt := range builtins {
+		if seen[bt] {
+			t.Errorf("BuiltinBackends() contains duplicate %q", bt)
+		}
+		seen[bt] = true
+	}
+	if !seen[BackendPass] || !seen[BackendAzureKeyVault] {
+		t.Error("BuiltinBackends() missing expected backend types")
+	}
+}
+
+func TestRegisteredBackends(t *testing.T) {
+	originalFactories := make(map[BackendType]BackendFactory)
+	for k, v := range backendFactories {
+		originalFactories[k] = v
+	}
+	defer func() {
+		backendFactories = originalFactories
+	}()
+
+	backendFactories = map[BackendType]BackendFactory{}
+	RegisterBackend("zeta", func(cfg Config) (Backend, error) { return nil, nil })
+	RegisterBackend("alpha", func(cfg Config) (Backend, error) { return nil, nil })
+
+	got := RegisteredBackends()
+	if len(got) != 2 || got[0] != "alpha" || got[1] != "zeta" {
+		t.Errorf("RegisteredBackends() = %v, want [alpha zeta]", got)
+	}
+}
@@ -0,0 +1,46 @@
+package vaultmux
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// contentHashWorkers bounds the concurrent reads made by ContentHashes.
+const contentHashWorkers = 4
+
+// ContentHash returns the hex SHA-256 of the named item's value, so that
+// external systems can detect drift, or compare a secret across backends,
+// without handling the value itself. The hash is stable across reads and
+// changes whenever the value does.
+//
+// A hash does not protect a low-entropy value, such as a short password,
+// from a guessing attack; treat hashes of those as sensitive.
+func ContentHash(ctx context.Context, b Backend, name string, session Session) (string, error) {
+	notes, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	return hashContent(notes), nil
+}
+
+// ContentHashes returns ContentHash for each of names, keyed by name. Items
+// are read with up to four concurrent calls; the first error stops the
+// batch and no hashes are returned.
+func ContentHashes(ctx context.Context, b Backend, names []string, session Session) (map[string]string, error) {
+	items, err := fetchItems(ctx, b, session, names, contentHashWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]string, len(names))
+	for i, item := range items {
+		hashes[names[i]] = hashContent(item.Notes)
+	}
+	return hashes, nil
+}
+
+func hashContent(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
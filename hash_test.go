@@ -0,0 +1,67 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestContentHash(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "v1")
+	session, _ := backend.Authenticate(ctx)
+
+	first, err := vaultmux.ContentHash(ctx, backend, "api-key", session)
+	if err != nil {
+		t.Fatalf("ContentHash() error = %v", err)
+	}
+	// sha256("v1")
+	if want := "3bfc269594ef649228e9a74bab00f042efc91d5acc6fbee31a382e80d42388fe"; first != want {
+		t.Errorf("ContentHash() = %s, want %s", first, want)
+	}
+	if again, _ := vaultmux.ContentHash(ctx, backend, "api-key", session); again != first {
+		t.Errorf("ContentHash() = %s on second read, want stable %s", again, first)
+	}
+
+	if err := backend.UpdateItem(ctx, "api-key", "v2", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+	if updated, _ := vaultmux.ContentHash(ctx, backend, "api-key", session); updated == first {
+		t.Error("ContentHash() unchanged after the value was updated")
+	}
+}
+
+func TestContentHashes(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "v1")
+	backend.SetItem("db-password", "s3cret")
+	backend.SetItem("copy-of-api-key", "v1")
+	session, _ := backend.Authenticate(ctx)
+
+	names := []string{"api-key", "db-password", "copy-of-api-key"}
+	hashes, err := vaultmux.ContentHashes(ctx, backend, names, session)
+	if err != nil {
+		t.Fatalf("ContentHashes() error = %v", err)
+	}
+	if len(hashes) != 3 {
+		t.Fatalf("ContentHashes() returned %d hashes, want 3", len(hashes))
+	}
+	for _, name := range names {
+		want, _ := vaultmux.ContentHash(ctx, backend, name, session)
+		if hashes[name] != want {
+			t.Errorf("ContentHashes()[%s] = %s, want %s", name, hashes[name], want)
+		}
+	}
+	if hashes["api-key"] != hashes["copy-of-api-key"] || hashes["api-key"] == hashes["db-password"] {
+		t.Error("ContentHashes() does not match equal values and distinguish different ones")
+	}
+
+	if _, err := vaultmux.ContentHashes(ctx, backend, []string{"api-key", "missing"}, session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("ContentHashes() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,162 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// CollisionStrategy selects what an import does with an item whose name
+// already exists in the backend.
+type CollisionStrategy int
+
+const (
+	// CollisionDefault keeps each import's own default: Import overwrites,
+	// ImportDir skips unless DirImportOptions.Overwrite is set.
+	CollisionDefault CollisionStrategy = iota
+	// CollisionSkip leaves the existing item unchanged.
+	CollisionSkip
+	// CollisionOverwrite replaces the existing item's value.
+	CollisionOverwrite
+	// CollisionRename imports the item under the name with the first free
+	// numeric suffix ("api-key-1", "api-key-2", ...).
+	CollisionRename
+	// CollisionError stops the import with an error wrapping
+	// ErrAlreadyExists.
+	CollisionError
+)
+
+// String returns the string representation of CollisionStrategy.
+func (s CollisionStrategy) String() string {
+	switch s {
+	case CollisionDefault:
+		return "Default"
+	case CollisionSkip:
+		return "Skip"
+	case CollisionOverwrite:
+		return "Overwrite"
+	case CollisionRename:
+		return "Rename"
+	case CollisionError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// resolveCollision applies s to name before it is imported into b. It
+// returns the name to store the item under and whether that item already
+// exists; skip reports that the item should not be imported at all.
+func resolveCollision(ctx context.Context, b Backend, session Session, name string, s CollisionStrategy) (target string, exists, skip bool, err error) {
+	exists, err = b.ItemExists(ctx, name, session)
+	if err != nil || !exists {
+		return name, false, false, err
+	}
+
+	switch s {
+	case CollisionSkip:
+		return name, true, true, nil
+	case CollisionError:
+		return "", true, false, WrapError(b.Name(), "import", name, ErrAlreadyExists)
+	case CollisionRename:
+		for i := 1; ; i++ {
+			target = fmt.Sprintf("%s-%d", name, i)
+			taken, err := b.ItemExists(ctx, target, session)
+			if err != nil {
+				return "", false, false, err
+			}
+			if !taken {
+				return target, false, false, nil
+			}
+		}
+	default:
+		return name, true, false, nil
+	}
+}
+
+// ImportOptions holds optional settings for Import.
+type ImportOptions struct {
+	// AgeIdentity decrypts a snapshot exported with AgeRecipients. It holds
+	// one or more age secret keys ("AGE-SECRET-KEY-1..."), in the format of
+	// an age identity file.
+	AgeIdentity string
+
+	// OnCollision selects what happens to items that already exist
+	// (default: overwrite them).
+	OnCollision CollisionStrategy
+}
+
+// ImportOption configures ImportOptions.
+type ImportOption func(*ImportOptions)
+
+// WithAgeIdentity makes Import decrypt the snapshot with the given age
+// identity.
+func WithAgeIdentity(identity string) ImportOption {
+	return func(o *ImportOptions) {
+		o.AgeIdentity = identity
+	}
+}
+
+// WithOnCollision sets what Import does with items that already exist.
+func WithOnCollision(s CollisionStrategy) ImportOption {
+	return func(o *ImportOptions) {
+		o.OnCollision = s
+	}
+}
+
+// Import reads a snapshot written by Export from r and stores each item's
+// value in b with SetItem, creating it or, unless WithOnCollision says
+// otherwise, overwriting it. Only names and values are restored. It returns
+// the number of items imported, not counting skipped ones; on error, items
+// before the failing one have already been stored.
+func Import(ctx context.Context, b Backend, session Session, r io.Reader, opts ...ImportOption) (int, error) {
+	var o ImportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.AgeIdentity != "" {
+		identities, err := age.ParseIdentities(strings.NewReader(o.AgeIdentity))
+		if err != nil {
+			return 0, fmt.Errorf("invalid age identity: %w", err)
+		}
+		if r, err = age.Decrypt(r, identities...); err != nil {
+			return 0, fmt.Errorf("decrypt snapshot: %w", err)
+		}
+	}
+
+	dec := json.NewDecoder(r)
+	imported := 0
+	for {
+		var item Item
+		err := dec.Decode(&item)
+		if errors.Is(err, io.EOF) {
+			return imported, nil
+		}
+		if err != nil {
+			return imported, fmt.Errorf("read snapshot: %w", err)
+		}
+
+		name := item.Name
+		if o.OnCollision != CollisionDefault {
+			target, _, skip, err := resolveCollision(ctx, b, session, name, o.OnCollision)
+			if err != nil {
+				return imported, err
+			}
+			if skip {
+				continue
+			}
+			name = target
+		}
+
+		if err := SetItem(ctx, b, name, item.Notes, session); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+}
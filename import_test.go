@@ -0,0 +1,111 @@
+package vaultmux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestExportImport_Age(t *testing.T) {
+	ctx := context.Background()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var snapshot bytes.Buffer
+	err = vaultmux.Export(ctx, newExportMock(10), nil, &snapshot,
+		vaultmux.WithAgeRecipients(identity.Recipient().String()))
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if bytes.Contains(snapshot.Bytes(), []byte("value-000")) || bytes.Contains(snapshot.Bytes(), []byte(`"name"`)) {
+		t.Fatal("encrypted snapshot contains plaintext")
+	}
+	if !bytes.HasPrefix(snapshot.Bytes(), []byte("age-encryption.org/v1")) {
+		t.Errorf("snapshot does not start with the age header")
+	}
+
+	restored := mock.New()
+	n, err := vaultmux.Import(ctx, restored, nil, bytes.NewReader(snapshot.Bytes()),
+		vaultmux.WithAgeIdentity(identity.String()))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if n != 10 {
+		t.Errorf("Import() = %d items, want 10", n)
+	}
+	if got, _ := restored.GetNotes(ctx, "item-007", nil); got != "value-007" {
+		t.Errorf("restored item-007 = %q, want %q", got, "value-007")
+	}
+
+	wrong, _ := age.GenerateX25519Identity()
+	_, err = vaultmux.Import(ctx, mock.New(), nil, bytes.NewReader(snapshot.Bytes()),
+		vaultmux.WithAgeIdentity(wrong.String()))
+	if err == nil || !strings.Contains(err.Error(), "decrypt snapshot") {
+		t.Errorf("Import() with wrong identity error = %v, want decrypt failure", err)
+	}
+}
+
+func TestImport_Plaintext(t *testing.T) {
+	ctx := context.Background()
+	var snapshot bytes.Buffer
+	if err := vaultmux.Export(ctx, newExportMock(3), nil, &snapshot); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	// Existing items are overwritten
+	restored := mock.New()
+	restored.SetItem("item-001", "stale")
+	if n, err := vaultmux.Import(ctx, restored, nil, &snapshot); err != nil || n != 3 {
+		t.Fatalf("Import() = %d, %v; want 3 items", n, err)
+	}
+	if got, _ := restored.GetNotes(ctx, "item-001", nil); got != "value-001" {
+		t.Errorf("item-001 = %q, want %q", got, "value-001")
+	}
+}
+
+func TestImport_OnCollision(t *testing.T) {
+	ctx := context.Background()
+	var snapshot bytes.Buffer
+	if err := vaultmux.Export(ctx, newExportMock(2), nil, &snapshot); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	restored := mock.New()
+	restored.SetItem("item-001", "stale")
+	n, err := vaultmux.Import(ctx, restored, nil, bytes.NewReader(snapshot.Bytes()), vaultmux.WithOnCollision(vaultmux.CollisionSkip))
+	if err != nil || n != 1 {
+		t.Fatalf("Import(Skip) = %d, %v; want 1 item", n, err)
+	}
+	if got, _ := restored.GetNotes(ctx, "item-001", nil); got != "stale" {
+		t.Errorf("item-001 = %q after Skip, want stale", got)
+	}
+
+	if _, err := vaultmux.Import(ctx, restored, nil, bytes.NewReader(snapshot.Bytes()), vaultmux.WithOnCollision(vaultmux.CollisionRename)); err != nil {
+		t.Fatalf("Import(Rename) error = %v", err)
+	}
+	if got, _ := restored.GetNotes(ctx, "item-001-1", nil); got != "value-001" {
+		t.Errorf("item-001-1 = %q after Rename, want value-001", got)
+	}
+
+	_, err = vaultmux.Import(ctx, restored, nil, bytes.NewReader(snapshot.Bytes()), vaultmux.WithOnCollision(vaultmux.CollisionError))
+	if !errors.Is(err, vaultmux.ErrAlreadyExists) {
+		t.Errorf("Import(Error) error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestExport_InvalidAgeRecipient(t *testing.T) {
+	err := vaultmux.Export(context.Background(), newExportMock(1), nil, &bytes.Buffer{},
+		vaultmux.WithAgeRecipients("not-a-key"))
+	if err == nil {
+		t.Error("Export() accepted invalid age recipient")
+	}
+}
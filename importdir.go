@@ -0,0 +1,144 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// DirImportOptions holds optional settings for ImportDir.
+type DirImportOptions struct {
+	// Overwrite updates items that already exist. Without it they are left
+	// unchanged and reported in ImportResult.Skipped. It is ignored if
+	// OnCollision is set.
+	Overwrite bool
+
+	// OnCollision selects what happens to items that already exist. The
+	// default, CollisionDefault, follows Overwrite.
+	OnCollision CollisionStrategy
+
+	// Locations imports files in subdirectories into a location named after
+	// the subdirectory, e.g. prod/db becomes item "db" in location "prod".
+	// The backend must implement LocationItemCreator. Without it, the
+	// relative path is the item name ("prod/db").
+	Locations bool
+}
+
+// ImportResult reports what ImportDir did, by item name.
+type ImportResult struct {
+	Imported []string          // Created or overwritten, under the name stored
+	Skipped  []string          // Already existed and were left unchanged
+	Encoded  []string          // Binary files stored in base64; read them with GetDecoded and EncodingBase64
+	Renamed  map[string]string // Original name -> name stored under, with CollisionRename
+}
+
+// ImportDir stores each file under dir as an item whose name is the file's
+// path relative to dir and whose value is the file's contents, the layout
+// of a Kubernetes secret volume. Files that are not valid UTF-8 are stored
+// base64-encoded, since backends store text.
+//
+// Hidden files and directories (names starting with "."), such as the
+// ..data links of Kubernetes volumes, are skipped; symlinks to regular
+// files are followed. On error, files before the failing one have already
+// been imported.
+func ImportDir(ctx context.Context, b Backend, session Session, dir string, opts DirImportOptions) (ImportResult, error) {
+	var result ImportResult
+
+	onCollision := opts.OnCollision
+	if onCollision == CollisionDefault {
+		onCollision = CollisionSkip
+		if opts.Overwrite {
+			onCollision = CollisionOverwrite
+		}
+	}
+
+	var creator LocationItemCreator
+	if opts.Locations {
+		var ok bool
+		if creator, ok = b.(LocationItemCreator); !ok {
+			return result, WrapError(b.Name(), "import", "", fmt.Errorf("%w: importing into locations", ErrNotSupported))
+		}
+	}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if info, err := os.Stat(p); err != nil || !info.Mode().IsRegular() {
+			return err // Skip symlinks to directories and special files
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		content := string(data)
+		encoded := !utf8.Valid(data)
+		if encoded {
+			content = base64.StdEncoding.EncodeToString(data)
+		}
+
+		location, itemName := "", name
+		if creator != nil {
+			if i := strings.LastIndex(name, "/"); i >= 0 {
+				location, itemName = path.Dir(name), name[i+1:]
+			}
+		}
+
+		target, exists, skip, err := resolveCollision(ctx, b, session, itemName, onCollision)
+		if err != nil {
+			return err
+		}
+		if skip {
+			result.Skipped = append(result.Skipped, itemName)
+			return nil
+		}
+		if target != itemName {
+			if result.Renamed == nil {
+				result.Renamed = make(map[string]string)
+			}
+			result.Renamed[itemName] = target
+			itemName = target
+		}
+
+		switch {
+		case exists:
+			err = b.UpdateItem(ctx, itemName, content, session)
+		case location != "":
+			err = creator.CreateItemInLocation(ctx, itemName, content, "", location, session, WithCreateLocation())
+		default:
+			err = b.CreateItem(ctx, itemName, content, session)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Imported = append(result.Imported, itemName)
+		if encoded {
+			result.Encoded = append(result.Encoded, itemName)
+		}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,149 @@
+package vaultmux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestImportDir(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	keystore := []byte{0x30, 0x82, 0xff, 0x00, 0xfe}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string][]byte{
+		"api-key":          []byte("s3cret"),
+		"certs/keystore":   keystore,
+		"..data/api-key":   []byte("kubernetes internals"),
+		".hidden-settings": []byte("skip me"),
+	})
+
+	result, err := vaultmux.ImportDir(ctx, backend, session, dir, vaultmux.DirImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDir() error = %v", err)
+	}
+	if want := []string{"api-key", "certs/keystore"}; !reflect.DeepEqual(result.Imported, want) {
+		t.Errorf("ImportDir() imported %v, want %v", result.Imported, want)
+	}
+	if want := []string{"certs/keystore"}; !reflect.DeepEqual(result.Encoded, want) {
+		t.Errorf("ImportDir() encoded %v, want %v", result.Encoded, want)
+	}
+
+	if notes, _ := backend.GetNotes(ctx, "api-key", session); notes != "s3cret" {
+		t.Errorf("api-key = %q, want s3cret", notes)
+	}
+	got, err := vaultmux.GetDecoded(ctx, backend, "certs/keystore", vaultmux.EncodingBase64, session)
+	if err != nil || !bytes.Equal(got, keystore) {
+		t.Errorf("GetDecoded(certs/keystore) = %x, %v; want %x", got, err, keystore)
+	}
+}
+
+func TestImportDir_Overwrite(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "old")
+	session, _ := backend.Authenticate(ctx)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string][]byte{"api-key": []byte("new")})
+
+	result, err := vaultmux.ImportDir(ctx, backend, session, dir, vaultmux.DirImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDir() error = %v", err)
+	}
+	if len(result.Skipped) != 1 || len(result.Imported) != 0 {
+		t.Errorf("ImportDir() = %+v, want api-key skipped", result)
+	}
+	if notes, _ := backend.GetNotes(ctx, "api-key", session); notes != "old" {
+		t.Errorf("api-key = %q after import without Overwrite, want old", notes)
+	}
+
+	if _, err := vaultmux.ImportDir(ctx, backend, session, dir, vaultmux.DirImportOptions{Overwrite: true}); err != nil {
+		t.Fatalf("ImportDir(Overwrite) error = %v", err)
+	}
+	if notes, _ := backend.GetNotes(ctx, "api-key", session); notes != "new" {
+		t.Errorf("api-key = %q after import with Overwrite, want new", notes)
+	}
+}
+
+func TestImportDir_OnCollision(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string][]byte{
+		"api-key": []byte("new"),
+		"db-url":  []byte("postgres://db"),
+	})
+
+	tests := []struct {
+		strategy vaultmux.CollisionStrategy
+		want     map[string]string
+		wantErr  bool
+	}{
+		{vaultmux.CollisionSkip, map[string]string{"api-key": "old", "db-url": "postgres://db"}, false},
+		{vaultmux.CollisionOverwrite, map[string]string{"api-key": "new", "db-url": "postgres://db"}, false},
+		{vaultmux.CollisionRename, map[string]string{"api-key": "old", "api-key-1": "new", "db-url": "postgres://db"}, false},
+		{vaultmux.CollisionError, map[string]string{"api-key": "old"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			ctx := context.Background()
+			backend := mock.New()
+			backend.SetItem("api-key", "old")
+			session, _ := backend.Authenticate(ctx)
+
+			// Overwrite is ignored once OnCollision is set
+			_, err := vaultmux.ImportDir(ctx, backend, session, dir, vaultmux.DirImportOptions{
+				Overwrite:   true,
+				OnCollision: tt.strategy,
+			})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ImportDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, vaultmux.ErrAlreadyExists) {
+				t.Errorf("ImportDir() error = %v, want ErrAlreadyExists", err)
+			}
+
+			got := make(map[string]string)
+			items, _ := backend.ListItems(ctx, session)
+			for _, item := range items {
+				got[item.Name], _ = backend.GetNotes(ctx, item.Name, session)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("store = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportDir_LocationsNotSupported(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	_, err := vaultmux.ImportDir(ctx, backend, session, t.TempDir(), vaultmux.DirImportOptions{Locations: true})
+	if !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("ImportDir(Locations) error = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,135 @@
+// Package gcpmock is an in-memory implementation of the GCP Secret Manager
+// gRPC API, for testing the gcpsecrets backend without GCP credentials.
+//
+// It implements the secret and version operations vaultmux uses; IAM
+// methods return Unimplemented. Besides internal/gcpresource, the resource
+// name parser it shares with the gcpsecrets backend, the package imports
+// nothing from vaultmux, so it can be extracted as a standalone emulator
+// (see gcp-mock-secret-server.md).
+package gcpmock
+
+import (
+	"context"
+	"net"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server implements secretmanagerpb.SecretManagerServiceServer on top of a
+// Storage.
+type Server struct {
+	secretmanagerpb.UnimplementedSecretManagerServiceServer
+
+	storage *Storage
+}
+
+// NewServer creates a server with empty storage.
+func NewServer() *Server {
+	return &Server{storage: NewStorage()}
+}
+
+// Storage returns the server's store, for inspecting or seeding it directly.
+func (s *Server) Storage() *Storage {
+	return s.storage
+}
+
+// Listen serves s over plaintext gRPC on a TCP listener at addr, such as
+// "127.0.0.1:0" for a free port. It returns the address to dial (the
+// gcpsecrets "endpoint" option) and a function that stops the server.
+func (s *Server) Listen(addr string) (endpoint string, stop func(), err error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", nil, err
+	}
+	srv := grpc.NewServer()
+	secretmanagerpb.RegisterSecretManagerServiceServer(srv, s)
+	go func() { _ = srv.Serve(lis) }()
+	return lis.Addr().String(), srv.Stop, nil
+}
+
+// ListSecrets lists the secrets in a project, applying the request's filter
+// before paging.
+func (s *Server) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest) (*secretmanagerpb.ListSecretsResponse, error) {
+	secrets, next, err := s.storage.ListSecrets(req.GetParent(), req.GetFilter(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &secretmanagerpb.ListSecretsResponse{
+		Secrets:       secrets,
+		NextPageToken: next,
+	}, nil
+}
+
+// CreateSecret creates a secret without versions.
+func (s *Server) CreateSecret(ctx context.Context, req *secretmanagerpb.CreateSecretRequest) (*secretmanagerpb.Secret, error) {
+	return s.storage.CreateSecret(req.GetParent(), req.GetSecretId(), req.GetSecret())
+}
+
+// GetSecret returns a secret's metadata.
+func (s *Server) GetSecret(ctx context.Context, req *secretmanagerpb.GetSecretRequest) (*secretmanagerpb.Secret, error) {
+	return s.storage.GetSecret(req.GetName())
+}
+
+// UpdateSecret updates the labels, annotations or version aliases of a
+// secret, as selected by the update mask.
+func (s *Server) UpdateSecret(ctx context.Context, req *secretmanagerpb.UpdateSecretRequest) (*secretmanagerpb.Secret, error) {
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+	return s.storage.UpdateSecret(req.GetSecret(), req.GetUpdateMask().GetPaths())
+}
+
+// DeleteSecret deletes a secret and its versions, if the request's etag
+// (when set) still matches.
+func (s *Server) DeleteSecret(ctx context.Context, req *secretmanagerpb.DeleteSecretRequest) (*emptypb.Empty, error) {
+	if err := s.storage.DeleteSecret(req.GetName(), req.GetEtag()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// AddSecretVersion adds a version to a secret.
+func (s *Server) AddSecretVersion(ctx context.Context, req *secretmanagerpb.AddSecretVersionRequest) (*secretmanagerpb.SecretVersion, error) {
+	return s.storage.AddSecretVersion(req.GetParent(), req.GetPayload().GetData())
+}
+
+// GetSecretVersion returns a version's metadata.
+func (s *Server) GetSecretVersion(ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest) (*secretmanagerpb.SecretVersion, error) {
+	return s.storage.GetSecretVersion(req.GetName())
+}
+
+// AccessSecretVersion returns a version's payload.
+func (s *Server) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	return s.storage.AccessSecretVersion(req.GetName())
+}
+
+// ListSecretVersions lists the versions of a secret, newest first.
+func (s *Server) ListSecretVersions(ctx context.Context, req *secretmanagerpb.ListSecretVersionsRequest) (*secretmanagerpb.ListSecretVersionsResponse, error) {
+	versions, next, err := s.storage.ListSecretVersions(req.GetParent(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &secretmanagerpb.ListSecretVersionsResponse{
+		Versions:      versions,
+		NextPageToken: next,
+	}, nil
+}
+
+// EnableSecretVersion enables a disabled version.
+func (s *Server) EnableSecretVersion(ctx context.Context, req *secretmanagerpb.EnableSecretVersionRequest) (*secretmanagerpb.SecretVersion, error) {
+	return s.storage.SetVersionState(req.GetName(), secretmanagerpb.SecretVersion_ENABLED)
+}
+
+// DisableSecretVersion disables a version.
+func (s *Server) DisableSecretVersion(ctx context.Context, req *secretmanagerpb.DisableSecretVersionRequest) (*secretmanagerpb.SecretVersion, error) {
+	return s.storage.SetVersionState(req.GetName(), secretmanagerpb.SecretVersion_DISABLED)
+}
+
+// DestroySecretVersion destroys a version and its payload.
+func (s *Server) DestroySecretVersion(ctx context.Context, req *secretmanagerpb.DestroySecretVersionRequest) (*secretmanagerpb.SecretVersion, error) {
+	return s.storage.SetVersionState(req.GetName(), secretmanagerpb.SecretVersion_DESTROYED)
+}
@@ -0,0 +1,213 @@
+package gcpmock
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// newTestClient starts a server and returns an SDK client connected to it.
+func newTestClient(t testing.TB) (*secretmanager.Client, *Server) {
+	t.Helper()
+	srv := NewServer()
+	endpoint, stop, err := srv.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(stop)
+
+	client, err := secretmanager.NewClient(context.Background(),
+		option.WithEndpoint(endpoint),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client, srv
+}
+
+func TestServer_Client(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+
+	secret, err := client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   testParent,
+		SecretId: "api-key",
+		Secret:   &secretmanagerpb.Secret{Labels: map[string]string{"env": "prod"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+	if _, err := client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  secret.GetName(),
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte("k1")},
+	}); err != nil {
+		t.Fatalf("AddSecretVersion() error = %v", err)
+	}
+
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: secret.GetName() + "/versions/latest"})
+	if err != nil || string(resp.GetPayload().GetData()) != "k1" {
+		t.Errorf("AccessSecretVersion() = %v, %v; want k1", resp, err)
+	}
+	got, err := client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: secret.GetName()})
+	if err != nil || got.GetLabels()["env"] != "prod" {
+		t.Errorf("GetSecret() = %v, %v; want env=prod label", got, err)
+	}
+
+	it := client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{Parent: testParent})
+	var listed int
+	for {
+		_, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ListSecrets() error = %v", err)
+		}
+		listed++
+	}
+	if listed != 1 || srv.Storage().SecretCount() != 1 {
+		t.Errorf("listed %d secrets, want 1", listed)
+	}
+
+	if err := client.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{Name: secret.GetName()}); err != nil {
+		t.Fatalf("DeleteSecret() error = %v", err)
+	}
+	_, err = client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: secret.GetName()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetSecret() after delete error = %v, want NotFound", err)
+	}
+	_, err = client.GetIamPolicy(ctx, nil)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("GetIamPolicy() error = %v, want Unimplemented", err)
+	}
+}
+
+func TestServer_ListSecretsFilter(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	for id, env := range map[string]string{"api-prod": "prod", "db-prod": "prod", "api-dev": "dev", "unlabelled": ""} {
+		secret := &secretmanagerpb.Secret{}
+		if env != "" {
+			secret.Labels = map[string]string{"env": env}
+		}
+		if _, err := client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{Parent: testParent, SecretId: id, Secret: secret}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := func(filter string) ([]string, error) {
+		// Page size 1 checks the filter is applied before paging
+		it := client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{Parent: testParent, Filter: filter, PageSize: 1})
+		var ids []string
+		for {
+			secret, err := it.Next()
+			if err == iterator.Done {
+				return ids, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, secret.GetName()[len(testParent+"/secrets/"):])
+		}
+	}
+
+	for _, tt := range []struct {
+		filter string
+		want   []string
+	}{
+		{"labels.env=prod", []string{"api-prod", "db-prod"}},
+		{"name:api", []string{"api-dev", "api-prod"}},
+		{"labels.env=prod AND name:api", []string{"api-prod"}},
+		{"", []string{"api-dev", "api-prod", "db-prod", "unlabelled"}},
+	} {
+		got, err := list(tt.filter)
+		if err != nil {
+			t.Fatalf("ListSecrets(%q) error = %v", tt.filter, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ListSecrets(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+
+	if _, err := list("create_time>2020"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unsupported filter error = %v, want InvalidArgument", err)
+	}
+}
+
+// BenchmarkStorageParallel drives a mixed workload through an SDK client
+// against the in-process server from 1, 8 and 64 goroutines and reports
+// throughput: of every ten operations one creates a secret, one lists and
+// eight read the latest version. Run it with -race to check the server
+// under contention.
+func BenchmarkStorageParallel(b *testing.B) {
+	for _, concurrency := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			benchmarkParallel(b, concurrency)
+		})
+	}
+}
+
+func benchmarkParallel(b *testing.B, concurrency int) {
+	ctx := context.Background()
+	client, srv := newTestClient(b)
+
+	seeded := make([]string, 20)
+	for i := range seeded {
+		seeded[i] = mustCreate(b, srv.Storage(), fmt.Sprintf("seed-%02d", i), "value") + "/versions/latest"
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	b.ReportAllocs()
+	b.ResetTimer()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				op := next.Add(1) - 1
+				if op >= int64(b.N) {
+					return
+				}
+				var err error
+				switch op % 10 {
+				case 0:
+					_, err = client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+						Parent:   testParent,
+						SecretId: fmt.Sprintf("item-%d", op),
+						Secret:   &secretmanagerpb.Secret{},
+					})
+				case 1:
+					it := client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{Parent: testParent, PageSize: 100})
+					if _, err = it.Next(); err == iterator.Done {
+						err = nil
+					}
+				default:
+					_, err = client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: seeded[op%int64(len(seeded))]})
+				}
+				if err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	b.StopTimer()
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ops/s")
+}
@@ -0,0 +1,575 @@
+package gcpmock
+
+import (
+	"cmp"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/blackwell-systems/vaultmux/internal/gcpresource"
+)
+
+// defaultShards is the number of shards NewStorage splits secrets across.
+const defaultShards = 32
+
+// Storage is the mock's in-memory secret store. Secrets are sharded by a
+// hash of their resource name, each shard behind its own RWMutex, so
+// concurrent requests for different secrets rarely contend. A secret and
+// all of its versions live in one shard.
+type Storage struct {
+	shards []*shard
+}
+
+// shard holds the secrets whose names hash to it.
+type shard struct {
+	mu      sync.RWMutex
+	secrets map[string]*StoredSecret // Key: "projects/{project}/secrets/{secret-id}"
+}
+
+// StoredSecret is a secret with all of its versions.
+type StoredSecret struct {
+	Name        string // projects/{project}/secrets/{secret-id}
+	CreateTime  *timestamppb.Timestamp
+	Labels      map[string]string
+	Annotations map[string]string
+	Replication *secretmanagerpb.Replication
+	Topics      []string // Pub/Sub topic names notified of changes
+	Etag        string   // Changed by every metadata update, not by new versions
+
+	Versions    map[string]*StoredVersion // Key: "1", "2", ... (never "latest")
+	NextVersion int64                     // Number of the next version added
+	Aliases     map[string]int64          // Secret.VersionAliases, e.g. "prod" -> 2
+
+	// Cached "latest" resolution, kept current by add/enable/disable/destroy
+	Latest      *StoredVersion // Highest ENABLED version (nil if none)
+	LatestDirty bool           // Latest must be recomputed by a rescan
+}
+
+// StoredVersion is a single secret version.
+type StoredVersion struct {
+	Name        string // projects/{project}/secrets/{secret-id}/versions/{n}
+	CreateTime  *timestamppb.Timestamp
+	DestroyTime *timestamppb.Timestamp
+	State       secretmanagerpb.SecretVersion_State
+	Payload     []byte // Cleared when the version is destroyed
+}
+
+// NewStorage creates an empty store.
+func NewStorage() *Storage {
+	return newStorage(defaultShards)
+}
+
+// newStorage creates an empty store with n shards.
+func newStorage(n int) *Storage {
+	s := &Storage{shards: make([]*shard, n)}
+	for i := range s.shards {
+		s.shards[i] = &shard{secrets: make(map[string]*StoredSecret)}
+	}
+	return s
+}
+
+// shardFor returns the shard holding the secret named secretName.
+func (s *Storage) shardFor(secretName string) *shard {
+	h := fnv.New32a()
+	h.Write([]byte(secretName))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// CreateSecret creates a secret with no versions under parent.
+func (s *Storage) CreateSecret(parent, secretID string, secret *secretmanagerpb.Secret) (*secretmanagerpb.Secret, error) {
+	if _, _, err := gcpresource.ParseParent(parent); err != nil {
+		return nil, err
+	}
+	if secretID == "" || strings.Contains(secretID, "/") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid secret_id %q", secretID)
+	}
+
+	name := parent + "/secrets/" + secretID
+	sh := s.shardFor(name)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if _, ok := sh.secrets[name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "Secret [%s] already exists.", name)
+	}
+	stored := &StoredSecret{
+		Name:        name,
+		CreateTime:  timestamppb.Now(),
+		Labels:      maps.Clone(secret.GetLabels()),
+		Annotations: maps.Clone(secret.GetAnnotations()),
+		Replication: secret.GetReplication(),
+		Topics:      topicNames(secret.GetTopics()),
+		Etag:        newEtag(),
+		Versions:    make(map[string]*StoredVersion),
+		NextVersion: 1,
+		Aliases:     make(map[string]int64),
+	}
+	sh.secrets[name] = stored
+	return stored.proto(), nil
+}
+
+// GetSecret returns the metadata of a secret.
+func (s *Storage) GetSecret(name string) (*secretmanagerpb.Secret, error) {
+	sh := s.shardFor(name)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	stored, err := sh.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return stored.proto(), nil
+}
+
+// UpdateSecret replaces the fields of a secret named in paths and gives it
+// a new etag. Supported paths are "labels", "annotations", "topics" and
+// "version_aliases". If secret has an etag, it must match the stored one.
+func (s *Storage) UpdateSecret(secret *secretmanagerpb.Secret, paths []string) (*secretmanagerpb.Secret, error) {
+	sh := s.shardFor(secret.GetName())
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	stored, err := sh.get(secret.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := stored.checkEtag(secret.GetEtag()); err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		switch path {
+		case "labels":
+			stored.Labels = maps.Clone(secret.GetLabels())
+		case "annotations":
+			stored.Annotations = maps.Clone(secret.GetAnnotations())
+		case "topics":
+			stored.Topics = topicNames(secret.GetTopics())
+		case "version_aliases":
+			for _, version := range secret.GetVersionAliases() {
+				if _, ok := stored.Versions[strconv.FormatInt(version, 10)]; !ok {
+					return nil, status.Errorf(codes.InvalidArgument, "alias target version %d of %s does not exist", version, stored.Name)
+				}
+			}
+			stored.Aliases = maps.Clone(secret.GetVersionAliases())
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported update_mask path %q", path)
+		}
+	}
+	stored.Etag = newEtag()
+	return stored.proto(), nil
+}
+
+// DeleteSecret deletes a secret and all of its versions. A non-empty etag
+// must match the secret's current one, or the delete fails with
+// codes.Aborted.
+func (s *Storage) DeleteSecret(name, etag string) error {
+	sh := s.shardFor(name)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	stored, err := sh.get(name)
+	if err != nil {
+		return err
+	}
+	if err := stored.checkEtag(etag); err != nil {
+		return err
+	}
+	delete(sh.secrets, name)
+	return nil
+}
+
+// ListSecrets returns a page of the secrets under parent that match filter,
+// ordered by name. pageToken is the offset of the page, as returned for the
+// previous one; the returned token is empty on the last page. See
+// parseFilter for the supported filter expressions.
+func (s *Storage) ListSecrets(parent, filter string, pageSize int32, pageToken string) ([]*secretmanagerpb.Secret, string, error) {
+	if _, _, err := gcpresource.ParseParent(parent); err != nil {
+		return nil, "", err
+	}
+	match, err := parseFilter(filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var secrets []*secretmanagerpb.Secret
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for name, stored := range sh.secrets {
+			if strings.HasPrefix(name, parent+"/secrets/") && match(stored) {
+				secrets = append(secrets, stored.proto())
+			}
+		}
+		sh.mu.RUnlock()
+	}
+	slices.SortFunc(secrets, func(a, b *secretmanagerpb.Secret) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return page(secrets, pageSize, pageToken)
+}
+
+// AddSecretVersion adds an enabled version holding payload to a secret.
+func (s *Storage) AddSecretVersion(secretName string, payload []byte) (*secretmanagerpb.SecretVersion, error) {
+	sh := s.shardFor(secretName)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	stored, err := sh.get(secretName)
+	if err != nil {
+		return nil, err
+	}
+	id := strconv.FormatInt(stored.NextVersion, 10)
+	stored.NextVersion++
+	version := &StoredVersion{
+		Name:       secretName + "/versions/" + id,
+		CreateTime: timestamppb.Now(),
+		State:      secretmanagerpb.SecretVersion_ENABLED,
+		Payload:    slices.Clone(payload),
+	}
+	stored.Versions[id] = version
+	stored.Latest, stored.LatestDirty = version, false // Always the highest
+	return version.proto(), nil
+}
+
+// GetSecretVersion returns the metadata of a version. The version ID may
+// be a number, an alias or "latest".
+func (s *Storage) GetSecretVersion(name string) (*secretmanagerpb.SecretVersion, error) {
+	secret, id, err := gcpresource.ParseVersionName(name)
+	if err != nil {
+		return nil, err
+	}
+	secretName := secret.String()
+	sh := s.shardFor(secretName)
+	sh.refreshLatest(secretName, id)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	version, err := sh.version(secretName, id)
+	if err != nil {
+		return nil, err
+	}
+	return version.proto(), nil
+}
+
+// AccessSecretVersion returns the payload of an enabled version.
+func (s *Storage) AccessSecretVersion(name string) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	secret, id, err := gcpresource.ParseVersionName(name)
+	if err != nil {
+		return nil, err
+	}
+	secretName := secret.String()
+	sh := s.shardFor(secretName)
+	sh.refreshLatest(secretName, id)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	version, err := sh.version(secretName, id)
+	if err != nil {
+		return nil, err
+	}
+	if version.State != secretmanagerpb.SecretVersion_ENABLED {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is in %s state.", version.Name, version.State)
+	}
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Name:    version.Name,
+		Payload: &secretmanagerpb.SecretPayload{Data: slices.Clone(version.Payload)},
+	}, nil
+}
+
+// ListSecretVersions returns a page of the versions of a secret, newest
+// first. Versions are ordered by number before paging, so page tokens
+// select the same versions on every call.
+func (s *Storage) ListSecretVersions(secretName string, pageSize int32, pageToken string) ([]*secretmanagerpb.SecretVersion, string, error) {
+	sh := s.shardFor(secretName)
+	sh.mu.RLock()
+	stored, err := sh.get(secretName)
+	if err != nil {
+		sh.mu.RUnlock()
+		return nil, "", err
+	}
+	versions := slices.Collect(maps.Values(stored.Versions))
+	slices.SortFunc(versions, func(a, b *StoredVersion) int {
+		return cmp.Compare(versionNumber(b), versionNumber(a))
+	})
+	sh.mu.RUnlock()
+
+	paged, next, err := page(versions, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	out := make([]*secretmanagerpb.SecretVersion, len(paged))
+	for i, version := range paged {
+		out[i] = version.proto()
+	}
+	return out, next, nil
+}
+
+// SetVersionState enables, disables or destroys a version. Destroying a
+// version discards its payload; a destroyed version can't change state.
+func (s *Storage) SetVersionState(name string, state secretmanagerpb.SecretVersion_State) (*secretmanagerpb.SecretVersion, error) {
+	secret, id, err := gcpresource.ParseVersionName(name)
+	if err != nil {
+		return nil, err
+	}
+	secretName := secret.String()
+	sh := s.shardFor(secretName)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	stored, err := sh.get(secretName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := sh.version(secretName, id)
+	if err != nil {
+		return nil, err
+	}
+	if version.State == secretmanagerpb.SecretVersion_DESTROYED {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is in DESTROYED state.", version.Name)
+	}
+	version.State = state
+	if state == secretmanagerpb.SecretVersion_DESTROYED {
+		version.Payload = nil
+		version.DestroyTime = timestamppb.Now()
+	}
+
+	switch {
+	case stored.LatestDirty:
+		// Rebuilt on the next lookup
+	case state == secretmanagerpb.SecretVersion_ENABLED:
+		if stored.Latest == nil || versionNumber(version) > versionNumber(stored.Latest) {
+			stored.Latest = version
+		}
+	case version == stored.Latest:
+		stored.Latest, stored.LatestDirty = nil, true
+	}
+	return version.proto(), nil
+}
+
+// SecretCount returns the number of secrets in the store.
+func (s *Storage) SecretCount() int {
+	n := 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		n += len(sh.secrets)
+		sh.mu.RUnlock()
+	}
+	return n
+}
+
+// refreshLatest rescans the versions of the secret named secretName if id
+// is "latest" and its cached latest version is dirty. It takes sh.mu
+// itself, so it must not be held.
+func (sh *shard) refreshLatest(secretName, id string) {
+	if id != "latest" {
+		return
+	}
+	sh.mu.RLock()
+	stored, ok := sh.secrets[secretName]
+	dirty := ok && stored.LatestDirty
+	sh.mu.RUnlock()
+	if !dirty {
+		return
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if stored, ok := sh.secrets[secretName]; ok && stored.LatestDirty {
+		stored.Latest, stored.LatestDirty = stored.scanLatest(), false
+	}
+}
+
+// get returns the secret named name. sh.mu must be held.
+func (sh *shard) get(name string) (*StoredSecret, error) {
+	if _, err := gcpresource.ParseSecretName(name); err != nil {
+		return nil, err
+	}
+	stored, ok := sh.secrets[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Secret [%s] not found or has no versions.", name)
+	}
+	return stored, nil
+}
+
+// version resolves id, which may be a number, an alias or "latest", to a
+// version of the secret named secretName. sh.mu must be held.
+func (sh *shard) version(secretName, id string) (*StoredVersion, error) {
+	stored, err := sh.get(secretName)
+	if err != nil {
+		return nil, err
+	}
+	if number, ok := stored.Aliases[id]; ok {
+		id = strconv.FormatInt(number, 10)
+	} else if id == "latest" {
+		return stored.latest()
+	}
+	version, ok := stored.Versions[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Secret Version [%s/versions/%s] not found.", secretName, id)
+	}
+	return version, nil
+}
+
+// latest returns the highest-numbered enabled version, from the cache
+// unless it is dirty. A dirty cache is left for refreshLatest to rebuild,
+// since this runs under the read lock.
+func (s *StoredSecret) latest() (*StoredVersion, error) {
+	latest := s.Latest
+	if s.LatestDirty {
+		latest = s.scanLatest()
+	}
+	if latest == nil {
+		return nil, status.Errorf(codes.NotFound, "Secret [%s] has no enabled versions.", s.Name)
+	}
+	return latest, nil
+}
+
+// scanLatest finds the highest-numbered enabled version by checking every
+// version.
+func (s *StoredSecret) scanLatest() *StoredVersion {
+	var latest *StoredVersion
+	for _, version := range s.Versions {
+		if version.State == secretmanagerpb.SecretVersion_ENABLED && (latest == nil || versionNumber(version) > versionNumber(latest)) {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// versionNumber returns the number at the end of a version's name.
+func versionNumber(v *StoredVersion) int64 {
+	n, _ := strconv.ParseInt(v.Name[strings.LastIndex(v.Name, "/")+1:], 10, 64)
+	return n
+}
+
+// checkEtag returns codes.Aborted if etag is set and differs from the
+// secret's.
+func (s *StoredSecret) checkEtag(etag string) error {
+	if etag != "" && etag != s.Etag {
+		return status.Errorf(codes.Aborted, "etag %s does not match the current etag of %s", etag, s.Name)
+	}
+	return nil
+}
+
+// etagCounter makes every etag unique, also across a delete and re-create.
+var etagCounter atomic.Int64
+
+// newEtag returns a fresh etag, quoted as GCP returns them.
+func newEtag() string {
+	return strconv.Quote(strconv.FormatInt(etagCounter.Add(1), 10))
+}
+
+// proto returns the secret's metadata as an API message.
+func (s *StoredSecret) proto() *secretmanagerpb.Secret {
+	return &secretmanagerpb.Secret{
+		Name:           s.Name,
+		CreateTime:     s.CreateTime,
+		Labels:         maps.Clone(s.Labels),
+		Annotations:    maps.Clone(s.Annotations),
+		Replication:    s.Replication,
+		VersionAliases: maps.Clone(s.Aliases),
+		Topics:         topicProtos(s.Topics),
+		Etag:           s.Etag,
+	}
+}
+
+// topicNames returns the names of topics.
+func topicNames(topics []*secretmanagerpb.Topic) []string {
+	var names []string
+	for _, topic := range topics {
+		names = append(names, topic.GetName())
+	}
+	return names
+}
+
+// topicProtos returns topics named names as API messages.
+func topicProtos(names []string) []*secretmanagerpb.Topic {
+	var topics []*secretmanagerpb.Topic
+	for _, name := range names {
+		topics = append(topics, &secretmanagerpb.Topic{Name: name})
+	}
+	return topics
+}
+
+// proto returns the version's metadata as an API message.
+func (v *StoredVersion) proto() *secretmanagerpb.SecretVersion {
+	return &secretmanagerpb.SecretVersion{
+		Name:        v.Name,
+		CreateTime:  v.CreateTime,
+		DestroyTime: v.DestroyTime,
+		State:       v.State,
+	}
+}
+
+// parseFilter parses a ListSecrets filter into a predicate. It supports a
+// subset of the API's syntax: "labels.<key>=<value>" and "name:<substring>"
+// terms, combined with spaces or AND. The substring is matched against the
+// secret ID. An empty filter matches every secret.
+func parseFilter(filter string) (func(*StoredSecret) bool, error) {
+	var terms []func(*StoredSecret) bool
+	for _, term := range strings.Fields(filter) {
+		if term == "AND" {
+			continue
+		}
+		if label, ok := strings.CutPrefix(term, "labels."); ok {
+			key, value, ok := strings.Cut(label, "=")
+			if !ok || key == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid filter term %q", term)
+			}
+			terms = append(terms, func(s *StoredSecret) bool {
+				got, ok := s.Labels[key]
+				return ok && got == value
+			})
+			continue
+		}
+		if sub, ok := strings.CutPrefix(term, "name:"); ok && sub != "" {
+			terms = append(terms, func(s *StoredSecret) bool {
+				_, id, _ := strings.Cut(s.Name, "/secrets/")
+				return strings.Contains(id, sub)
+			})
+			continue
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported filter term %q", term)
+	}
+
+	return func(s *StoredSecret) bool {
+		for _, match := range terms {
+			if !match(s) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// page returns the page of items starting at the offset in pageToken.
+func page[T any](items []T, pageSize int32, pageToken string) ([]T, string, error) {
+	if pageSize < 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	start := 0
+	if pageToken != "" {
+		n, err := strconv.Atoi(pageToken)
+		if err != nil || n < 0 || n > len(items) {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page_token %q", pageToken)
+		}
+		start = n
+	}
+	if pageSize == 0 {
+		pageSize = 25000 // The API's maximum
+	}
+	end := min(start+int(pageSize), len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[start:end], next, nil
+}
@@ -0,0 +1,351 @@
+package gcpmock
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testParent = "projects/test-project"
+
+// mustCreate creates a secret holding value in s.
+func mustCreate(t testing.TB, s *Storage, id, value string) string {
+	t.Helper()
+	secret, err := s.CreateSecret(testParent, id, &secretmanagerpb.Secret{})
+	if err != nil {
+		t.Fatalf("CreateSecret(%s) error = %v", id, err)
+	}
+	if _, err := s.AddSecretVersion(secret.GetName(), []byte(value)); err != nil {
+		t.Fatalf("AddSecretVersion(%s) error = %v", id, err)
+	}
+	return secret.GetName()
+}
+
+func TestStorage_Versions(t *testing.T) {
+	s := NewStorage()
+	name := mustCreate(t, s, "db", "v1")
+	if _, err := s.AddSecretVersion(name, []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+
+	access := func(id string) (string, codes.Code) {
+		resp, err := s.AccessSecretVersion(name + "/versions/" + id)
+		return string(resp.GetPayload().GetData()), status.Code(err)
+	}
+	if got, code := access("latest"); got != "v2" || code != codes.OK {
+		t.Errorf("latest = %q, %v; want v2", got, code)
+	}
+	if got, _ := access("1"); got != "v1" {
+		t.Errorf("version 1 = %q, want v1", got)
+	}
+
+	if _, err := s.SetVersionState(name+"/versions/2", secretmanagerpb.SecretVersion_DISABLED); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := access("latest"); got != "v1" {
+		t.Errorf("latest with version 2 disabled = %q, want v1", got)
+	}
+	if _, code := access("2"); code != codes.FailedPrecondition {
+		t.Errorf("access to disabled version: %v, want FailedPrecondition", code)
+	}
+
+	if _, err := s.UpdateSecret(&secretmanagerpb.Secret{Name: name, VersionAliases: map[string]int64{"stable": 1}}, []string{"version_aliases"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := access("stable"); got != "v1" {
+		t.Errorf("alias stable = %q, want v1", got)
+	}
+
+	if _, err := s.SetVersionState(name+"/versions/1", secretmanagerpb.SecretVersion_DESTROYED); err != nil {
+		t.Fatal(err)
+	}
+	if _, code := access("latest"); code != codes.NotFound {
+		t.Errorf("latest with no enabled versions: %v, want NotFound", code)
+	}
+	if _, err := s.SetVersionState(name+"/versions/1", secretmanagerpb.SecretVersion_ENABLED); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("enabling a destroyed version: %v, want FailedPrecondition", err)
+	}
+
+	if _, err := s.CreateSecret(testParent, "db", &secretmanagerpb.Secret{}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("duplicate CreateSecret() error = %v, want AlreadyExists", err)
+	}
+	if err := s.DeleteSecret(name, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetSecret(name); status.Code(err) != codes.NotFound {
+		t.Errorf("GetSecret() after delete error = %v, want NotFound", err)
+	}
+}
+
+func TestStorage_Etag(t *testing.T) {
+	s := NewStorage()
+	name := mustCreate(t, s, "db", "v1")
+	etag := func() string {
+		t.Helper()
+		secret, err := s.GetSecret(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return secret.GetEtag()
+	}
+
+	created := etag()
+	if created == "" {
+		t.Fatal("GetSecret() returned no etag")
+	}
+	if _, err := s.AddSecretVersion(name, []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if etag() != created {
+		t.Error("etag changed when a version was added")
+	}
+
+	if _, err := s.UpdateSecret(&secretmanagerpb.Secret{Name: name, Labels: map[string]string{"env": "prod"}}, []string{"labels"}); err != nil {
+		t.Fatal(err)
+	}
+	current := etag()
+	if current == created {
+		t.Error("etag unchanged after a label update")
+	}
+	if _, err := s.UpdateSecret(&secretmanagerpb.Secret{Name: name, Etag: created}, []string{"labels"}); status.Code(err) != codes.Aborted {
+		t.Errorf("UpdateSecret(stale etag) error = %v, want Aborted", err)
+	}
+
+	if err := s.DeleteSecret(name, created); status.Code(err) != codes.Aborted {
+		t.Errorf("DeleteSecret(stale etag) error = %v, want Aborted", err)
+	}
+	if err := s.DeleteSecret(name, current); err != nil {
+		t.Errorf("DeleteSecret(current etag) error = %v", err)
+	}
+}
+
+func TestStorage_Topics(t *testing.T) {
+	s := NewStorage()
+	topic := func(name string) *secretmanagerpb.Topic { return &secretmanagerpb.Topic{Name: name} }
+	created, err := s.CreateSecret(testParent, "db", &secretmanagerpb.Secret{
+		Topics: []*secretmanagerpb.Topic{topic("projects/p/topics/a"), topic("projects/p/topics/b")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetSecret(created.GetName()); len(got.GetTopics()) != 2 || got.GetTopics()[1].GetName() != "projects/p/topics/b" {
+		t.Errorf("GetSecret() topics = %v, want a and b", got.GetTopics())
+	}
+
+	updated, err := s.UpdateSecret(&secretmanagerpb.Secret{
+		Name:   created.GetName(),
+		Topics: []*secretmanagerpb.Topic{topic("projects/p/topics/c")},
+	}, []string{"topics"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.GetTopics()) != 1 || updated.GetTopics()[0].GetName() != "projects/p/topics/c" {
+		t.Errorf("UpdateSecret() topics = %v, want c", updated.GetTopics())
+	}
+}
+
+func TestStorage_LatestCache(t *testing.T) {
+	s := NewStorage()
+	name := mustCreate(t, s, "db", "v1")
+	for _, value := range []string{"v2", "v3"} {
+		if _, err := s.AddSecretVersion(name, []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stored := s.shardFor(name).secrets[name]
+	latest := func() string {
+		t.Helper()
+		resp, err := s.AccessSecretVersion(name + "/versions/latest")
+		if err != nil {
+			t.Fatalf("AccessSecretVersion(latest) error = %v", err)
+		}
+		return string(resp.GetPayload().GetData())
+	}
+
+	if _, err := s.SetVersionState(name+"/versions/3", secretmanagerpb.SecretVersion_DISABLED); err != nil {
+		t.Fatal(err)
+	}
+	if !stored.LatestDirty {
+		t.Error("disabling the latest version did not mark the cache dirty")
+	}
+	if got := latest(); got != "v2" || stored.LatestDirty {
+		t.Errorf("latest = %q (dirty %v), want v2 with the cache rebuilt", got, stored.LatestDirty)
+	}
+
+	if _, err := s.SetVersionState(name+"/versions/1", secretmanagerpb.SecretVersion_DISABLED); err != nil {
+		t.Fatal(err)
+	}
+	if stored.LatestDirty {
+		t.Error("disabling an older version marked the cache dirty")
+	}
+	if _, err := s.SetVersionState(name+"/versions/3", secretmanagerpb.SecretVersion_ENABLED); err != nil {
+		t.Fatal(err)
+	}
+	if got := latest(); got != "v3" {
+		t.Errorf("latest after re-enabling version 3 = %q, want v3", got)
+	}
+}
+
+func TestStorage_ListSecretsPaging(t *testing.T) {
+	s := NewStorage()
+	for i := range 7 {
+		mustCreate(t, s, fmt.Sprintf("secret-%d", i), "v")
+	}
+	if _, err := s.CreateSecret("projects/other-project", "elsewhere", &secretmanagerpb.Secret{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	token := ""
+	for {
+		secrets, next, err := s.ListSecrets(testParent, "", 3, token)
+		if err != nil {
+			t.Fatalf("ListSecrets() error = %v", err)
+		}
+		for _, secret := range secrets {
+			names = append(names, secret.GetName())
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if len(names) != 7 || names[0] != testParent+"/secrets/secret-0" || names[6] != testParent+"/secrets/secret-6" {
+		t.Errorf("ListSecrets() pages = %v, want secret-0 through secret-6 in order", names)
+	}
+}
+
+func TestStorage_Concurrent(t *testing.T) {
+	s := NewStorage()
+	var wg sync.WaitGroup
+	for w := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				name := mustCreate(t, s, fmt.Sprintf("w%d-s%d", w, i), "v1")
+				if _, err := s.AddSecretVersion(name, []byte("v2")); err != nil {
+					t.Error(err)
+				}
+				if resp, err := s.AccessSecretVersion(name + "/versions/latest"); err != nil || string(resp.GetPayload().GetData()) != "v2" {
+					t.Errorf("AccessSecretVersion(%s) = %v, %v", name, resp, err)
+				}
+				if _, _, err := s.ListSecrets(testParent, "", 10, ""); err != nil {
+					t.Error(err)
+				}
+				if i%2 == 0 {
+					if err := s.DeleteSecret(name, ""); err != nil {
+						t.Error(err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := s.SecretCount(); n != 16*25 {
+		t.Errorf("SecretCount() = %d, want %d", n, 16*25)
+	}
+}
+
+// BenchmarkAccessLatest reads the latest version of a secret with few and
+// with many versions; both should take the same time per access.
+func BenchmarkAccessLatest(b *testing.B) {
+	for _, versions := range []int{10, 10000} {
+		b.Run(fmt.Sprintf("versions=%d", versions), func(b *testing.B) {
+			s := NewStorage()
+			name := mustCreate(b, s, "db", "v")
+			for range versions - 1 {
+				if _, err := s.AddSecretVersion(name, []byte("v")); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ResetTimer()
+			for range b.N {
+				if _, err := s.AccessSecretVersion(name + "/versions/latest"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkStorageRead reads the latest version of random secrets from
+// parallel goroutines, with a single shard (one lock for every secret) and
+// with the default sharding.
+func BenchmarkStorageRead(b *testing.B) {
+	for _, shards := range []int{1, defaultShards} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			s := newStorage(shards)
+			names := make([]string, 1024)
+			for i := range names {
+				names[i] = mustCreate(b, s, fmt.Sprintf("secret-%d", i), "value") + "/versions/latest"
+			}
+			var writes sync.WaitGroup
+			stop := make(chan struct{})
+			writes.Add(1)
+			go func() { // A steady trickle of writes, as in a mixed workload
+				defer writes.Done()
+				for i := 0; ; i++ {
+					select {
+					case <-stop:
+						return
+					default:
+						_, _ = s.AddSecretVersion(testParent+fmt.Sprintf("/secrets/secret-%d", i%len(names)), []byte("value"))
+					}
+				}
+			}()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if _, err := s.AccessSecretVersion(names[i%len(names)]); err != nil {
+						b.Error(err)
+					}
+					i += 7
+				}
+			})
+			b.StopTimer()
+			close(stop)
+			writes.Wait()
+		})
+	}
+}
+
+func TestStorage_ListSecretVersionsPaging(t *testing.T) {
+	s := NewStorage()
+	name := mustCreate(t, s, "db", "v1")
+	for i := 2; i <= 10; i++ {
+		if _, err := s.AddSecretVersion(name, fmt.Appendf(nil, "v%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	token, pages := "", 0
+	for {
+		versions, next, err := s.ListSecretVersions(name, 3, token)
+		if err != nil {
+			t.Fatalf("ListSecretVersions() error = %v", err)
+		}
+		pages++
+		for _, version := range versions {
+			got = append(got, version.GetName()[len(name+"/versions/"):])
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	want := []string{"10", "9", "8", "7", "6", "5", "4", "3", "2", "1"}
+	if pages != 4 || !slices.Equal(got, want) {
+		t.Errorf("ListSecretVersions() = %v over %d pages, want %v over 4", got, pages, want)
+	}
+}
@@ -0,0 +1,79 @@
+// Package gcpresource parses GCP Secret Manager resource names. It is shared
+// by the gcpsecrets backend and the gcpmock server, so both accept exactly
+// the same names.
+package gcpresource
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SecretName is a parsed secret resource name.
+type SecretName struct {
+	Project  string
+	Location string // Empty for a global secret
+	Secret   string
+}
+
+// Parent returns the name of the project or location holding the secret:
+// projects/{project} or projects/{project}/locations/{location}.
+func (n SecretName) Parent() string {
+	if n.Location != "" {
+		return "projects/" + n.Project + "/locations/" + n.Location
+	}
+	return "projects/" + n.Project
+}
+
+// String returns the full resource name.
+func (n SecretName) String() string {
+	return n.Parent() + "/secrets/" + n.Secret
+}
+
+// ParseParent splits a parent name of the form projects/{project}, or
+// projects/{project}/locations/{location} for regional secrets. Malformed
+// names return a gRPC InvalidArgument error.
+func ParseParent(parent string) (project, location string, err error) {
+	parts := strings.Split(parent, "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "projects" && parts[1] != "":
+		return parts[1], "", nil
+	case len(parts) == 4 && parts[0] == "projects" && parts[1] != "" && parts[2] == "locations" && parts[3] != "":
+		return parts[1], parts[3], nil
+	}
+	return "", "", status.Errorf(codes.InvalidArgument, "malformed parent %q: want projects/{project} or projects/{project}/locations/{location}", parent)
+}
+
+// ParseSecretName parses a secret resource name of the form
+// projects/{project}/secrets/{secret}, or
+// projects/{project}/locations/{location}/secrets/{secret} for a regional
+// secret. Malformed names return a gRPC InvalidArgument error, so servers
+// implementing the Secret Manager API can return it to clients unchanged.
+func ParseSecretName(name string) (SecretName, error) {
+	if i := strings.LastIndex(name, "/secrets/"); i >= 0 {
+		parent, secret := name[:i], name[i+len("/secrets/"):]
+		if project, location, err := ParseParent(parent); err == nil && secret != "" && !strings.Contains(secret, "/") {
+			return SecretName{Project: project, Location: location, Secret: secret}, nil
+		}
+	}
+	return SecretName{}, status.Errorf(codes.InvalidArgument, "malformed secret name %q: want projects/{project}/secrets/{secret}", name)
+}
+
+// ParseVersionName parses a secret version resource name of the form
+// projects/{project}/secrets/{secret}/versions/{version}, where version is a
+// number or an alias such as "latest". Regional secret versions are accepted
+// as for ParseSecretName. Malformed names return a gRPC InvalidArgument
+// error.
+func ParseVersionName(name string) (secret SecretName, version string, err error) {
+	i := strings.LastIndex(name, "/versions/")
+	if i >= 0 {
+		version = name[i+len("/versions/"):]
+		if version != "" && !strings.Contains(version, "/") {
+			if secret, err := ParseSecretName(name[:i]); err == nil {
+				return secret, version, nil
+			}
+		}
+	}
+	return SecretName{}, "", status.Errorf(codes.InvalidArgument, "malformed version name %q: want projects/{project}/secrets/{secret}/versions/{version}", name)
+}
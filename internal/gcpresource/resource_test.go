@@ -0,0 +1,66 @@
+package gcpresource
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSecretName_RoundTrip(t *testing.T) {
+	for _, name := range []string{
+		"projects/my-proj/secrets/api-key",
+		"projects/my-proj/locations/europe-west3/secrets/api-key",
+		"projects/secrets/secrets/secrets",
+	} {
+		n, err := ParseSecretName(name)
+		if err != nil {
+			t.Fatalf("ParseSecretName(%q) error = %v", name, err)
+		}
+		if n.String() != name {
+			t.Errorf("ParseSecretName(%q).String() = %q", name, n.String())
+		}
+	}
+}
+
+func TestParseVersionName_Location(t *testing.T) {
+	n, version, err := ParseVersionName("projects/p/locations/us-east1/secrets/db/versions/latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SecretName{Project: "p", Location: "us-east1", Secret: "db"}
+	if n != want || version != "latest" {
+		t.Errorf("ParseVersionName() = %+v, %q; want %+v, latest", n, version, want)
+	}
+}
+
+func TestParseParent(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantProject  string
+		wantLocation string
+		wantErr      bool
+	}{
+		{input: "projects/p", wantProject: "p"},
+		{input: "projects/p/locations/us-east1", wantProject: "p", wantLocation: "us-east1"},
+		{input: "projects/", wantErr: true},
+		{input: "projects/p/locations/", wantErr: true},
+		{input: "projects/p/secrets/s", wantErr: true},
+		{input: "folders/p", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			project, location, err := ParseParent(tt.input)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("ParseParent(%q) error = %v, want InvalidArgument", tt.input, err)
+				}
+				return
+			}
+			if err != nil || project != tt.wantProject || location != tt.wantLocation {
+				t.Errorf("ParseParent(%q) = %q, %q, %v; want %q, %q", tt.input, project, location, err, tt.wantProject, tt.wantLocation)
+			}
+		})
+	}
+}
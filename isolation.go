@@ -0,0 +1,60 @@
+package vaultmux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PrefixedBackend is implemented by backends that namespace stored item names
+// with a configured prefix (AWS Secrets Manager, GCP Secret Manager, Azure Key Vault).
+type PrefixedBackend interface {
+	// Prefix returns the prefix prepended to item names when stored.
+	Prefix() string
+}
+
+// PrefixIsolationError reports items visible to a backend that fall outside
+// the expected prefix.
+type PrefixIsolationError struct {
+	Prefix string   // Expected prefix
+	Names  []string // Offending stored names
+}
+
+// Error returns the error message.
+func (e *PrefixIsolationError) Error() string {
+	return fmt.Sprintf("prefix isolation violated: %d item(s) outside %q: %s",
+		len(e.Names), e.Prefix, strings.Join(e.Names, ", "))
+}
+
+// VerifyPrefixIsolation lists items and fails if any stored name doesn't start
+// with expectedPrefix, which indicates the backend can see secrets outside its
+// namespace (e.g. two environments configured with overlapping prefixes).
+// It is intended as a startup guard.
+//
+// For backends implementing PrefixedBackend, the stored name is the backend's
+// prefix followed by the listed item name; otherwise the listed name is used.
+// A *PrefixIsolationError lists the offending names.
+func VerifyPrefixIsolation(ctx context.Context, b Backend, session Session, expectedPrefix string) error {
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	var backendPrefix string
+	if pb, ok := b.(PrefixedBackend); ok {
+		backendPrefix = pb.Prefix()
+	}
+
+	var leaked []string
+	for _, item := range items {
+		stored := backendPrefix + item.Name
+		if !strings.HasPrefix(stored, expectedPrefix) {
+			leaked = append(leaked, stored)
+		}
+	}
+
+	if len(leaked) > 0 {
+		return &PrefixIsolationError{Prefix: expectedPrefix, Names: leaked}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestVerifyPrefixIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		items      []string
+		wantLeaked []string
+	}{
+		{
+			name:  "all in prefix",
+			items: []string{"prod/db", "prod/api"},
+		},
+		{
+			name:       "leak detected",
+			items:      []string{"prod/db", "staging/db", "other"},
+			wantLeaked: []string{"other", "staging/db"},
+		},
+		{
+			name:  "empty backend",
+			items: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := mock.New()
+			for _, name := range tt.items {
+				backend.SetItem(name, "value")
+			}
+			session, _ := backend.Authenticate(ctx)
+
+			err := vaultmux.VerifyPrefixIsolation(ctx, backend, session, "prod/")
+			if tt.wantLeaked == nil {
+				if err != nil {
+					t.Fatalf("VerifyPrefixIsolation() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var isoErr *vaultmux.PrefixIsolationError
+			if !errors.As(err, &isoErr) {
+				t.Fatalf("VerifyPrefixIsolation() error = %v, want *PrefixIsolationError", err)
+			}
+			sort.Strings(isoErr.Names)
+			if len(isoErr.Names) != len(tt.wantLeaked) {
+				t.Fatalf("Names = %v, want %v", isoErr.Names, tt.wantLeaked)
+			}
+			for i := range tt.wantLeaked {
+				if isoErr.Names[i] != tt.wantLeaked[i] {
+					t.Errorf("Names = %v, want %v", isoErr.Names, tt.wantLeaked)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyPrefixIsolation_ListError(t *testing.T) {
+	ctx := context.Background()
+	backend := &failingLister{Backend: mock.New()}
+	session, _ := backend.Authenticate(ctx)
+
+	err := vaultmux.VerifyPrefixIsolation(ctx, backend, session, "prod/")
+	if !errors.Is(err, vaultmux.ErrPermissionDenied) {
+		t.Errorf("VerifyPrefixIsolation() error = %v, want ErrPermissionDenied", err)
+	}
+}
+
+// failingLister wraps the mock backend with a ListItems that always fails.
+type failingLister struct {
+	*mock.Backend
+}
+
+func (f *failingLister) ListItems(ctx context.Context, _ vaultmux.Session) ([]*vaultmux.Item, error) {
+	return nil, vaultmux.ErrPermissionDenied
+}
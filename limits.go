@@ -0,0 +1,38 @@
+package vaultmux
+
+// Limits describes a backend's native constraints on secrets and names.
+type Limits struct {
+	// MaxSecretBytes is the largest secret value the backend stores.
+	MaxSecretBytes int
+
+	// MaxNameLength is the longest item name, in bytes, the backend accepts.
+	// For backends with a prefix option it includes the prefix.
+	MaxNameLength int
+
+	// AllowedNameCharset lists the characters allowed in item names, in
+	// regular expression character class syntax without the brackets, e.g.
+	// "A-Za-z0-9_-". Empty means any name accepted by ValidateItemName.
+	AllowedNameCharset string
+}
+
+// DefaultLimits are the limits reported for backends without a native limit
+// of their own. MaxNameLength matches ValidateItemName.
+var DefaultLimits = Limits{
+	MaxSecretBytes: 1 << 20,
+	MaxNameLength:  256,
+}
+
+// LimitsReporter is implemented by backends that report their native
+// secret-size and name limits.
+type LimitsReporter interface {
+	Limits() Limits
+}
+
+// BackendLimits returns the limits reported by b, or DefaultLimits if b
+// doesn't implement LimitsReporter.
+func BackendLimits(b Backend) Limits {
+	if r, ok := b.(LimitsReporter); ok {
+		return r.Limits()
+	}
+	return DefaultLimits
+}
@@ -0,0 +1,85 @@
+package vaultmux_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	_ "github.com/blackwell-systems/vaultmux/backends/awssecrets"
+	_ "github.com/blackwell-systems/vaultmux/backends/azurekeyvault"
+	_ "github.com/blackwell-systems/vaultmux/backends/bitwarden"
+	_ "github.com/blackwell-systems/vaultmux/backends/gcpsecrets"
+	_ "github.com/blackwell-systems/vaultmux/backends/onepassword"
+	_ "github.com/blackwell-systems/vaultmux/backends/pass"
+	"github.com/blackwell-systems/vaultmux/backends/wincred"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+// newLimitsBackend constructs a backend of type t without contacting it.
+func newLimitsBackend(t *testing.T, bt vaultmux.BackendType) vaultmux.Backend {
+	t.Helper()
+	if bt == vaultmux.BackendWindowsCredentialManager {
+		return &wincred.Backend{} // the factory fails outside Windows
+	}
+
+	cfg := vaultmux.Config{
+		Backend:     bt,
+		StorePath:   t.TempDir(),
+		SessionFile: t.TempDir() + "/session",
+		Options: map[string]string{
+			"project_id": "limits-test",
+			"vault_url":  "https://limits-test.vault.azure.net/",
+		},
+	}
+	backend, err := vaultmux.New(cfg)
+	if err != nil {
+		t.Fatalf("New(%s) error = %v", bt, err)
+	}
+	return backend
+}
+
+func TestBackendLimits_Builtin(t *testing.T) {
+	for _, bt := range vaultmux.BuiltinBackends() {
+		t.Run(bt.String(), func(t *testing.T) {
+			limits := vaultmux.BackendLimits(newLimitsBackend(t, bt))
+			if limits.MaxSecretBytes < 1024 {
+				t.Errorf("MaxSecretBytes = %d, want at least 1024", limits.MaxSecretBytes)
+			}
+			if limits.MaxNameLength < 64 {
+				t.Errorf("MaxNameLength = %d, want at least 64", limits.MaxNameLength)
+			}
+			if limits.AllowedNameCharset == "" {
+				return
+			}
+
+			// ValidateItemNameForBackend must warn about exactly the
+			// printable ASCII characters outside the reported charset.
+			allowed := regexp.MustCompile("^[" + limits.AllowedNameCharset + "]$")
+			for r := rune('!'); r <= '~'; r++ {
+				warned := false
+				for _, w := range vaultmux.ValidateItemNameForBackend("a"+string(r), bt) {
+					if strings.Contains(w.Message, fmt.Sprintf("%q which", string(r))) {
+						warned = true
+					}
+				}
+				if want := !allowed.MatchString(string(r)); warned != want {
+					t.Errorf("ValidateItemNameForBackend warns about %q = %v, want %v", r, warned, want)
+				}
+			}
+
+			tooLong := strings.Repeat("a", limits.MaxNameLength+1)
+			want := fmt.Sprintf("at most %d", limits.MaxNameLength)
+			if w := vaultmux.ValidateItemNameForBackend(tooLong, bt); len(w) != 1 || !strings.Contains(w[0].Message, want) {
+				t.Errorf("ValidateItemNameForBackend(%d chars) = %v, want %q", len(tooLong), w, want)
+			}
+		})
+	}
+}
+
+func TestBackendLimits_Default(t *testing.T) {
+	if got := vaultmux.BackendLimits(mock.New()); got != vaultmux.DefaultLimits {
+		t.Errorf("BackendLimits(mock) = %+v, want DefaultLimits", got)
+	}
+}
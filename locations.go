@@ -0,0 +1,76 @@
+package vaultmux
+
+import (
+	"context"
+	"sync"
+)
+
+// nonEmptyLocationWorkers bounds the concurrent ListItemsInLocation calls
+// made by ListNonEmptyLocations.
+const nonEmptyLocationWorkers = 8
+
+// ListNonEmptyLocations returns the locations of b that contain at least
+// one item, in the order ListLocations returns them, e.g. to keep empty
+// folders out of a picker. Locations are checked with up to eight
+// concurrent ListItemsInLocation calls; the first error stops the check.
+func ListNonEmptyLocations(ctx context.Context, b Backend, session Session) ([]string, error) {
+	locations, err := b.ListLocations(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	nonEmpty := make([]bool, len(locations))
+	indexes := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range min(nonEmptyLocationWorkers, len(locations)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				items, err := b.ListItemsInLocation(ctx, "", locations[i], session)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				nonEmpty[i] = len(items) > 0
+			}
+		}()
+	}
+
+send:
+	for i := range locations {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for i, location := range locations {
+		if nonEmpty[i] {
+			result = append(result, location)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestListNonEmptyLocations(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	for _, location := range []string{"prod", "staging", "archive"} {
+		if err := backend.CreateLocation(ctx, location, session); err != nil {
+			t.Fatalf("CreateLocation(%s) error = %v", location, err)
+		}
+	}
+	backend.SetItemWithLocation("db-password", "a", "prod")
+	backend.SetItemWithLocation("api-key", "b", "prod")
+	backend.SetItemWithLocation("db-password-staging", "c", "staging")
+
+	locations, err := vaultmux.ListNonEmptyLocations(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("ListNonEmptyLocations() error = %v", err)
+	}
+	sort.Strings(locations)
+	if len(locations) != 2 || locations[0] != "prod" || locations[1] != "staging" {
+		t.Errorf("ListNonEmptyLocations() = %v, want [prod staging]", locations)
+	}
+}
+
+// failingLocationBackend fails to list the items of one location.
+type failingLocationBackend struct {
+	*mock.Backend
+	failing string
+	err     error
+}
+
+func (b failingLocationBackend) ListItemsInLocation(ctx context.Context, locType, locValue string, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	if locValue == b.failing {
+		return nil, b.err
+	}
+	return b.Backend.ListItemsInLocation(ctx, locType, locValue, session)
+}
+
+func TestListNonEmptyLocations_Error(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+	for _, location := range []string{"prod", "staging"} {
+		_ = backend.CreateLocation(ctx, location, session)
+	}
+
+	listErr := errors.New("permission denied")
+	_, err := vaultmux.ListNonEmptyLocations(ctx, failingLocationBackend{backend, "staging", listErr}, session)
+	if !errors.Is(err, listErr) {
+		t.Errorf("ListNonEmptyLocations() error = %v, want %v", err, listErr)
+	}
+}
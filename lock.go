@@ -0,0 +1,22 @@
+package vaultmux
+
+import "context"
+
+// Locker is implemented by CLI backends whose vault can be locked again
+// after use (Bitwarden, 1Password). Authenticate is the unlock counterpart.
+type Locker interface {
+	// Lock locks the vault and forgets the cached session, so the next
+	// operation needs Authenticate again.
+	Lock(ctx context.Context) error
+}
+
+// Lock locks b's vault, e.g. once a short-lived job has read its secrets,
+// so a session token left on disk can no longer be used. Backends that
+// don't implement Locker return ErrNotSupported.
+func Lock(ctx context.Context, b Backend) error {
+	l, ok := b.(Locker)
+	if !ok {
+		return WrapError(b.Name(), "lock", "", ErrNotSupported)
+	}
+	return l.Lock(ctx)
+}
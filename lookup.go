@@ -0,0 +1,188 @@
+package vaultmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// ErrDefaultUsed is wrapped around the error that made GetNotesWithTimeout
+// fall back to its default value.
+var ErrDefaultUsed = errors.New("default value used")
+
+// ErrEmptySecret indicates an item exists but its value is empty or only
+// whitespace.
+var ErrEmptySecret = errors.New("secret value is empty")
+
+// GetNotesRequired returns the notes of the named item, failing with
+// ErrEmptySecret if they are empty or only whitespace. This catches a
+// secret that was created but never filled in at the point of reading,
+// rather than as a confusing failure wherever the value is used. A missing
+// item still returns ErrNotFound.
+func GetNotesRequired(ctx context.Context, b Backend, name string, session Session) (string, error) {
+	notes, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(notes) == "" {
+		return "", WrapError(b.Name(), "get", name, ErrEmptySecret)
+	}
+	return notes, nil
+}
+
+// GetNotesOrDefault returns the notes of the named item, or def if the item
+// does not exist. All other errors are returned unchanged.
+func GetNotesOrDefault(ctx context.Context, b Backend, name, def string, session Session) (string, error) {
+	notes, err := b.GetNotes(ctx, name, session)
+	if errors.Is(err, ErrNotFound) {
+		return def, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return notes, nil
+}
+
+// GetNotesWithTimeout returns the notes of the named item, giving up after
+// timeout. If the read times out or fails to reach the backend, def is
+// returned together with an error wrapping both ErrDefaultUsed and the
+// cause; callers that only want a value at startup can ignore it. Other
+// errors, including ErrNotFound, are returned unchanged with no default.
+//
+// The read runs in its own goroutine, so the timeout holds even for a
+// backend that ignores ctx; such a call is left to finish in the background.
+func GetNotesWithTimeout(ctx context.Context, b Backend, name string, timeout time.Duration, def string, session Session) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		notes string
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		notes, err := b.GetNotes(ctx, name, session)
+		done <- result{notes, err}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		res.err = ctx.Err()
+	}
+
+	var netErr net.Error
+	switch {
+	case res.err == nil:
+		return res.notes, nil
+	case errors.Is(res.err, context.DeadlineExceeded), errors.As(res.err, &netErr):
+		return def, fmt.Errorf("%w: %w", ErrDefaultUsed, res.err)
+	default:
+		return "", res.err
+	}
+}
+
+// GetItemOrNil returns the named item, or nil if it does not exist.
+// All other errors are returned unchanged.
+func GetItemOrNil(ctx context.Context, b Backend, name string, session Session) (*Item, error) {
+	item, err := b.GetItem(ctx, name, session)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetNotesFirst tries each name in order and returns the notes of the first
+// item that exists, along with the name that matched. ErrNotFound is returned
+// only if none of the names exist; other errors stop the search.
+func GetNotesFirst(ctx context.Context, b Backend, names []string, session Session) (value string, found string, err error) {
+	for _, name := range names {
+		notes, err := b.GetNotes(ctx, name, session)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		return notes, name, nil
+	}
+	return "", "", ErrNotFound
+}
+
+// BackendWithSession pairs a backend with the session to use for it.
+type BackendWithSession struct {
+	Backend Backend
+	Session Session
+}
+
+// FindAnywhere looks up name in all backends concurrently and returns the
+// first item found, along with the type of the backend that served it. The
+// remaining lookups are cancelled once an item is found; FindAnywhere does
+// not wait for them to return.
+//
+// ErrNotFound is returned only if every backend reports the item missing.
+// If no backend has it and some failed for another reason, the first such
+// error is returned instead, since the item may be in a backend that could
+// not be reached.
+func FindAnywhere(ctx context.Context, name string, backends []BackendWithSession) (*Item, BackendType, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		item    *Item
+		backend BackendType
+		err     error
+	}
+	results := make(chan result, len(backends))
+	for _, bs := range backends {
+		go func() {
+			item, err := bs.Backend.GetItem(ctx, name, bs.Session)
+			results <- result{item, BackendType(bs.Backend.Name()), err}
+		}()
+	}
+
+	var firstErr error
+	for range backends {
+		r := <-results
+		if r.err == nil {
+			return r.item, r.backend, nil
+		}
+		if firstErr == nil && !errors.Is(r.err, ErrNotFound) {
+			firstErr = r.err
+		}
+	}
+	if firstErr != nil {
+		return nil, "", firstErr
+	}
+	return nil, "", ErrNotFound
+}
+
+// ItemFinder is implemented by backends where one name can match several
+// items, such as the same title in different 1Password vaults or Bitwarden
+// folders.
+type ItemFinder interface {
+	FindItems(ctx context.Context, name string, session Session) ([]*Item, error)
+}
+
+// FindItems returns every item named name, across all locations, so callers
+// can disambiguate when GetItem fails with ErrAmbiguous. Backends that don't
+// implement ItemFinder have unique names and return at most one item. No
+// items and a nil error are returned if nothing matches.
+func FindItems(ctx context.Context, b Backend, name string, session Session) ([]*Item, error) {
+	if finder, ok := b.(ItemFinder); ok {
+		return finder.FindItems(ctx, name, session)
+	}
+
+	item, err := GetItemOrNil(ctx, b, name, session)
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return []*Item{item}, nil
+}
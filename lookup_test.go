@@ -0,0 +1,236 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestGetNotesOrDefault(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+	backend.SetItem("present", "value")
+
+	got, err := vaultmux.GetNotesOrDefault(ctx, backend, "present", "fallback", session)
+	if err != nil || got != "value" {
+		t.Errorf("GetNotesOrDefault(present) = %q, %v; want %q, nil", got, err, "value")
+	}
+
+	got, err = vaultmux.GetNotesOrDefault(ctx, backend, "missing", "fallback", session)
+	if err != nil || got != "fallback" {
+		t.Errorf("GetNotesOrDefault(missing) = %q, %v; want %q, nil", got, err, "fallback")
+	}
+
+	connErr := errors.New("connection refused")
+	backend.GetError = connErr
+	if _, err := vaultmux.GetNotesOrDefault(ctx, backend, "missing", "fallback", session); !errors.Is(err, connErr) {
+		t.Errorf("GetNotesOrDefault() error = %v, want %v", err, connErr)
+	}
+}
+
+func TestGetNotesRequired(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+	backend.SetItem("present", "value")
+	backend.SetItem("empty", "")
+	backend.SetItem("blank", " \n")
+
+	got, err := vaultmux.GetNotesRequired(ctx, backend, "present", session)
+	if err != nil || got != "value" {
+		t.Errorf("GetNotesRequired(present) = %q, %v; want %q, nil", got, err, "value")
+	}
+
+	for _, name := range []string{"empty", "blank"} {
+		if _, err := vaultmux.GetNotesRequired(ctx, backend, name, session); !errors.Is(err, vaultmux.ErrEmptySecret) {
+			t.Errorf("GetNotesRequired(%s) error = %v, want ErrEmptySecret", name, err)
+		}
+	}
+
+	_, err = vaultmux.GetNotesRequired(ctx, backend, "missing", session)
+	if !errors.Is(err, vaultmux.ErrNotFound) || errors.Is(err, vaultmux.ErrEmptySecret) {
+		t.Errorf("GetNotesRequired(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetItemOrNil(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+	backend.SetItem("present", "value")
+
+	item, err := vaultmux.GetItemOrNil(ctx, backend, "present", session)
+	if err != nil || item == nil || item.Notes != "value" {
+		t.Errorf("GetItemOrNil(present) = %+v, %v", item, err)
+	}
+
+	item, err = vaultmux.GetItemOrNil(ctx, backend, "missing", session)
+	if err != nil || item != nil {
+		t.Errorf("GetItemOrNil(missing) = %+v, %v; want nil, nil", item, err)
+	}
+
+	connErr := errors.New("connection refused")
+	backend.GetError = connErr
+	if _, err := vaultmux.GetItemOrNil(ctx, backend, "present", session); !errors.Is(err, connErr) {
+		t.Errorf("GetItemOrNil() error = %v, want %v", err, connErr)
+	}
+}
+
+func TestGetNotesFirst(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+	backend.SetItem("DATABASE_PASSWORD", "s3cret")
+
+	value, found, err := vaultmux.GetNotesFirst(ctx, backend, []string{"DB_PASSWORD", "DATABASE_PASSWORD"}, session)
+	if err != nil {
+		t.Fatalf("GetNotesFirst() error = %v", err)
+	}
+	if value != "s3cret" || found != "DATABASE_PASSWORD" {
+		t.Errorf("GetNotesFirst() = %q, %q; want %q, %q", value, found, "s3cret", "DATABASE_PASSWORD")
+	}
+
+	if _, _, err := vaultmux.GetNotesFirst(ctx, backend, []string{"A", "B"}, session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetNotesFirst() with no matches error = %v, want ErrNotFound", err)
+	}
+
+	connErr := errors.New("connection refused")
+	backend.GetError = connErr
+	if _, _, err := vaultmux.GetNotesFirst(ctx, backend, []string{"DB_PASSWORD"}, session); !errors.Is(err, connErr) {
+		t.Errorf("GetNotesFirst() error = %v, want %v", err, connErr)
+	}
+}
+
+// namedBackend reports a different backend name, so tests can tell mocks
+// apart by BackendType.
+type namedBackend struct {
+	*mock.Backend
+	name string
+}
+
+func (b namedBackend) Name() string { return b.name }
+
+func TestFindAnywhere(t *testing.T) {
+	ctx := context.Background()
+
+	var backends []vaultmux.BackendWithSession
+	for _, name := range []string{"pass", "awssecrets", "gcpsecrets"} {
+		b := namedBackend{mock.New(), name}
+		session, _ := b.Authenticate(ctx)
+		backends = append(backends, vaultmux.BackendWithSession{Backend: b, Session: session})
+	}
+	backends[1].Backend.(namedBackend).SetItem("db-password", "s3cret")
+
+	item, source, err := vaultmux.FindAnywhere(ctx, "db-password", backends)
+	if err != nil {
+		t.Fatalf("FindAnywhere() error = %v", err)
+	}
+	if item.Notes != "s3cret" || source != vaultmux.BackendAWSSecretsManager {
+		t.Errorf("FindAnywhere() = %q from %q, want s3cret from awssecrets", item.Notes, source)
+	}
+
+	if _, _, err := vaultmux.FindAnywhere(ctx, "missing", backends); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("FindAnywhere(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// An unreachable backend may hold the item, so its error wins over ErrNotFound
+	connErr := errors.New("connection refused")
+	unreachable := mock.New()
+	unreachable.GetError = connErr
+	backends = append(backends, vaultmux.BackendWithSession{Backend: unreachable})
+	if _, _, err := vaultmux.FindAnywhere(ctx, "missing", backends); !errors.Is(err, connErr) {
+		t.Errorf("FindAnywhere(missing) error = %v, want %v", err, connErr)
+	}
+}
+
+func TestFindItems_Fallback(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "secret")
+	session, _ := backend.Authenticate(ctx)
+
+	found, err := vaultmux.FindItems(ctx, backend, "api-key", session)
+	if err != nil || len(found) != 1 || found[0].Notes != "secret" {
+		t.Errorf("FindItems(api-key) = %v, %v; want one item", found, err)
+	}
+
+	found, err = vaultmux.FindItems(ctx, backend, "missing", session)
+	if err != nil || len(found) != 0 {
+		t.Errorf("FindItems(missing) = %v, %v; want none", found, err)
+	}
+}
+
+func TestAmbiguousError(t *testing.T) {
+	err := vaultmux.WrapError("1password", "get", "api-key", &vaultmux.AmbiguousError{
+		Name:       "api-key",
+		Candidates: []*vaultmux.Item{{ID: "a"}, {ID: "b"}},
+	})
+	if !errors.Is(err, vaultmux.ErrAmbiguous) {
+		t.Errorf("errors.Is(%v, ErrAmbiguous) = false", err)
+	}
+	if want := `1password: get "api-key": ambiguous item name: 2 items named "api-key"`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+// latencyBackend delays every GetNotes, like a slow or unreachable backend.
+type latencyBackend struct {
+	*mock.Backend
+	latency time.Duration
+}
+
+func (b latencyBackend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	select {
+	case <-time.After(b.latency):
+		return b.Backend.GetNotes(ctx, name, session)
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestGetNotesWithTimeout(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("db", "real")
+
+	slow := latencyBackend{backend, time.Second}
+	got, err := vaultmux.GetNotesWithTimeout(ctx, slow, "db", 10*time.Millisecond, "fallback", nil)
+	if got != "fallback" {
+		t.Errorf("GetNotesWithTimeout(slow) = %q, want fallback", got)
+	}
+	if !errors.Is(err, vaultmux.ErrDefaultUsed) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetNotesWithTimeout(slow) error = %v, want ErrDefaultUsed wrapping DeadlineExceeded", err)
+	}
+
+	fast := latencyBackend{backend, time.Millisecond}
+	got, err = vaultmux.GetNotesWithTimeout(ctx, fast, "db", time.Second, "fallback", nil)
+	if err != nil || got != "real" {
+		t.Errorf("GetNotesWithTimeout(fast) = %q, %v; want real, nil", got, err)
+	}
+
+	got, err = vaultmux.GetNotesWithTimeout(ctx, fast, "missing", time.Second, "fallback", nil)
+	if !errors.Is(err, vaultmux.ErrNotFound) || errors.Is(err, vaultmux.ErrDefaultUsed) || got != "" {
+		t.Errorf("GetNotesWithTimeout(missing) = %q, %v; want ErrNotFound without default", got, err)
+	}
+}
+
+func TestGetNotesWithTimeout_ConnectivityError(t *testing.T) {
+	backend := mock.New()
+	backend.GetError = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	got, err := vaultmux.GetNotesWithTimeout(context.Background(), backend, "db", time.Second, "fallback", nil)
+	if got != "fallback" || !errors.Is(err, vaultmux.ErrDefaultUsed) {
+		t.Errorf("GetNotesWithTimeout() = %q, %v; want fallback with ErrDefaultUsed", got, err)
+	}
+
+	backend.GetError = vaultmux.ErrPermissionDenied
+	if got, err := vaultmux.GetNotesWithTimeout(context.Background(), backend, "db", time.Second, "fallback", nil); got != "" || !errors.Is(err, vaultmux.ErrPermissionDenied) {
+		t.Errorf("GetNotesWithTimeout() = %q, %v; want ErrPermissionDenied without default", got, err)
+	}
+}
@@ -0,0 +1,71 @@
+package vaultmux
+
+import (
+	"context"
+	"errors"
+)
+
+// MissingSecretPolicy selects what GetNotes returns for a missing item.
+type MissingSecretPolicy int
+
+const (
+	// MissingSecretError returns an error wrapping ErrNotFound (default).
+	MissingSecretError MissingSecretPolicy = iota
+	// MissingSecretReturnEmpty returns "" and a nil error, for callers that
+	// treat an absent secret like an empty one.
+	MissingSecretReturnEmpty
+)
+
+// String returns the string representation of MissingSecretPolicy.
+func (p MissingSecretPolicy) String() string {
+	switch p {
+	case MissingSecretError:
+		return "Error"
+	case MissingSecretReturnEmpty:
+		return "ReturnEmpty"
+	default:
+		return "Unknown"
+	}
+}
+
+// EmptyOnMissing wraps a Backend so that GetNotes returns "" and a nil
+// error for missing items, implementing MissingSecretReturnEmpty the same
+// way for every backend. GetItem and ItemExists are unchanged, so callers
+// that need to tell a missing item from an empty one still can.
+//
+// Writes pass straight through, including CreateItemWithOptions and
+// SetItem; Unwrap gives access to the wrapped backend's other optional
+// interfaces.
+type EmptyOnMissing struct {
+	Backend
+}
+
+// NewEmptyOnMissing wraps b so that GetNotes ignores missing items.
+func NewEmptyOnMissing(b Backend) *EmptyOnMissing {
+	return &EmptyOnMissing{Backend: b}
+}
+
+// CreateItemWithOptions creates an item with opts. Options are ignored if
+// the wrapped backend is not an ItemCreator.
+func (e *EmptyOnMissing) CreateItemWithOptions(ctx context.Context, name, content string, session Session, opts ...CreateOption) error {
+	return createItemWithOptions(ctx, e.Backend, name, content, session, opts...)
+}
+
+// SetItem creates or updates an item.
+func (e *EmptyOnMissing) SetItem(ctx context.Context, name, content string, session Session) error {
+	return SetItem(ctx, e.Backend, name, content, session)
+}
+
+// Unwrap returns the wrapped backend.
+func (e *EmptyOnMissing) Unwrap() Backend {
+	return e.Backend
+}
+
+// GetNotes retrieves the notes of an item, returning "" for missing items.
+func (e *EmptyOnMissing) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	notes, err := e.Backend.GetNotes(ctx, name, session)
+	if errors.Is(err, ErrNotFound) {
+		return "", nil
+	}
+	return notes, err
+}
@@ -0,0 +1,72 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestEmptyOnMissing(t *testing.T) {
+	ctx := context.Background()
+	inner := mock.New()
+	inner.SetItem("present", "value")
+	backend := vaultmux.NewEmptyOnMissing(inner)
+
+	notes, err := backend.GetNotes(ctx, "absent", nil)
+	if err != nil || notes != "" {
+		t.Errorf("GetNotes(absent) = %q, %v; want \"\", nil", notes, err)
+	}
+	exists, err := backend.ItemExists(ctx, "absent", nil)
+	if err != nil || exists {
+		t.Errorf("ItemExists(absent) = %v, %v; want false, nil", exists, err)
+	}
+	if notes, _ := backend.GetNotes(ctx, "present", nil); notes != "value" {
+		t.Errorf("GetNotes(present) = %q, want value", notes)
+	}
+
+	inner.GetError = errors.New("connection refused")
+	if _, err := backend.GetNotes(ctx, "absent", nil); err == nil {
+		t.Error("GetNotes() swallowed a non-NotFound error")
+	}
+}
+
+func TestNew_MissingSecretPolicy(t *testing.T) {
+	vaultmux.RegisterBackend("mock-missing", func(cfg vaultmux.Config) (vaultmux.Backend, error) {
+		return mock.New(), nil
+	})
+	ctx := context.Background()
+
+	backend, err := vaultmux.New(vaultmux.Config{Backend: "mock-missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.GetNotes(ctx, "absent", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("default policy GetNotes() error = %v, want ErrNotFound", err)
+	}
+
+	backend, err = vaultmux.New(vaultmux.Config{
+		Backend:             "mock-missing",
+		MissingSecretPolicy: vaultmux.MissingSecretReturnEmpty,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notes, err := backend.GetNotes(ctx, "absent", nil); err != nil || notes != "" {
+		t.Errorf("ReturnEmpty GetNotes() = %q, %v; want \"\", nil", notes, err)
+	}
+
+	creator, ok := backend.(vaultmux.ItemCreator)
+	if !ok {
+		t.Fatalf("New() = %T, want an ItemCreator", backend)
+	}
+	if err := creator.CreateItemWithOptions(ctx, "db", "pw", nil, vaultmux.WithDescription("primary")); err != nil {
+		t.Fatalf("CreateItemWithOptions() error = %v", err)
+	}
+	inner := vaultmux.Innermost(backend)
+	if item, err := inner.GetItem(ctx, "db", nil); err != nil || item.Description != "primary" {
+		t.Errorf("stored db = %+v, %v; want it with description primary", item, err)
+	}
+}
@@ -3,6 +3,7 @@ package mock
 
 import (
 	"context"
+	"maps"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 // Backend is an in-memory mock for testing.
 type Backend struct {
 	items     map[string]*vaultmux.Item
+	tags      map[string]map[string]string // keyed like items
 	locations map[string]bool
 	mu        sync.RWMutex
 
@@ -22,12 +24,27 @@ type Backend struct {
 	UpdateError error
 	DeleteError error
 	SyncError   error
+
+	// Clock sets Created/Modified times (nil means time.Now)
+	Clock vaultmux.Clock
+
+	// CaseInsensitive simulates a backend like Windows Credential Manager:
+	// names are stored in canonical case, so names differing only by case
+	// refer to the same item, and the original name is kept in
+	// Fields[vaultmux.FieldDisplayName].
+	CaseInsensitive bool
+
+	// IDGenerator sets Item.ID for new items, to simulate backends with
+	// opaque server-generated IDs such as 1Password item IDs or AWS ARNs
+	// (nil uses the name as the ID). Look items up by ID with GetItemByID.
+	IDGenerator func(name string) string
 }
 
 // New creates a new mock backend.
 func New() *Backend {
 	return &Backend{
 		items:     make(map[string]*vaultmux.Item),
+		tags:      make(map[string]map[string]string),
 		locations: make(map[string]bool),
 	}
 }
@@ -35,6 +52,9 @@ func New() *Backend {
 // Name returns the backend name.
 func (b *Backend) Name() string { return "mock" }
 
+// CaseSensitive reports whether item names are case-sensitive.
+func (b *Backend) CaseSensitive() bool { return !b.CaseInsensitive }
+
 // Init is a no-op for mock.
 func (b *Backend) Init(ctx context.Context) error { return nil }
 
@@ -60,15 +80,18 @@ func (b *Backend) Sync(ctx context.Context, session vaultmux.Session) error {
 }
 
 // GetItem retrieves an item from the in-memory store.
-func (b *Backend) GetItem(ctx context.Context, name string, _ vaultmux.Session) (*vaultmux.Item, error) {
+func (b *Backend) GetItem(ctx context.Context, name string, session vaultmux.Session) (*vaultmux.Item, error) {
 	if b.GetError != nil {
 		return nil, b.GetError
 	}
+	if item, ok, err := vaultmux.PinnedRead(ctx, session, name); ok {
+		return item, err
+	}
 
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	item, ok := b.items[name]
+	item, ok := b.items[b.key(name)]
 	if !ok {
 		return nil, vaultmux.ErrNotFound
 	}
@@ -78,6 +101,24 @@ func (b *Backend) GetItem(ctx context.Context, name string, _ vaultmux.Session)
 	return &itemCopy, nil
 }
 
+// GetItemByID retrieves the item whose Item.ID is id.
+func (b *Backend) GetItemByID(ctx context.Context, id string, _ vaultmux.Session) (*vaultmux.Item, error) {
+	if b.GetError != nil {
+		return nil, b.GetError
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, item := range b.items {
+		if item.ID == id {
+			itemCopy := *item
+			return &itemCopy, nil
+		}
+	}
+	return nil, vaultmux.ErrNotFound
+}
+
 // GetNotes retrieves just the notes field.
 func (b *Backend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
 	item, err := b.GetItem(ctx, name, session)
@@ -92,7 +133,7 @@ func (b *Backend) ItemExists(ctx context.Context, name string, _ vaultmux.Sessio
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	_, ok := b.items[name]
+	_, ok := b.items[b.key(name)]
 	return ok, nil
 }
 
@@ -111,7 +152,12 @@ func (b *Backend) ListItems(ctx context.Context, _ vaultmux.Session) ([]*vaultmu
 }
 
 // CreateItem creates a new item.
-func (b *Backend) CreateItem(ctx context.Context, name, content string, _ vaultmux.Session) error {
+func (b *Backend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) error {
+	return b.CreateItemWithOptions(ctx, name, content, session)
+}
+
+// CreateItemWithOptions creates a new item, applying any create options.
+func (b *Backend) CreateItemWithOptions(ctx context.Context, name, content string, _ vaultmux.Session, opts ...vaultmux.CreateOption) error {
 	if b.CreateError != nil {
 		return b.CreateError
 	}
@@ -119,20 +165,19 @@ func (b *Backend) CreateItem(ctx context.Context, name, content string, _ vaultm
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if _, exists := b.items[name]; exists {
+	if _, exists := b.items[b.key(name)]; exists {
 		return vaultmux.ErrAlreadyExists
 	}
 
-	now := time.Now()
-	b.items[name] = &vaultmux.Item{
-		ID:       name, // Use name as ID for simplicity
-		Name:     name,
-		Type:     vaultmux.ItemTypeSecureNote,
-		Notes:    content,
-		Created:  now,
-		Modified: now,
+	if vaultmux.IsDryRun(ctx) {
+		return nil
 	}
 
+	o := vaultmux.NewCreateOptions(opts...)
+	item := b.newItem(name, content)
+	item.Description = o.Description
+	b.items[b.key(name)] = item
+
 	return nil
 }
 
@@ -145,13 +190,17 @@ func (b *Backend) UpdateItem(ctx context.Context, name, content string, _ vaultm
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	item, ok := b.items[name]
+	item, ok := b.items[b.key(name)]
 	if !ok {
 		return vaultmux.ErrNotFound
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	item.Notes = content
-	item.Modified = time.Now()
+	item.Modified = b.now()
 
 	return nil
 }
@@ -165,14 +214,77 @@ func (b *Backend) DeleteItem(ctx context.Context, name string, _ vaultmux.Sessio
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if _, ok := b.items[name]; !ok {
+	if _, ok := b.items[b.key(name)]; !ok {
+		return vaultmux.ErrNotFound
+	}
+
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	delete(b.items, b.key(name))
+	delete(b.tags, b.key(name))
+	return nil
+}
+
+// SetTags replaces the tags on an item.
+func (b *Backend) SetTags(ctx context.Context, name string, tags map[string]string, _ vaultmux.Session) error {
+	if b.UpdateError != nil {
+		return b.UpdateError
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.items[b.key(name)]; !ok {
 		return vaultmux.ErrNotFound
 	}
 
-	delete(b.items, name)
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
+	b.tags[b.key(name)] = maps.Clone(tags)
 	return nil
 }
 
+// GetTags returns a copy of the tags on an item.
+func (b *Backend) GetTags(ctx context.Context, name string, _ vaultmux.Session) (map[string]string, error) {
+	if b.GetError != nil {
+		return nil, b.GetError
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if _, ok := b.items[b.key(name)]; !ok {
+		return nil, vaultmux.ErrNotFound
+	}
+
+	tags := make(map[string]string, len(b.tags[b.key(name)]))
+	maps.Copy(tags, b.tags[b.key(name)])
+	return tags, nil
+}
+
+// ListItemsByTag returns items tagged key=value, or with key set to any
+// value if value is empty.
+func (b *Backend) ListItemsByTag(ctx context.Context, key, value string, _ vaultmux.Session) ([]*vaultmux.Item, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var items []*vaultmux.Item
+	for k, item := range b.items {
+		v, ok := b.tags[k][key]
+		if !ok || (value != "" && v != value) {
+			continue
+		}
+		itemCopy := *item
+		items = append(items, &itemCopy)
+	}
+
+	return items, nil
+}
+
 // ListLocations lists all locations.
 func (b *Backend) ListLocations(ctx context.Context, _ vaultmux.Session) ([]string, error) {
 	b.mu.RLock()
@@ -204,6 +316,10 @@ func (b *Backend) CreateLocation(ctx context.Context, name string, _ vaultmux.Se
 		return vaultmux.ErrAlreadyExists
 	}
 
+	if vaultmux.IsDryRun(ctx) {
+		return nil
+	}
+
 	b.locations[name] = true
 	return nil
 }
@@ -224,6 +340,44 @@ func (b *Backend) ListItemsInLocation(ctx context.Context, locType, locValue str
 	return items, nil
 }
 
+// key returns the storage key for name.
+func (b *Backend) key(name string) string {
+	if b.CaseInsensitive {
+		return vaultmux.CanonicalName(name)
+	}
+	return name
+}
+
+// newItem builds a stored item. Case-insensitive mocks store the canonical
+// name and keep the original for display.
+func (b *Backend) newItem(name, content string) *vaultmux.Item {
+	now := b.now()
+	id := b.key(name) // Use name as ID for simplicity
+	if b.IDGenerator != nil {
+		id = b.IDGenerator(name)
+	}
+	item := &vaultmux.Item{
+		ID:       id,
+		Name:     b.key(name),
+		Type:     vaultmux.ItemTypeSecureNote,
+		Notes:    content,
+		Created:  now,
+		Modified: now,
+	}
+	if b.CaseInsensitive {
+		item.Fields = map[string]string{vaultmux.FieldDisplayName: name}
+	}
+	return item
+}
+
+// now returns the current time from Clock, or time.Now if unset.
+func (b *Backend) now() time.Time {
+	if b.Clock == nil {
+		return time.Now()
+	}
+	return b.Clock.Now()
+}
+
 // Helper methods for tests
 
 // SetItem directly sets an item in the store (for test setup).
@@ -231,15 +385,7 @@ func (b *Backend) SetItem(name, content string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	now := time.Now()
-	b.items[name] = &vaultmux.Item{
-		ID:       name,
-		Name:     name,
-		Type:     vaultmux.ItemTypeSecureNote,
-		Notes:    content,
-		Created:  now,
-		Modified: now,
-	}
+	b.items[b.key(name)] = b.newItem(name, content)
 }
 
 // SetItemWithLocation sets an item with a specific location.
@@ -247,16 +393,9 @@ func (b *Backend) SetItemWithLocation(name, content, location string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	now := time.Now()
-	b.items[name] = &vaultmux.Item{
-		ID:       name,
-		Name:     name,
-		Type:     vaultmux.ItemTypeSecureNote,
-		Notes:    content,
-		Location: location,
-		Created:  now,
-		Modified: now,
-	}
+	item := b.newItem(name, content)
+	item.Location = location
+	b.items[b.key(name)] = item
 }
 
 // Clear removes all items and locations.
@@ -265,6 +404,7 @@ func (b *Backend) Clear() {
 	defer b.mu.Unlock()
 
 	b.items = make(map[string]*vaultmux.Item)
+	b.tags = make(map[string]map[string]string)
 	b.locations = make(map[string]bool)
 }
 
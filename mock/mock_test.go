@@ -3,7 +3,11 @@ package mock
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/blackwell-systems/vaultmux"
 )
@@ -381,3 +385,157 @@ func TestMockBackend_ErrorPaths(t *testing.T) {
 		}
 	})
 }
+
+func TestMockBackend_CreateItemWithDescription(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	session, _ := backend.Authenticate(ctx)
+
+	err := backend.CreateItemWithOptions(ctx, "described", "value", session,
+		vaultmux.WithDescription("API key for staging"))
+	if err != nil {
+		t.Fatalf("CreateItemWithOptions() error = %v", err)
+	}
+
+	item, err := backend.GetItem(ctx, "described", session)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Description != "API key for staging" {
+		t.Errorf("item.Description = %q, want %q", item.Description, "API key for staging")
+	}
+	if item.Notes != "value" {
+		t.Errorf("item.Notes = %q, want %q", item.Notes, "value")
+	}
+}
+
+func TestMockBackend_IDGenerator(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	n := 0
+	backend.IDGenerator = func(name string) string {
+		n++
+		return fmt.Sprintf("00000000-0000-4000-8000-%012d", n)
+	}
+	session, _ := backend.Authenticate(ctx)
+
+	if err := backend.CreateItem(ctx, "api-key", "a", session); err != nil {
+		t.Fatal(err)
+	}
+	backend.SetItem("db-password", "b")
+
+	for _, name := range []string{"api-key", "db-password"} {
+		item, err := backend.GetItem(ctx, name, session)
+		if err != nil {
+			t.Fatalf("GetItem(%s) error = %v", name, err)
+		}
+		if item.ID == item.Name {
+			t.Errorf("%s: Item.ID = Item.Name = %q, want a generated ID", name, item.ID)
+		}
+
+		byID, err := backend.GetItemByID(ctx, item.ID, session)
+		if err != nil {
+			t.Fatalf("GetItemByID(%s) error = %v", item.ID, err)
+		}
+		if byID.Name != name {
+			t.Errorf("GetItemByID(%s).Name = %q, want %q", item.ID, byID.Name, name)
+		}
+	}
+
+	// Updates keep the ID
+	if err := backend.UpdateItem(ctx, "api-key", "a2", session); err != nil {
+		t.Fatal(err)
+	}
+	if item, _ := backend.GetItemByID(ctx, "00000000-0000-4000-8000-000000000001", session); item == nil || item.Notes != "a2" {
+		t.Errorf("GetItemByID() after update = %+v, want api-key with a2", item)
+	}
+
+	if _, err := backend.GetItemByID(ctx, "api-key", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetItemByID(name) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMockBackend_Tags(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	var _ vaultmux.TaggableBackend = backend
+	session, _ := backend.Authenticate(ctx)
+
+	backend.SetItem("api-prod", "a")
+	backend.SetItem("api-staging", "b")
+	backend.SetItem("db-prod", "c")
+	backend.SetItem("untagged", "d")
+
+	for name, tags := range map[string]map[string]string{
+		"api-prod":    {"env": "prod", "team": "api"},
+		"api-staging": {"env": "staging", "team": "api"},
+		"db-prod":     {"env": "prod"},
+	} {
+		if err := backend.SetTags(ctx, name, tags, session); err != nil {
+			t.Fatalf("SetTags(%s) error = %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		key, value string
+		want       []string
+	}{
+		{"env", "prod", []string{"api-prod", "db-prod"}},
+		{"team", "api", []string{"api-prod", "api-staging"}},
+		{"team", "", []string{"api-prod", "api-staging"}},
+		{"env", "dev", nil},
+	}
+	for _, tt := range tests {
+		items, err := backend.ListItemsByTag(ctx, tt.key, tt.value, session)
+		if err != nil {
+			t.Fatalf("ListItemsByTag(%s=%s) error = %v", tt.key, tt.value, err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Name)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ListItemsByTag(%s=%s) = %v, want %v", tt.key, tt.value, got, tt.want)
+		}
+	}
+
+	tags, err := backend.GetTags(ctx, "db-prod", session)
+	if err != nil || !reflect.DeepEqual(tags, map[string]string{"env": "prod"}) {
+		t.Errorf("GetTags(db-prod) = %v, %v", tags, err)
+	}
+	if tags, _ := backend.GetTags(ctx, "untagged", session); len(tags) != 0 {
+		t.Errorf("GetTags(untagged) = %v, want empty", tags)
+	}
+
+	// SetTags replaces rather than merges
+	_ = backend.SetTags(ctx, "api-prod", map[string]string{"owner": "alice"}, session)
+	if items, _ := backend.ListItemsByTag(ctx, "env", "prod", session); len(items) != 1 {
+		t.Errorf("ListItemsByTag(env=prod) after retag = %d items, want 1", len(items))
+	}
+
+	// Deleting an item drops its tags
+	_ = backend.DeleteItem(ctx, "db-prod", session)
+	backend.SetItem("db-prod", "c")
+	if tags, _ := backend.GetTags(ctx, "db-prod", session); len(tags) != 0 {
+		t.Errorf("GetTags() after delete and recreate = %v, want empty", tags)
+	}
+
+	if err := backend.SetTags(ctx, "missing", map[string]string{"a": "b"}, session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("SetTags(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := backend.GetTags(ctx, "missing", session); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("GetTags(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+	var _ vaultmux.Clock = clock
+
+	clock.Advance(time.Hour)
+	if got := clock.Now(); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(time.Hour))
+	}
+}
@@ -0,0 +1,49 @@
+package vaultmux
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ModifiedSinceLister is implemented by backends that need extra metadata
+// lookups to tell when an item last changed (e.g. GCP, where a new version
+// doesn't change the secret itself).
+type ModifiedSinceLister interface {
+	ListItemsModifiedSince(ctx context.Context, since time.Time, session Session) ([]*Item, error)
+}
+
+// ListItemsModifiedSince returns the items modified after since, for
+// incremental sync jobs. Backends implementing ModifiedSinceLister are asked
+// directly; otherwise items are filtered on Item.Modified. Items without a
+// modification time are always included, and a warning is logged to the
+// default slog logger, so callers never miss a change.
+func ListItemsModifiedSince(ctx context.Context, b Backend, since time.Time, session Session) ([]*Item, error) {
+	if lister, ok := b.(ModifiedSinceLister); ok {
+		return lister.ListItemsModifiedSince(ctx, since, session)
+	}
+
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []*Item
+	unknown := 0
+	for _, item := range items {
+		switch {
+		case item.Modified.IsZero():
+			unknown++
+			modified = append(modified, item)
+		case item.Modified.After(since):
+			modified = append(modified, item)
+		}
+	}
+
+	if unknown > 0 {
+		slog.Default().WarnContext(ctx, "backend does not report modification times; including items unfiltered",
+			"backend", b.Name(), "items", unknown)
+	}
+
+	return modified, nil
+}
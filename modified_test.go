@@ -0,0 +1,76 @@
+package vaultmux_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestListItemsModifiedSince(t *testing.T) {
+	ctx := context.Background()
+	clock := mock.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	backend := mock.New()
+	backend.Clock = clock
+	backend.SetItem("api-key", "a")
+	backend.SetItem("db-password", "b")
+	session, _ := backend.Authenticate(ctx)
+
+	clock.Advance(time.Hour)
+	since := clock.Now()
+	clock.Advance(time.Minute)
+	if err := backend.UpdateItem(ctx, "db-password", "rotated", session); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+
+	items, err := vaultmux.ListItemsModifiedSince(ctx, backend, since, session)
+	if err != nil {
+		t.Fatalf("ListItemsModifiedSince() error = %v", err)
+	}
+	if got := names(items); !reflect.DeepEqual(got, []string{"db-password"}) {
+		t.Errorf("ListItemsModifiedSince() = %v, want [db-password]", got)
+	}
+}
+
+// noModifiedBackend lists items without modification times.
+type noModifiedBackend struct {
+	*mock.Backend
+}
+
+func (b noModifiedBackend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	items, err := b.Backend.ListItems(ctx, session)
+	for _, item := range items {
+		item.Modified = time.Time{}
+	}
+	return items, err
+}
+
+func TestListItemsModifiedSince_UnknownModified(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "a")
+	backend.SetItem("db-password", "b")
+	session, _ := backend.Authenticate(ctx)
+
+	items, err := vaultmux.ListItemsModifiedSince(ctx, noModifiedBackend{backend}, time.Now(), session)
+	if err != nil {
+		t.Fatalf("ListItemsModifiedSince() error = %v", err)
+	}
+	if got := names(items); !reflect.DeepEqual(got, []string{"api-key", "db-password"}) {
+		t.Errorf("ListItemsModifiedSince() = %v, want all items", got)
+	}
+}
+
+func names(items []*vaultmux.Item) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.Name
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,29 @@
+package vaultmux
+
+import "context"
+
+// ItemNameLister is implemented by backends that can list item names
+// without building full Item values.
+type ItemNameLister interface {
+	ListItemNames(ctx context.Context, session Session) ([]string, error)
+}
+
+// ListItemNames returns the names of all items, as ListItems would report
+// them. Backends implementing ItemNameLister are asked directly, which saves
+// allocations on large vaults; otherwise the names are taken from ListItems.
+func ListItemNames(ctx context.Context, b Backend, session Session) ([]string, error) {
+	if lister, ok := b.(ItemNameLister); ok {
+		return lister.ListItemNames(ctx, session)
+	}
+
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names, nil
+}
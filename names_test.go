@@ -0,0 +1,54 @@
+package vaultmux_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestListItemNames_Fallback(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("api-key", "a")
+	backend.SetItem("db-password", "b")
+	session, _ := backend.Authenticate(ctx)
+
+	got, err := vaultmux.ListItemNames(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	sort.Strings(got)
+	if want := []string{"api-key", "db-password"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListItemNames() = %v, want %v", got, want)
+	}
+}
+
+// namesOnlyBackend lists names natively and fails full listings.
+type namesOnlyBackend struct {
+	*mock.Backend
+}
+
+func (namesOnlyBackend) ListItems(ctx context.Context, session vaultmux.Session) ([]*vaultmux.Item, error) {
+	return nil, vaultmux.ErrNotSupported
+}
+
+func (namesOnlyBackend) ListItemNames(ctx context.Context, session vaultmux.Session) ([]string, error) {
+	return []string{"native"}, nil
+}
+
+func TestListItemNames_Native(t *testing.T) {
+	ctx := context.Background()
+	backend := namesOnlyBackend{mock.New()}
+
+	got, err := vaultmux.ListItemNames(ctx, backend, nil)
+	if err != nil {
+		t.Fatalf("ListItemNames() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"native"}) {
+		t.Errorf("ListItemNames() = %v, want [native]", got)
+	}
+}
@@ -0,0 +1,69 @@
+package vaultmux
+
+import (
+	"errors"
+	"os/exec"
+	"time"
+)
+
+// Event is implemented by all events reported to an Observer.
+// Observers type-switch on the concrete event type.
+type Event interface {
+	event()
+}
+
+// Observer receives events from backends for metrics and diagnostics.
+// Observe is called synchronously and must be safe for concurrent use.
+type Observer interface {
+	Observe(e Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(e Event)
+
+// Observe calls f(e).
+func (f ObserverFunc) Observe(e Event) { f(e) }
+
+// SubprocessEvent is reported by CLI backends after each subprocess exits.
+// Arguments beyond the subcommand are omitted since they may contain secrets.
+type SubprocessEvent struct {
+	Backend    string        // Backend name (e.g. "bitwarden")
+	Command    string        // Executable (e.g. "bw")
+	Subcommand string        // First argument (e.g. "get"), if any
+	Duration   time.Duration // Wall time of the subprocess
+	ExitCode   int           // Process exit code; -1 if it didn't start or was killed
+	Err        error         // Error returned by the runner, if any
+}
+
+func (SubprocessEvent) event() {}
+
+// ReportSubprocess sends a SubprocessEvent to o for a command that started at
+// start and finished with err. It is a no-op if o is nil.
+// CLI backend implementations call this from their command runner.
+func ReportSubprocess(o Observer, backend, command string, args []string, start time.Time, err error) {
+	if o == nil {
+		return
+	}
+
+	e := SubprocessEvent{
+		Backend:  backend,
+		Command:  command,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if len(args) > 0 {
+		e.Subcommand = args[0]
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		e.ExitCode = 0
+	case errors.As(err, &exitErr):
+		e.ExitCode = exitErr.ExitCode()
+	default:
+		e.ExitCode = -1
+	}
+
+	o.Observe(e)
+}
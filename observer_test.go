@@ -0,0 +1,57 @@
+package vaultmux
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestReportSubprocess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the false command")
+	}
+
+	var got []Event
+	o := ObserverFunc(func(e Event) { got = append(got, e) })
+
+	exitErr := exec.CommandContext(context.Background(), "false").Run()
+
+	tests := []struct {
+		name     string
+		args     []string
+		err      error
+		wantSub  string
+		wantCode int
+	}{
+		{name: "success", args: []string{"sync"}, wantSub: "sync", wantCode: 0},
+		{name: "exit error", args: []string{"get", "item", "x"}, err: exitErr, wantSub: "get", wantCode: 1},
+		{name: "not started", err: errors.New("executable not found"), wantCode: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			ReportSubprocess(o, "bitwarden", "bw", tt.args, time.Now().Add(-time.Second), tt.err)
+
+			if len(got) != 1 {
+				t.Fatalf("observed %d events, want 1", len(got))
+			}
+			e, ok := got[0].(SubprocessEvent)
+			if !ok {
+				t.Fatalf("event type = %T, want SubprocessEvent", got[0])
+			}
+			if e.Subcommand != tt.wantSub || e.ExitCode != tt.wantCode {
+				t.Errorf("event = %+v, want subcommand %q exit code %d", e, tt.wantSub, tt.wantCode)
+			}
+			if e.Duration < time.Second {
+				t.Errorf("Duration = %v, want >= 1s", e.Duration)
+			}
+		})
+	}
+
+	// A nil observer is a no-op
+	ReportSubprocess(nil, "bitwarden", "bw", nil, time.Now(), nil)
+}
@@ -0,0 +1,124 @@
+package vaultmux
+
+import "context"
+
+// ItemCreator is implemented by backends that accept CreateOptions when
+// creating items. Callers can type-assert a Backend to ItemCreator to use it;
+// CreateItem is equivalent to CreateItemWithOptions with no options.
+type ItemCreator interface {
+	CreateItemWithOptions(ctx context.Context, name, content string, session Session, opts ...CreateOption) error
+}
+
+// CreateOptions holds optional settings for item creation.
+// Backends ignore settings they have no place to store.
+type CreateOptions struct {
+	// Description is a human-readable note stored as metadata, separate
+	// from the secret value.
+	Description string
+
+	// CreateLocation creates a missing location when creating an item in
+	// it, instead of failing with ErrNotFound.
+	CreateLocation bool
+
+	// Tags are key/value metadata attached to the item (GCP labels).
+	Tags map[string]string
+}
+
+// CreateOption configures CreateOptions.
+type CreateOption func(*CreateOptions)
+
+// WithDescription attaches a human-readable description to the created item.
+func WithDescription(description string) CreateOption {
+	return func(o *CreateOptions) {
+		o.Description = description
+	}
+}
+
+// WithCreateLocation makes CreateItemInLocation create the target location
+// if it doesn't exist.
+func WithCreateLocation() CreateOption {
+	return func(o *CreateOptions) {
+		o.CreateLocation = true
+	}
+}
+
+// WithTags attaches key/value tags to the created item.
+func WithTags(tags map[string]string) CreateOption {
+	return func(o *CreateOptions) {
+		o.Tags = tags
+	}
+}
+
+// NewCreateOptions applies opts in order and returns the result.
+// Backend implementations use this to resolve the options they were passed.
+func NewCreateOptions(opts ...CreateOption) CreateOptions {
+	var o CreateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// createItemWithOptions creates an item with opts if b is an ItemCreator,
+// and with CreateItem, ignoring opts, otherwise.
+func createItemWithOptions(ctx context.Context, b Backend, name, content string, session Session, opts ...CreateOption) error {
+	if creator, ok := b.(ItemCreator); ok {
+		return creator.CreateItemWithOptions(ctx, name, content, session, opts...)
+	}
+	return b.CreateItem(ctx, name, content, session)
+}
+
+// LocationItemCreator is implemented by backends that can create an item
+// directly inside a location (Bitwarden folder, 1Password vault, pass
+// directory) rather than creating it and moving it afterwards.
+// If the location doesn't exist, ErrNotFound is returned unless the
+// WithCreateLocation option is given.
+type LocationItemCreator interface {
+	CreateItemInLocation(ctx context.Context, name, content, locType, locValue string, session Session, opts ...CreateOption) error
+}
+
+// ItemLister is implemented by backends that accept ListOptions when listing
+// items. ListItems is equivalent to ListItemsWithOptions with no options.
+type ItemLister interface {
+	ListItemsWithOptions(ctx context.Context, session Session, opts ...ListOption) ([]*Item, error)
+}
+
+// ListOptions holds optional settings for listing items.
+type ListOptions struct {
+	// FullNames returns stored names including the backend prefix instead
+	// of the stripped short names.
+	FullNames bool
+
+	// MaxResults stops the listing after this many items. If more items
+	// match, the first MaxResults are returned together with an error
+	// wrapping ErrListTruncated. Zero uses the backend's default from
+	// Config.MaxListResults.
+	MaxResults int
+}
+
+// ListOption configures ListOptions.
+type ListOption func(*ListOptions)
+
+// WithFullNames makes listings return fully-qualified stored names
+// (prefix included) rather than names with the prefix stripped.
+func WithFullNames() ListOption {
+	return func(o *ListOptions) {
+		o.FullNames = true
+	}
+}
+
+// WithMaxResults limits a listing to n items; see ListOptions.MaxResults.
+func WithMaxResults(n int) ListOption {
+	return func(o *ListOptions) {
+		o.MaxResults = n
+	}
+}
+
+// NewListOptions applies opts in order and returns the result.
+func NewListOptions(opts ...ListOption) ListOptions {
+	var o ListOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,23 @@
+package vaultmux
+
+import "testing"
+
+func TestNewCreateOptions(t *testing.T) {
+	if got := NewCreateOptions(); got.Description != "" {
+		t.Errorf("NewCreateOptions() Description = %q, want empty", got.Description)
+	}
+
+	got := NewCreateOptions(WithDescription("first"), WithDescription("second"))
+	if got.Description != "second" {
+		t.Errorf("Description = %q, want %q (last option wins)", got.Description, "second")
+	}
+}
+
+func TestNewListOptions(t *testing.T) {
+	if NewListOptions().FullNames {
+		t.Error("NewListOptions() FullNames = true, want false by default")
+	}
+	if !NewListOptions(WithFullNames()).FullNames {
+		t.Error("NewListOptions(WithFullNames()) FullNames = false, want true")
+	}
+}
@@ -0,0 +1,220 @@
+// Package otel wraps a vaultmux.Backend so that every operation emits an
+// OpenTelemetry span.
+//
+//	backend = otel.NewTracingBackend(backend)
+//
+// Spans are named after the operation ("vaultmux.GetItem") and carry the
+// backend name and operation as attributes. Secret values are never
+// recorded; item names are recorded only with WithRecordNames.
+package otel
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+// tracerName identifies vaultmux as the instrumentation scope.
+const tracerName = "github.com/blackwell-systems/vaultmux/otel"
+
+// Attribute keys set on every span.
+const (
+	BackendKey   = attribute.Key("vaultmux.backend")   // Backend name, e.g. "awssecrets"
+	OperationKey = attribute.Key("vaultmux.operation") // Operation, e.g. "GetItem"
+	ItemKey      = attribute.Key("vaultmux.item")      // Item or location name (WithRecordNames only)
+)
+
+// Options holds optional settings for NewTracingBackend.
+type Options struct {
+	// TracerProvider creates the tracer (default: the global provider
+	// from otel.GetTracerProvider).
+	TracerProvider trace.TracerProvider
+
+	// RecordNames adds item and location names to spans. Leave it off
+	// where names are sensitive; error messages recorded on spans then
+	// have names replaced with vaultmux.MaskName tokens.
+	RecordNames bool
+}
+
+// Option configures Options.
+type Option func(*Options)
+
+// WithTracerProvider sets the tracer provider used instead of the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *Options) {
+		o.TracerProvider = tp
+	}
+}
+
+// WithRecordNames records item and location names on spans.
+func WithRecordNames() Option {
+	return func(o *Options) {
+		o.RecordNames = true
+	}
+}
+
+// TracingBackend wraps a Backend and starts a span around each operation
+// that takes a context. Close and Name are passed through untraced.
+//
+// Optional interfaces of the wrapped backend (ItemCreator, ItemSetter, ...)
+// are not exposed by the wrapper; type-assert the wrapped backend instead.
+type TracingBackend struct {
+	vaultmux.Backend
+	tracer      trace.Tracer
+	recordNames bool
+}
+
+// NewTracingBackend wraps b so that its operations are traced.
+func NewTracingBackend(b vaultmux.Backend, opts ...Option) *TracingBackend {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tp := o.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &TracingBackend{
+		Backend:     b,
+		tracer:      tp.Tracer(tracerName),
+		recordNames: o.RecordNames,
+	}
+}
+
+// start begins the span for op; name is the item or location it acts on,
+// or "" for operations on the whole backend.
+func (t *TracingBackend) start(ctx context.Context, op, name string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{BackendKey.String(t.Backend.Name()), OperationKey.String(op)}
+	if t.recordNames && name != "" {
+		attrs = append(attrs, ItemKey.String(name))
+	}
+	return t.tracer.Start(ctx, "vaultmux."+op, trace.WithAttributes(attrs...))
+}
+
+// end records err, if any, and ends the span. Unless names are recorded,
+// name is masked in the recorded error message.
+func (t *TracingBackend) end(span trace.Span, name string, err error) {
+	if err != nil {
+		msg := err.Error()
+		if !t.recordNames && name != "" {
+			msg = strings.ReplaceAll(msg, name, vaultmux.MaskName(name))
+		}
+		// span.RecordError would record the unmasked message
+		span.AddEvent("exception", trace.WithAttributes(
+			attribute.String("exception.message", msg),
+		))
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
+
+// Init initializes the backend.
+func (t *TracingBackend) Init(ctx context.Context) (err error) {
+	ctx, span := t.start(ctx, "Init", "")
+	defer func() { t.end(span, "", err) }()
+	return t.Backend.Init(ctx)
+}
+
+// IsAuthenticated reports whether the backend is authenticated.
+func (t *TracingBackend) IsAuthenticated(ctx context.Context) bool {
+	ctx, span := t.start(ctx, "IsAuthenticated", "")
+	defer span.End()
+	return t.Backend.IsAuthenticated(ctx)
+}
+
+// Authenticate authenticates with the backend.
+func (t *TracingBackend) Authenticate(ctx context.Context) (_ vaultmux.Session, err error) {
+	ctx, span := t.start(ctx, "Authenticate", "")
+	defer func() { t.end(span, "", err) }()
+	return t.Backend.Authenticate(ctx)
+}
+
+// Sync pulls the latest data from the server.
+func (t *TracingBackend) Sync(ctx context.Context, session vaultmux.Session) (err error) {
+	ctx, span := t.start(ctx, "Sync", "")
+	defer func() { t.end(span, "", err) }()
+	return t.Backend.Sync(ctx, session)
+}
+
+// GetItem retrieves an item.
+func (t *TracingBackend) GetItem(ctx context.Context, name string, session vaultmux.Session) (_ *vaultmux.Item, err error) {
+	ctx, span := t.start(ctx, "GetItem", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.GetItem(ctx, name, session)
+}
+
+// GetNotes retrieves the notes of an item.
+func (t *TracingBackend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (_ string, err error) {
+	ctx, span := t.start(ctx, "GetNotes", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.GetNotes(ctx, name, session)
+}
+
+// ItemExists checks whether an item exists.
+func (t *TracingBackend) ItemExists(ctx context.Context, name string, session vaultmux.Session) (_ bool, err error) {
+	ctx, span := t.start(ctx, "ItemExists", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.ItemExists(ctx, name, session)
+}
+
+// ListItems lists all items.
+func (t *TracingBackend) ListItems(ctx context.Context, session vaultmux.Session) (_ []*vaultmux.Item, err error) {
+	ctx, span := t.start(ctx, "ListItems", "")
+	defer func() { t.end(span, "", err) }()
+	return t.Backend.ListItems(ctx, session)
+}
+
+// CreateItem creates an item.
+func (t *TracingBackend) CreateItem(ctx context.Context, name, content string, session vaultmux.Session) (err error) {
+	ctx, span := t.start(ctx, "CreateItem", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.CreateItem(ctx, name, content, session)
+}
+
+// UpdateItem updates an item.
+func (t *TracingBackend) UpdateItem(ctx context.Context, name, content string, session vaultmux.Session) (err error) {
+	ctx, span := t.start(ctx, "UpdateItem", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.UpdateItem(ctx, name, content, session)
+}
+
+// DeleteItem deletes an item.
+func (t *TracingBackend) DeleteItem(ctx context.Context, name string, session vaultmux.Session) (err error) {
+	ctx, span := t.start(ctx, "DeleteItem", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.DeleteItem(ctx, name, session)
+}
+
+// ListLocations lists locations.
+func (t *TracingBackend) ListLocations(ctx context.Context, session vaultmux.Session) (_ []string, err error) {
+	ctx, span := t.start(ctx, "ListLocations", "")
+	defer func() { t.end(span, "", err) }()
+	return t.Backend.ListLocations(ctx, session)
+}
+
+// LocationExists checks whether a location exists.
+func (t *TracingBackend) LocationExists(ctx context.Context, name string, session vaultmux.Session) (_ bool, err error) {
+	ctx, span := t.start(ctx, "LocationExists", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.LocationExists(ctx, name, session)
+}
+
+// CreateLocation creates a location.
+func (t *TracingBackend) CreateLocation(ctx context.Context, name string, session vaultmux.Session) (err error) {
+	ctx, span := t.start(ctx, "CreateLocation", name)
+	defer func() { t.end(span, name, err) }()
+	return t.Backend.CreateLocation(ctx, name, session)
+}
+
+// ListItemsInLocation lists the items in a location.
+func (t *TracingBackend) ListItemsInLocation(ctx context.Context, locType, locValue string, session vaultmux.Session) (_ []*vaultmux.Item, err error) {
+	ctx, span := t.start(ctx, "ListItemsInLocation", locValue)
+	defer func() { t.end(span, locValue, err) }()
+	return t.Backend.ListItemsInLocation(ctx, locType, locValue, session)
+}
@@ -0,0 +1,112 @@
+package otel_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+	vaultmuxotel "github.com/blackwell-systems/vaultmux/otel"
+)
+
+// newRecorder installs an in-memory exporter as the global tracer provider.
+func newRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return exporter
+}
+
+func attrs(s tracetest.SpanStub) map[attribute.Key]string {
+	m := make(map[attribute.Key]string)
+	for _, kv := range s.Attributes {
+		m[kv.Key] = kv.Value.Emit()
+	}
+	return m
+}
+
+func TestTracingBackend_GetItem(t *testing.T) {
+	exporter := newRecorder(t)
+	ctx := context.Background()
+
+	inner := mock.New()
+	inner.SetItem("db-password", "hunter2")
+	backend := vaultmuxotel.NewTracingBackend(inner)
+
+	if _, err := backend.GetItem(ctx, "db-password", nil); err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "vaultmux.GetItem" {
+		t.Errorf("span name = %q, want vaultmux.GetItem", span.Name)
+	}
+	got := attrs(span)
+	if got[vaultmuxotel.BackendKey] != "mock" || got[vaultmuxotel.OperationKey] != "GetItem" {
+		t.Errorf("span attributes = %v, want backend mock and operation GetItem", got)
+	}
+	if _, ok := got[vaultmuxotel.ItemKey]; ok {
+		t.Error("item name recorded without WithRecordNames")
+	}
+	for _, v := range got {
+		if strings.Contains(v, "hunter2") {
+			t.Errorf("secret value recorded in attribute %q", v)
+		}
+	}
+}
+
+func TestTracingBackend_Errors(t *testing.T) {
+	exporter := newRecorder(t)
+	ctx := context.Background()
+	backend := vaultmuxotel.NewTracingBackend(wrappingBackend{mock.New()})
+
+	if _, err := backend.GetNotes(ctx, "customer-4711", nil); err == nil {
+		t.Fatal("GetNotes() of a missing item succeeded")
+	}
+
+	span := exporter.GetSpans()[0]
+	if span.Status.Code != codes.Error {
+		t.Errorf("span status = %v, want Error", span.Status.Code)
+	}
+	if strings.Contains(span.Status.Description, "customer-4711") {
+		t.Errorf("status %q contains the item name", span.Status.Description)
+	}
+	if !strings.Contains(span.Status.Description, vaultmux.MaskName("customer-4711")) {
+		t.Errorf("status %q does not contain the masked name", span.Status.Description)
+	}
+}
+
+func TestTracingBackend_RecordNames(t *testing.T) {
+	exporter := newRecorder(t)
+	backend := vaultmuxotel.NewTracingBackend(mock.New(), vaultmuxotel.WithRecordNames())
+
+	_ = backend.CreateItem(context.Background(), "api-key", "secret", nil)
+
+	if got := attrs(exporter.GetSpans()[0])[vaultmuxotel.ItemKey]; got != "api-key" {
+		t.Errorf("item attribute = %q, want api-key", got)
+	}
+}
+
+// wrappingBackend wraps mock errors the way real backends do, with the
+// item name in the message.
+type wrappingBackend struct {
+	*mock.Backend
+}
+
+func (b wrappingBackend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	notes, err := b.Backend.GetNotes(ctx, name, session)
+	return notes, vaultmux.WrapError(b.Name(), "get", name, err)
+}
@@ -0,0 +1,93 @@
+package vaultmux
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPEM indicates a secret value is not valid PEM of the expected type.
+var ErrInvalidPEM = errors.New("invalid PEM")
+
+// GetCertificate reads a secret and parses its first CERTIFICATE PEM block.
+func GetCertificate(ctx context.Context, b Backend, name string, session Session) (*x509.Certificate, error) {
+	block, err := getPEMBlock(ctx, b, name, session, "CERTIFICATE")
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, WrapError(b.Name(), "parse-certificate", name, fmt.Errorf("%w: %v", ErrInvalidPEM, err))
+	}
+	return cert, nil
+}
+
+// GetPrivateKey reads a secret and parses its first private key PEM block.
+// PKCS#1 RSA, SEC 1 EC and PKCS#8 keys are supported; the result is an
+// *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey.
+func GetPrivateKey(ctx context.Context, b Backend, name string, session Session) (crypto.PrivateKey, error) {
+	block, err := getPEMBlock(ctx, b, name, session, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var key crypto.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, WrapError(b.Name(), "parse-private-key", name,
+			fmt.Errorf("%w: unsupported block type %q", ErrInvalidPEM, block.Type))
+	}
+	if err != nil {
+		return nil, WrapError(b.Name(), "parse-private-key", name, fmt.Errorf("%w: %v", ErrInvalidPEM, err))
+	}
+	return key, nil
+}
+
+// getPEMBlock reads a secret and returns the first PEM block of blockType.
+// An empty blockType matches the first block whose type ends in "PRIVATE KEY".
+func getPEMBlock(ctx context.Context, b Backend, name string, session Session, blockType string) (*pem.Block, error) {
+	value, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := []byte(value)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if blockType == "" && isPrivateKeyType(block.Type) {
+			return block, nil
+		}
+		if block.Type == blockType {
+			return block, nil
+		}
+	}
+
+	want := blockType
+	if want == "" {
+		want = "PRIVATE KEY"
+	}
+	return nil, WrapError(b.Name(), "parse-pem", name, fmt.Errorf("%w: no %s block found", ErrInvalidPEM, want))
+}
+
+// isPrivateKeyType reports whether a PEM block type holds a private key.
+func isPrivateKeyType(t string) bool {
+	switch t {
+	case "RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package vaultmux_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+// selfSignedPEM returns a self-signed certificate and its EC key, both PEM-encoded.
+func selfSignedPEM(t *testing.T, commonName string) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestGetCertificate(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	certPEM, keyPEM := selfSignedPEM(t, "api.example.com")
+	backend.SetItem("tls-cert", certPEM)
+	backend.SetItem("tls-bundle", keyPEM+certPEM) // key first, cert second
+	backend.SetItem("not-pem", "just a password")
+
+	t.Run("parses certificate", func(t *testing.T) {
+		cert, err := vaultmux.GetCertificate(ctx, backend, "tls-cert", session)
+		if err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+		if cert.Subject.CommonName != "api.example.com" {
+			t.Errorf("Subject.CommonName = %q, want %q", cert.Subject.CommonName, "api.example.com")
+		}
+	})
+
+	t.Run("skips other blocks", func(t *testing.T) {
+		cert, err := vaultmux.GetCertificate(ctx, backend, "tls-bundle", session)
+		if err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+		if cert.Subject.CommonName != "api.example.com" {
+			t.Errorf("Subject.CommonName = %q, want %q", cert.Subject.CommonName, "api.example.com")
+		}
+	})
+
+	t.Run("non-PEM value", func(t *testing.T) {
+		_, err := vaultmux.GetCertificate(ctx, backend, "not-pem", session)
+		if !errors.Is(err, vaultmux.ErrInvalidPEM) {
+			t.Errorf("GetCertificate() error = %v, want ErrInvalidPEM", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := vaultmux.GetCertificate(ctx, backend, "missing", session)
+		if !errors.Is(err, vaultmux.ErrNotFound) {
+			t.Errorf("GetCertificate() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestGetPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	certPEM, keyPEM := selfSignedPEM(t, "api.example.com")
+	backend.SetItem("tls-bundle", certPEM+keyPEM)
+	backend.SetItem("cert-only", certPEM)
+
+	key, err := vaultmux.GetPrivateKey(ctx, backend, "tls-bundle", session)
+	if err != nil {
+		t.Fatalf("GetPrivateKey() error = %v", err)
+	}
+	if _, ok := key.(*ecdsa.PrivateKey); !ok {
+		t.Errorf("GetPrivateKey() returned %T, want *ecdsa.PrivateKey", key)
+	}
+
+	if _, err := vaultmux.GetPrivateKey(ctx, backend, "cert-only", session); !errors.Is(err, vaultmux.ErrInvalidPEM) {
+		t.Errorf("GetPrivateKey() error = %v, want ErrInvalidPEM", err)
+	}
+}
@@ -0,0 +1,85 @@
+package vaultmux
+
+import "context"
+
+// pinWorkers bounds the concurrent reads made by PinnedSession.
+const pinWorkers = 4
+
+// VersionReader is implemented by backends that can read a specific
+// version of a secret (GCP).
+type VersionReader interface {
+	// GetItemVersion reads the given version of the named secret, as
+	// reported in Item.Version.
+	GetItemVersion(ctx context.Context, name, version string, session Session) (*Item, error)
+}
+
+// pinnedSession is a Session whose reads return the state captured by
+// PinnedSession. Everything else is delegated to the session it wraps.
+type pinnedSession struct {
+	Session
+	reader   VersionReader     // Reads pinned versions; nil if values are cached
+	versions map[string]string // Name -> pinned version ID
+	items    map[string]*Item  // Name -> cached item, for unversioned backends
+}
+
+// PinnedSession captures the current state of every item in b and returns
+// a read-only view of session pinned to it, for reproducible reads during
+// a deployment. GetItem and GetNotes called with the returned session
+// return the captured state even if secrets are updated in the meantime,
+// and ErrNotFound for items created since.
+//
+// Backends implementing VersionReader record version IDs and read those
+// versions on demand; for other backends the items, values included, are
+// cached in memory until the session is dropped. Pin the backend the reads
+// are sent to: names are matched as that backend reports them, so pinning
+// through a NameTransformer or ValueTransformer is not supported.
+func PinnedSession(ctx context.Context, b Backend, session Session) (Session, error) {
+	names, err := ListItemNames(ctx, b, session)
+	if err != nil {
+		return nil, err
+	}
+	items, err := fetchItems(ctx, b, session, names, pinWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pinnedSession{
+		Session:  session,
+		versions: make(map[string]string),
+		items:    make(map[string]*Item),
+	}
+	p.reader, _ = b.(VersionReader)
+	for _, item := range items {
+		if p.reader != nil && item.Version != "" {
+			p.versions[item.Name] = item.Version
+		} else {
+			p.items[item.Name] = item
+		}
+	}
+	return p, nil
+}
+
+// PinnedRead serves a read of name made with a session from PinnedSession.
+// Backends call it at the start of GetItem; ok is false for other sessions,
+// and the backend reads as usual.
+func PinnedRead(ctx context.Context, session Session, name string) (item *Item, ok bool, err error) {
+	p, ok := session.(*pinnedSession)
+	if !ok {
+		return nil, false, nil
+	}
+	if version, found := p.versions[name]; found {
+		item, err = p.reader.GetItemVersion(ctx, name, version, p.Session)
+		return item, true, err
+	}
+	cached, found := p.items[name]
+	if !found {
+		return nil, true, ErrNotFound
+	}
+	return cloneItem(cached), true, nil
+}
+
+// isPinned reports whether session came from PinnedSession.
+func isPinned(session Session) bool {
+	_, ok := session.(*pinnedSession)
+	return ok
+}
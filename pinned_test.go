@@ -0,0 +1,38 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestPinnedSession_CachedValues(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("db-password", "v1")
+	session, _ := backend.Authenticate(ctx)
+
+	pinned, err := vaultmux.PinnedSession(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("PinnedSession() error = %v", err)
+	}
+
+	backend.SetItem("db-password", "v2")
+	backend.SetItem("api-key", "new")
+
+	if notes, err := backend.GetNotes(ctx, "db-password", pinned); err != nil || notes != "v1" {
+		t.Errorf("pinned GetNotes() = %q, %v; want v1", notes, err)
+	}
+	if _, err := backend.GetNotes(ctx, "api-key", pinned); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("pinned GetNotes(api-key) error = %v, want ErrNotFound", err)
+	}
+	if notes, _ := backend.GetNotes(ctx, "db-password", session); notes != "v2" {
+		t.Errorf("unpinned GetNotes() = %q, want v2", notes)
+	}
+	if !pinned.IsValid(ctx) {
+		t.Error("pinned session is not valid")
+	}
+}
@@ -0,0 +1,35 @@
+package vaultmux
+
+import "context"
+
+// AccessPolicy lists who can access a secret, normalized across providers.
+type AccessPolicy struct {
+	Bindings []Binding
+}
+
+// Binding grants Role to Members. Role and member strings are provider
+// specific: a GCP role ("roles/secretmanager.secretAccessor") and IAM
+// members ("user:a@example.com"), or an AWS action
+// ("secretsmanager:GetSecretValue") and principal ARNs.
+type Binding struct {
+	Role    string
+	Members []string
+}
+
+// AccessPolicyReader is implemented by backends that can report the access
+// policy attached to a secret.
+type AccessPolicyReader interface {
+	GetAccessPolicy(ctx context.Context, name string, session Session) (AccessPolicy, error)
+}
+
+// GetAccessPolicy returns the access policy on the named secret, for
+// security audits. Only the policy attached to the secret itself is
+// reported; access inherited from a project, account or vault is not.
+// Backends that don't implement AccessPolicyReader return ErrNotSupported.
+func GetAccessPolicy(ctx context.Context, b Backend, name string, session Session) (AccessPolicy, error) {
+	reader, ok := b.(AccessPolicyReader)
+	if !ok {
+		return AccessPolicy{}, WrapError(b.Name(), "get-policy", name, ErrNotSupported)
+	}
+	return reader.GetAccessPolicy(ctx, name, session)
+}
@@ -0,0 +1,42 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+// policyBackend reports a fixed access policy.
+type policyBackend struct {
+	*mock.Backend
+	policy vaultmux.AccessPolicy
+}
+
+func (b policyBackend) GetAccessPolicy(ctx context.Context, name string, session vaultmux.Session) (vaultmux.AccessPolicy, error) {
+	return b.policy, nil
+}
+
+func TestGetAccessPolicy(t *testing.T) {
+	ctx := context.Background()
+	want := vaultmux.AccessPolicy{Bindings: []vaultmux.Binding{
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:a@example.com"}},
+	}}
+
+	got, err := vaultmux.GetAccessPolicy(ctx, policyBackend{mock.New(), want}, "db", nil)
+	if err != nil {
+		t.Fatalf("GetAccessPolicy() error = %v", err)
+	}
+	if len(got.Bindings) != 1 || got.Bindings[0].Role != want.Bindings[0].Role {
+		t.Errorf("GetAccessPolicy() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetAccessPolicy_NotSupported(t *testing.T) {
+	_, err := vaultmux.GetAccessPolicy(context.Background(), mock.New(), "db", nil)
+	if !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("GetAccessPolicy() error = %v, want ErrNotSupported", err)
+	}
+}
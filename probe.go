@@ -0,0 +1,50 @@
+package vaultmux
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultInitTimeout bounds the connectivity check cloud backends make in
+// Init when Config.InitTimeout is not set.
+const DefaultInitTimeout = 10 * time.Second
+
+// ProbeConnectivity runs probe, a backend's Init connectivity check, with a
+// context that ends after timeout (DefaultInitTimeout if 0), so a
+// black-holed endpoint fails Init instead of hanging until ctx ends. If the
+// timeout fires, the returned error wraps context.DeadlineExceeded.
+//
+// If probe fails with a provider-suggested delay (see RetryAfter), such as
+// a GCP quota error, it is retried after waiting at least that long, as
+// long as the wait ends before the timeout. Otherwise the error is returned
+// at once.
+func ProbeConnectivity(ctx context.Context, timeout time.Duration, probe func(ctx context.Context) error) error {
+	timeout = cmp.Or(timeout, DefaultInitTimeout)
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := probe(probeCtx)
+		if err != nil && ctx.Err() == nil && errors.Is(probeCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("no response within %s: %w", timeout, context.DeadlineExceeded)
+		}
+		delay, ok := RetryAfter(err)
+		if !ok {
+			return err
+		}
+		if deadline, _ := probeCtx.Deadline(); time.Until(deadline) <= delay {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-probeCtx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+)
+
+func TestProbeConnectivity_HonorsRetryAfter(t *testing.T) {
+	quota := &vaultmux.BackendError{Backend: "test", Op: "init", Err: errors.New("quota exhausted"), RetryAfter: 50 * time.Millisecond}
+
+	calls := 0
+	start := time.Now()
+	err := vaultmux.ProbeConnectivity(context.Background(), time.Second, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return quota
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ProbeConnectivity() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("probe ran %d times, want 2", calls)
+	}
+	if elapsed := time.Since(start); elapsed < quota.RetryAfter {
+		t.Errorf("retried after %s, want at least %s", elapsed, quota.RetryAfter)
+	}
+}
+
+func TestProbeConnectivity_RetryAfterPastTimeout(t *testing.T) {
+	quota := &vaultmux.BackendError{Backend: "test", Op: "init", Err: errors.New("quota exhausted"), RetryAfter: time.Minute}
+
+	calls := 0
+	err := vaultmux.ProbeConnectivity(context.Background(), 100*time.Millisecond, func(ctx context.Context) error {
+		calls++
+		return quota
+	})
+	if !errors.Is(err, quota) || calls != 1 {
+		t.Errorf("ProbeConnectivity() = %v after %d probes, want the quota error after 1", err, calls)
+	}
+}
@@ -0,0 +1,35 @@
+package vaultmux
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ParseProxyURL parses the proxy_url option accepted by the SDK backends.
+// The scheme must be http, https, socks5 or socks5h, and a host is required.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, &ConfigError{Field: "proxy_url", Reason: fmt.Sprintf("%q: %v", raw, err)}
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, &ConfigError{Field: "proxy_url", Reason: fmt.Sprintf("%q: scheme must be http, https, socks5 or socks5h", raw)}
+	}
+	if u.Host == "" {
+		return nil, &ConfigError{Field: "proxy_url", Reason: fmt.Sprintf("%q: missing host", raw)}
+	}
+
+	return u, nil
+}
+
+// ProxyTransport returns a clone of http.DefaultTransport that sends every
+// request through proxy, regardless of the HTTP(S)_PROXY environment.
+func ProxyTransport(proxy *url.URL) *http.Transport {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = http.ProxyURL(proxy)
+	return tr
+}
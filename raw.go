@@ -0,0 +1,41 @@
+package vaultmux
+
+import "context"
+
+// RawItemAccessor is implemented by backends that namespace item names with
+// a prefix, to reach secrets by their full stored name.
+//
+// These methods bypass namespacing: no prefix is added, and wrappers such
+// as NameTransformer and ValueTransformer do not apply. They can read or
+// delete secrets belonging to other applications, so keep them to
+// administrative tooling.
+type RawItemAccessor interface {
+	// GetItemRaw retrieves the secret stored as storedName. The returned
+	// item is named storedName.
+	GetItemRaw(ctx context.Context, storedName string, session Session) (*Item, error)
+
+	// DeleteItemRaw deletes the secret stored as storedName.
+	DeleteItemRaw(ctx context.Context, storedName string, session Session) error
+}
+
+// GetItemRaw retrieves a secret by its full stored name, bypassing the
+// backend prefix. See RawItemAccessor. Backends that don't implement it
+// return ErrNotSupported.
+func GetItemRaw(ctx context.Context, b Backend, storedName string, session Session) (*Item, error) {
+	raw, ok := b.(RawItemAccessor)
+	if !ok {
+		return nil, WrapError(b.Name(), "get-raw", storedName, ErrNotSupported)
+	}
+	return raw.GetItemRaw(ctx, storedName, session)
+}
+
+// DeleteItemRaw deletes a secret by its full stored name, bypassing the
+// backend prefix. See RawItemAccessor. Backends that don't implement it
+// return ErrNotSupported.
+func DeleteItemRaw(ctx context.Context, b Backend, storedName string, session Session) error {
+	raw, ok := b.(RawItemAccessor)
+	if !ok {
+		return WrapError(b.Name(), "delete-raw", storedName, ErrNotSupported)
+	}
+	return raw.DeleteItemRaw(ctx, storedName, session)
+}
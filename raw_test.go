@@ -0,0 +1,22 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestGetItemRaw_NotSupported(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+
+	if _, err := vaultmux.GetItemRaw(ctx, backend, "any", nil); !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("GetItemRaw() error = %v, want ErrNotSupported", err)
+	}
+	if err := vaultmux.DeleteItemRaw(ctx, backend, "any", nil); !errors.Is(err, vaultmux.ErrNotSupported) {
+		t.Errorf("DeleteItemRaw() error = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,142 @@
+package vaultmux
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redactedText replaces secret values in redacted output.
+const redactedText = "***"
+
+// minRedactLen is the shortest value a Redactor will register; shorter
+// values would redact unrelated text.
+const minRedactLen = 4
+
+// Redactor replaces known secret values with "***" in strings and log
+// output. Values are registered with Add, or automatically as they are read
+// through a backend returned by Wrap. At most maxValues values are kept;
+// the oldest are dropped first. It is safe for concurrent use.
+type Redactor struct {
+	mu        sync.RWMutex
+	maxValues int
+	values    []string // Registration order, oldest first
+	replacer  *strings.Replacer
+}
+
+// NewRedactor creates a Redactor that remembers up to maxValues values.
+func NewRedactor(maxValues int) *Redactor {
+	return &Redactor{maxValues: maxValues}
+}
+
+// Add registers a secret value. Values shorter than 4 bytes are ignored.
+func (r *Redactor) Add(value string) {
+	if len(value) < minRedactLen {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, v := range r.values {
+		if v == value {
+			// Move to the end so frequently fetched values are kept
+			r.values = append(append(r.values[:i:i], r.values[i+1:]...), value)
+			return
+		}
+	}
+
+	r.values = append(r.values, value)
+	if len(r.values) > r.maxValues {
+		r.values = r.values[len(r.values)-r.maxValues:]
+	}
+	r.replacer = nil
+}
+
+// Redact returns s with every registered value replaced by "***".
+// It can be used directly as a func(string) string.
+func (r *Redactor) Redact(s string) string {
+	r.mu.RLock()
+	replacer := r.replacer
+	empty := len(r.values) == 0
+	r.mu.RUnlock()
+
+	if empty {
+		return s
+	}
+	if replacer == nil {
+		replacer = r.buildReplacer()
+	}
+	return replacer.Replace(s)
+}
+
+// buildReplacer creates and caches a replacer for the current values.
+func (r *Redactor) buildReplacer() *strings.Replacer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.replacer != nil {
+		return r.replacer
+	}
+
+	// Longest first, so a value containing another is redacted whole
+	values := append([]string(nil), r.values...)
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	pairs := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		pairs = append(pairs, v, redactedText)
+	}
+	r.replacer = strings.NewReplacer(pairs...)
+	return r.replacer
+}
+
+// Writer returns an io.Writer that redacts each write before passing it to
+// w. Values split across separate writes are not detected, which suits
+// loggers that write one line per call.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	return &redactWriter{r: r, w: w}
+}
+
+type redactWriter struct {
+	r *Redactor
+	w io.Writer
+}
+
+func (rw *redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(rw.w, rw.r.Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Wrap returns a Backend that registers every value read through GetItem or
+// GetNotes with the Redactor before returning it.
+func (r *Redactor) Wrap(b Backend) Backend {
+	return &redactingBackend{Backend: b, r: r}
+}
+
+type redactingBackend struct {
+	Backend
+	r *Redactor
+}
+
+func (b *redactingBackend) GetItem(ctx context.Context, name string, session Session) (*Item, error) {
+	item, err := b.Backend.GetItem(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+	b.r.Add(item.Notes)
+	return item, nil
+}
+
+func (b *redactingBackend) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	notes, err := b.Backend.GetNotes(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	b.r.Add(notes)
+	return notes, nil
+}
@@ -0,0 +1,61 @@
+package vaultmux_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestRedactor_FetchedValue(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("db-password", "hunter2-prod")
+	session, _ := backend.Authenticate(ctx)
+
+	r := vaultmux.NewRedactor(100)
+	wrapped := r.Wrap(backend)
+
+	value, err := wrapped.GetNotes(ctx, "db-password", session)
+	if err != nil {
+		t.Fatalf("GetNotes() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	logger := log.New(r.Writer(&buf), "", 0)
+	logger.Printf("connecting with password=%s to db.internal", value)
+
+	if got, want := buf.String(), "connecting with password=*** to db.internal\n"; got != want {
+		t.Errorf("log output = %q, want %q", got, want)
+	}
+	if got := r.Redact("nothing secret here"); got != "nothing secret here" {
+		t.Errorf("Redact() changed unrelated text: %q", got)
+	}
+}
+
+func TestRedactor_Bounded(t *testing.T) {
+	r := vaultmux.NewRedactor(2)
+	r.Add("first-secret")
+	r.Add("second-secret")
+	r.Add("third-secret")
+	r.Add("abc") // too short to register
+
+	got := r.Redact("first-secret second-secret third-secret abc")
+	if want := "first-secret *** *** abc"; got != want {
+		t.Errorf("Redact() = %q, want %q", got, want)
+	}
+}
+
+func TestRedactor_OverlappingValues(t *testing.T) {
+	r := vaultmux.NewRedactor(10)
+	r.Add("token")
+	r.Add("token-with-suffix")
+
+	if got := r.Redact("x token-with-suffix y"); strings.Contains(got, "suffix") {
+		t.Errorf("Redact() = %q, longer value not fully redacted", got)
+	}
+}
@@ -0,0 +1,127 @@
+package vaultmux
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+)
+
+// RefreshingSecret holds a secret value that is re-read from the backend
+// on a fixed interval. Get is lock-free, so it can sit on a hot path.
+type RefreshingSecret struct {
+	value atomic.Value // string
+	err   atomic.Pointer[refreshError]
+	next  atomic.Int64 // Unix nanoseconds of the next scheduled refresh
+}
+
+// RefreshOptions holds optional settings for NewRefreshingSecret.
+type RefreshOptions struct {
+	// Jitter randomizes each refresh interval by up to this fraction in
+	// either direction, e.g. 0.1 for ±10%, so that many instances started
+	// together don't refresh the same secret at the same moment. Values
+	// are clamped to [0, 1].
+	Jitter float64
+
+	// Rand returns a uniformly distributed number in [0, 1) used for
+	// jitter (default: math/rand/v2.Float64). Tests set it to make the
+	// schedule deterministic.
+	Rand func() float64
+}
+
+// RefreshOption configures RefreshOptions.
+type RefreshOption func(*RefreshOptions)
+
+// WithJitter randomizes refresh intervals by up to ±fraction.
+func WithJitter(fraction float64) RefreshOption {
+	return func(o *RefreshOptions) {
+		o.Jitter = fraction
+	}
+}
+
+// WithRandSource sets the random source used for jitter.
+func WithRandSource(rand func() float64) RefreshOption {
+	return func(o *RefreshOptions) {
+		o.Rand = rand
+	}
+}
+
+// Jittered returns d randomized by up to ±fraction of its length, using r,
+// a uniformly distributed number in [0, 1). A fraction of 0 returns d.
+func Jittered(d time.Duration, fraction, r float64) time.Duration {
+	fraction = min(max(fraction, 0), 1)
+	return d + time.Duration(float64(d)*fraction*(2*r-1))
+}
+
+// refreshError boxes an error, since atomic.Pointer needs a concrete type.
+type refreshError struct{ err error }
+
+// NewRefreshingSecret reads the notes of name and then re-reads them every
+// interval in a background goroutine until ctx is cancelled. The initial
+// read must succeed. A failed refresh keeps serving the last good value and
+// is reported by Err until a later refresh succeeds.
+//
+// With WithJitter, each wait is drawn anew from the jitter band around
+// interval, so instances drift apart instead of refreshing in lockstep.
+func NewRefreshingSecret(ctx context.Context, b Backend, session Session, name string, interval time.Duration, opts ...RefreshOption) (*RefreshingSecret, error) {
+	o := RefreshOptions{Rand: rand.Float64}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	notes, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RefreshingSecret{}
+	s.value.Store(notes)
+
+	schedule := func() time.Duration {
+		wait := Jittered(interval, o.Jitter, o.Rand())
+		s.next.Store(time.Now().Add(wait).UnixNano())
+		return wait
+	}
+	timer := time.NewTimer(schedule())
+
+	go func() {
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				timer.Reset(schedule())
+				notes, err := b.GetNotes(ctx, name, session)
+				if err != nil {
+					if ctx.Err() == nil {
+						s.err.Store(&refreshError{err})
+					}
+					continue
+				}
+				s.value.Store(notes)
+				s.err.Store(nil)
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// NextRefresh returns when the next refresh is scheduled.
+func (s *RefreshingSecret) NextRefresh() time.Time {
+	return time.Unix(0, s.next.Load())
+}
+
+// Get returns the most recently read value.
+func (s *RefreshingSecret) Get() string {
+	return s.value.Load().(string)
+}
+
+// Err returns the error from the last refresh, or nil if it succeeded.
+func (s *RefreshingSecret) Err() error {
+	if e := s.err.Load(); e != nil {
+		return e.err
+	}
+	return nil
+}
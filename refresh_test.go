@@ -0,0 +1,142 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+// eventually polls cond until it holds or a second passes.
+func eventually(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+// flakyBackend fails GetNotes while down is set. Unlike mock.Backend's
+// GetError, it is safe to toggle while a refresh goroutine is reading.
+type flakyBackend struct {
+	*mock.Backend
+	down atomic.Bool
+}
+
+func (b *flakyBackend) GetNotes(ctx context.Context, name string, session vaultmux.Session) (string, error) {
+	if b.down.Load() {
+		return "", errors.New("backend down")
+	}
+	return b.Backend.GetNotes(ctx, name, session)
+}
+
+func TestRefreshingSecret(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backend := &flakyBackend{Backend: mock.New()}
+	backend.SetItem("api-key", "v1")
+
+	secret, err := vaultmux.NewRefreshingSecret(ctx, backend, nil, "api-key", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewRefreshingSecret() error = %v", err)
+	}
+	if got := secret.Get(); got != "v1" {
+		t.Errorf("Get() = %q, want v1", got)
+	}
+
+	backend.SetItem("api-key", "v2")
+	if !eventually(t, func() bool { return secret.Get() == "v2" }) {
+		t.Errorf("Get() = %q after refresh interval, want v2", secret.Get())
+	}
+
+	// A failing refresh keeps the last good value and reports the error
+	backend.down.Store(true)
+	if !eventually(t, func() bool { return secret.Err() != nil }) {
+		t.Fatal("Err() = nil after failed refresh")
+	}
+	if got := secret.Get(); got != "v2" {
+		t.Errorf("Get() = %q during failures, want last good value v2", got)
+	}
+
+	backend.down.Store(false)
+	if !eventually(t, func() bool { return secret.Err() == nil }) {
+		t.Errorf("Err() = %v after successful refresh, want nil", secret.Err())
+	}
+}
+
+func TestRefreshingSecret_InitialError(t *testing.T) {
+	_, err := vaultmux.NewRefreshingSecret(context.Background(), mock.New(), nil, "missing", time.Second)
+	if !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("NewRefreshingSecret() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRefreshingSecret_Jitter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backend := mock.New()
+	backend.SetItem("api-key", "v1")
+
+	const (
+		interval = time.Hour
+		jitter   = 0.2
+		n        = 20
+	)
+
+	// Deterministic random source stepping evenly through [0, 1)
+	var calls int
+	rand := func() float64 {
+		calls++
+		return float64(calls%n) / n
+	}
+
+	low, high := time.Duration(float64(interval)*(1-jitter)), time.Duration(float64(interval)*(1+jitter))
+	shortest, longest := high, low
+	for range n {
+		start := time.Now()
+		secret, err := vaultmux.NewRefreshingSecret(ctx, backend, nil, "api-key", interval,
+			vaultmux.WithJitter(jitter), vaultmux.WithRandSource(rand))
+		if err != nil {
+			t.Fatalf("NewRefreshingSecret() error = %v", err)
+		}
+
+		wait := secret.NextRefresh().Sub(start)
+		if wait < low || wait > high+time.Second {
+			t.Errorf("next refresh in %v, want within [%v, %v]", wait, low, high)
+		}
+		shortest, longest = min(shortest, wait), max(longest, wait)
+	}
+
+	// Rather than all refreshing together, the secrets span most of the band
+	if spread := longest - shortest; spread < (high-low)*3/4 {
+		t.Errorf("next refreshes spread over %v, want close to the %v jitter band", spread, high-low)
+	}
+}
+
+func TestJittered(t *testing.T) {
+	tests := []struct {
+		fraction, r float64
+		want        time.Duration
+	}{
+		{fraction: 0, r: 0.9, want: 100 * time.Second},
+		{fraction: 0.1, r: 0, want: 90 * time.Second},
+		{fraction: 0.1, r: 0.5, want: 100 * time.Second},
+		{fraction: 0.5, r: 0.75, want: 125 * time.Second},
+		{fraction: 2, r: 0, want: 0}, // Clamped to ±100%
+	}
+	for _, tt := range tests {
+		if got := vaultmux.Jittered(100*time.Second, tt.fraction, tt.r); got != tt.want {
+			t.Errorf("Jittered(100s, %v, %v) = %v, want %v", tt.fraction, tt.r, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Capabilities lists the optional interfaces a backend implements.
+type Capabilities struct {
+	SetItem           bool `json:"set_item"`            // ItemSetter
+	UpdateResult      bool `json:"update_result"`       // ResultUpdater
+	CreateOptions     bool `json:"create_options"`      // ItemCreator
+	CreateInLocation  bool `json:"create_in_location"`  // LocationItemCreator
+	ListOptions       bool `json:"list_options"`        // ItemLister
+	ListItemNames     bool `json:"list_item_names"`     // ItemNameLister
+	ListModifiedSince bool `json:"list_modified_since"` // ModifiedSinceLister
+	StreamItems       bool `json:"stream_items"`        // ItemStreamer
+	Tags              bool `json:"tags"`                // TaggableBackend
+	RawItems          bool `json:"raw_items"`           // RawItemAccessor
+	AccessPolicy      bool `json:"access_policy"`       // AccessPolicyReader
+	Describe          bool `json:"describe"`            // Describer
+	Versions          bool `json:"versions"`            // VersionReader
+	Passwords         bool `json:"passwords"`           // PasswordManager
+	Lock              bool `json:"lock"`                // Locker
+	ServerTime        bool `json:"server_time"`         // ServerTimer
+	Identity          bool `json:"identity"`            // IdentityReporter
+}
+
+// BackendCapabilities reports which optional interfaces b implements.
+func BackendCapabilities(b Backend) Capabilities {
+	var c Capabilities
+	_, c.SetItem = b.(ItemSetter)
+	_, c.UpdateResult = b.(ResultUpdater)
+	_, c.CreateOptions = b.(ItemCreator)
+	_, c.CreateInLocation = b.(LocationItemCreator)
+	_, c.ListOptions = b.(ItemLister)
+	_, c.ListItemNames = b.(ItemNameLister)
+	_, c.ListModifiedSince = b.(ModifiedSinceLister)
+	_, c.StreamItems = b.(ItemStreamer)
+	_, c.Tags = b.(TaggableBackend)
+	_, c.RawItems = b.(RawItemAccessor)
+	_, c.AccessPolicy = b.(AccessPolicyReader)
+	_, c.Describe = b.(Describer)
+	_, c.Versions = b.(VersionReader)
+	_, c.Passwords = b.(PasswordManager)
+	_, c.Lock = b.(Locker)
+	_, c.ServerTime = b.(ServerTimer)
+	_, c.Identity = b.(IdentityReporter)
+	return c
+}
+
+// IdentityReporter is implemented by backends that can tell which account
+// or principal a session acts as.
+type IdentityReporter interface {
+	// WhoAmI returns a description of the authenticated identity, such as
+	// an account email or role ARN. It never includes credentials.
+	WhoAmI(ctx context.Context, session Session) (string, error)
+}
+
+// pingItem is the name ItemExists is asked about to check connectivity.
+const pingItem = "vaultmux-ping"
+
+// PingResult is the outcome of a connectivity check.
+type PingResult struct {
+	OK      bool   `json:"ok"`
+	Latency string `json:"latency"`         // e.g. "41.2ms"
+	Error   string `json:"error,omitempty"` // Set when OK is false
+}
+
+// BackendReport describes a configured backend for diagnostics and bug
+// reports. It holds no secret values, tokens or environment variable
+// values.
+type BackendReport struct {
+	Name          string        `json:"name"`
+	Capabilities  Capabilities  `json:"capabilities"`
+	Limits        Limits        `json:"limits"`
+	CaseSensitive bool          `json:"case_sensitive"`
+	Prefix        string        `json:"prefix,omitempty"`
+	Auth          AuthDiagnosis `json:"auth"`
+	Identity      string        `json:"identity,omitempty"`       // From WhoAmI, if implemented
+	IdentityError string        `json:"identity_error,omitempty"` // Set if WhoAmI failed
+	Ping          PingResult    `json:"ping"`
+}
+
+// JSON returns the report as indented JSON.
+func (r BackendReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Report gathers everything useful about b into one BackendReport: its
+// name, capabilities, limits, auth environment variables (names only), the
+// identity from WhoAmI if b implements IdentityReporter, and a ping that
+// checks for a nonexistent item. Failures of the checks are recorded in the
+// report; the returned error is set only if ctx ended before it was done.
+func Report(ctx context.Context, b Backend, session Session) (BackendReport, error) {
+	r := BackendReport{
+		Name:          b.Name(),
+		Capabilities:  BackendCapabilities(b),
+		Limits:        BackendLimits(b),
+		CaseSensitive: IsCaseSensitive(b),
+		Auth:          DiagnoseAuth(ctx, b),
+	}
+	if p, ok := b.(PrefixedBackend); ok {
+		r.Prefix = p.Prefix()
+	}
+	if id, ok := b.(IdentityReporter); ok {
+		identity, err := id.WhoAmI(ctx, session)
+		if err != nil {
+			r.IdentityError = err.Error()
+		}
+		r.Identity = identity
+	}
+
+	start := time.Now()
+	_, err := b.ItemExists(ctx, pingItem, session)
+	r.Ping = PingResult{OK: err == nil, Latency: time.Since(start).Round(100 * time.Microsecond).String()}
+	if err != nil {
+		r.Ping.Error = err.Error()
+	}
+	return r, ctx.Err()
+}
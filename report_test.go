@@ -0,0 +1,73 @@
+package vaultmux_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("db-password", "hunter2")
+	session, _ := backend.Authenticate(ctx)
+
+	r, err := vaultmux.Report(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if r.Name != "mock" {
+		t.Errorf("Name = %q, want mock", r.Name)
+	}
+	if !r.Ping.OK || r.Ping.Error != "" {
+		t.Errorf("Ping = %+v, want OK", r.Ping)
+	}
+	if r.Limits != vaultmux.BackendLimits(backend) {
+		t.Errorf("Limits = %+v, want %+v", r.Limits, vaultmux.BackendLimits(backend))
+	}
+	if r.Capabilities != vaultmux.BackendCapabilities(backend) {
+		t.Errorf("Capabilities = %+v, want %+v", r.Capabilities, vaultmux.BackendCapabilities(backend))
+	}
+
+	data, err := r.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	if _, ok := decoded["capabilities"].(map[string]any)["set_item"]; !ok {
+		t.Errorf("JSON %s lacks capability flags", data)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Error("report contains a secret value")
+	}
+}
+
+// unreachableBackend fails every ItemExists call.
+type unreachableBackend struct {
+	*mock.Backend
+}
+
+func (unreachableBackend) ItemExists(ctx context.Context, name string, session vaultmux.Session) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestReport_PingFailure(t *testing.T) {
+	ctx := context.Background()
+	backend := unreachableBackend{mock.New()}
+
+	r, err := vaultmux.Report(ctx, backend, nil)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if r.Ping.OK || !strings.Contains(r.Ping.Error, "connection refused") {
+		t.Errorf("Ping = %+v, want the ItemExists failure", r.Ping)
+	}
+}
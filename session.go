@@ -2,46 +2,120 @@ package vaultmux
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
 
 // SessionCache handles session persistence to disk.
+// Caches created by a SessionStore also keep sessions in memory, and skip the
+// disk entirely when their path is empty.
 type SessionCache struct {
-	path string
-	ttl  time.Duration
+	path  string
+	ttl   time.Duration
+	fs    fileSystem
+	clock Clock
+
+	store *SessionStore // Shared in-memory store (nil for disk-only caches)
+	key   string        // Key within store
+
+	fingerprint string // Current credential fingerprint ("" disables the check)
 }
 
 // CachedSession represents a persisted session.
 type CachedSession struct {
-	Token   string    `json:"token"`
-	Created time.Time `json:"created"`
-	Expires time.Time `json:"expires"`
-	Backend string    `json:"backend"`
+	Token       string    `json:"token"`
+	Created     time.Time `json:"created"`
+	Expires     time.Time `json:"expires"`
+	Backend     string    `json:"backend"`
+	Fingerprint string    `json:"fingerprint,omitempty"` // See CredentialFingerprint
+}
+
+// CredentialFingerprint hashes the parts that identify a credential, such
+// as an account email and server URL or an ARN, so a cached session can be
+// tied to an identity without storing the identity itself.
+func CredentialFingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileSystem abstracts the file operations used by SessionCache.
+type fileSystem interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	Remove(name string) error
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+// osFS implements fileSystem using the os package.
+type osFS struct{}
+
+func (osFS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+func (osFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
 }
+func (osFS) Remove(name string) error                     { return os.Remove(name) }
+func (osFS) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
 
 // NewSessionCache creates a session cache.
 // The parent directory is created with 0700 permissions for security.
 func NewSessionCache(path string, ttl time.Duration) *SessionCache {
-	// Ensure parent directory exists with restricted permissions
-	dir := filepath.Dir(path)
-	// Ignore error here since this is initialization; actual errors
-	// will surface during Load() or Save() operations
-	_ = os.MkdirAll(dir, 0700)
+	return newSessionCache(path, ttl, osFS{})
+}
+
+func newSessionCache(path string, ttl time.Duration, fs fileSystem) *SessionCache {
+	if path != "" {
+		// Ensure parent directory exists with restricted permissions
+		dir := filepath.Dir(path)
+		// Ignore error here since this is initialization; actual errors
+		// will surface during Load() or Save() operations
+		_ = fs.MkdirAll(dir, 0700)
+	}
 
 	return &SessionCache{
-		path: path,
-		ttl:  ttl,
+		path:  path,
+		ttl:   ttl,
+		fs:    fs,
+		clock: SystemClock(),
 	}
 }
 
-// Load reads a cached session from disk.
+// SetClock sets the clock used for session expiry.
+func (c *SessionCache) SetClock(clock Clock) {
+	c.clock = clock
+}
+
+// SetFingerprint sets the fingerprint of the credential currently in use
+// (see CredentialFingerprint). Save records it, and Load discards a cached
+// session recorded under a different fingerprint, so a session left over
+// from another account is never reused. An empty fingerprint, the default,
+// skips the check.
+func (c *SessionCache) SetFingerprint(fingerprint string) {
+	c.fingerprint = fingerprint
+}
+
+// Load reads a cached session, from memory if the cache belongs to a
+// SessionStore that already holds it, otherwise from disk.
 func (c *SessionCache) Load() (*CachedSession, error) {
-	data, err := os.ReadFile(c.path)
+	if c.store != nil {
+		if session := c.store.get(c.key, c.clock.Now()); session != nil {
+			if !c.matchesFingerprint(session) {
+				return nil, c.Clear()
+			}
+			return session, nil
+		}
+		if c.path == "" {
+			return nil, nil
+		}
+	}
+
+	data, err := c.fs.ReadFile(c.path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil // No cached session
@@ -52,59 +126,171 @@ func (c *SessionCache) Load() (*CachedSession, error) {
 	var session CachedSession
 	if err := json.Unmarshal(data, &session); err != nil {
 		// Invalid cache - try to remove it (ignore removal errors)
-		_ = os.Remove(c.path)
+		_ = c.fs.Remove(c.path)
 		return nil, fmt.Errorf("parse session cache: %w", err)
 	}
 
-	// Check if expired
-	if time.Now().After(session.Expires) {
-		// Remove expired session (ignore removal errors)
-		_ = os.Remove(c.path)
+	// Check if expired or saved under another identity
+	if c.clock.Now().After(session.Expires) || !c.matchesFingerprint(&session) {
+		// Remove stale session (ignore removal errors)
+		_ = c.fs.Remove(c.path)
 		return nil, nil
 	}
 
+	if c.store != nil {
+		c.store.set(c.key, &session)
+	}
+
 	return &session, nil
 }
 
 // Save writes a session to disk.
 // The session file is created with 0600 permissions (owner read/write only).
 func (c *SessionCache) Save(token, backend string) error {
+	now := c.clock.Now()
+	session := CachedSession{
+		Token:       token,
+		Created:     now,
+		Expires:     now.Add(c.ttl),
+		Backend:     backend,
+		Fingerprint: c.fingerprint,
+	}
+
+	if c.store != nil {
+		c.store.set(c.key, &session)
+		if c.path == "" {
+			return nil
+		}
+	}
+
 	// Ensure directory exists with restrictive permissions before writing
 	dir := filepath.Dir(c.path)
-	if err := os.MkdirAll(dir, 0700); err != nil {
+	if err := c.fs.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("create session directory: %w", err)
 	}
 
-	now := time.Now()
-	session := CachedSession{
-		Token:   token,
-		Created: now,
-		Expires: now.Add(c.ttl),
-		Backend: backend,
-	}
-
 	data, err := json.MarshalIndent(session, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal session: %w", err)
 	}
 
 	// Write with restricted permissions (0600 = owner read/write only)
-	if err := os.WriteFile(c.path, data, 0600); err != nil {
+	if err := c.fs.WriteFile(c.path, data, 0600); err != nil {
 		return fmt.Errorf("write session cache: %w", err)
 	}
 
 	return nil
 }
 
+// matchesFingerprint reports whether session was saved under the current
+// credential fingerprint, or the check is disabled.
+func (c *SessionCache) matchesFingerprint(session *CachedSession) bool {
+	return c.fingerprint == "" || session.Fingerprint == c.fingerprint
+}
+
 // Clear removes the cached session.
 func (c *SessionCache) Clear() error {
-	err := os.Remove(c.path)
+	if c.store != nil {
+		c.store.delete(c.key)
+		if c.path == "" {
+			return nil
+		}
+	}
+
+	err := c.fs.Remove(c.path)
 	if err != nil && !os.IsNotExist(err) {
 		return err
 	}
 	return nil
 }
 
+// SessionStore holds sessions in memory so that several backend instances in
+// one process can share them without re-reading the session file.
+// Opt in by setting Config.SessionStore to a store shared by those backends.
+// It is safe for concurrent use by multiple goroutines.
+//
+// Tokens are held in process memory as they are, not encrypted: the store
+// keeps them out of extra file copies, not away from the process itself.
+type SessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]*CachedSession
+	fs       fileSystem
+	clock    Clock
+}
+
+// NewSessionStore creates an empty session store.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		sessions: make(map[string]*CachedSession),
+		fs:       osFS{},
+		clock:    SystemClock(),
+	}
+}
+
+// SetClock sets the clock used for session expiry by the store and the
+// caches it creates afterwards.
+func (s *SessionStore) SetClock(clock Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clock
+}
+
+// Cache returns a SessionCache backed by this store for the given backend and
+// account. If path is non-empty, sessions are mirrored to that file;
+// otherwise they are kept in memory only.
+func (s *SessionStore) Cache(backend, account, path string, ttl time.Duration) *SessionCache {
+	c := newSessionCache(path, ttl, s.fs)
+	s.mu.RLock()
+	c.clock = s.clock
+	s.mu.RUnlock()
+	c.store = s
+	c.key = storeKey(backend, account)
+	return c
+}
+
+// Invalidate drops the in-memory session for a backend and account.
+// Mirrored session files are left in place; use SessionCache.Clear to remove both.
+func (s *SessionStore) Invalidate(backend, account string) {
+	s.delete(storeKey(backend, account))
+}
+
+// get returns a copy of the session for key if it is unexpired at now, as
+// read from the calling cache's clock, or nil.
+func (s *SessionStore) get(key string, now time.Time) *CachedSession {
+	s.mu.RLock()
+	session, ok := s.sessions[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+	if now.After(session.Expires) {
+		s.delete(key)
+		return nil
+	}
+
+	sessionCopy := *session
+	return &sessionCopy
+}
+
+func (s *SessionStore) set(key string, session *CachedSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessionCopy := *session
+	s.sessions[key] = &sessionCopy
+}
+
+func (s *SessionStore) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, key)
+}
+
+// storeKey builds the SessionStore key for a backend and account.
+func storeKey(backend, account string) string {
+	return backend + "\x00" + account
+}
+
 // AutoRefreshSession wraps a session with automatic refresh capability.
 // It is safe for concurrent use by multiple goroutines.
 type AutoRefreshSession struct {
@@ -105,6 +105,51 @@ func TestSessionCache_Clear(t *testing.T) {
 	}
 }
 
+func TestSessionCache_Fingerprint(t *testing.T) {
+	sessionFile := filepath.Join(t.TempDir(), ".test-session")
+	fingerprintA := CredentialFingerprint("alice@example.com", "https://vault.bitwarden.com")
+	fingerprintB := CredentialFingerprint("bob@example.com", "https://vault.bitwarden.com")
+
+	saver := NewSessionCache(sessionFile, 30*time.Minute)
+	saver.SetFingerprint(fingerprintA)
+	if err := saver.Save("alice-token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Same identity: the session is reused
+	same := NewSessionCache(sessionFile, 30*time.Minute)
+	same.SetFingerprint(fingerprintA)
+	if got, err := same.Load(); err != nil || got == nil || got.Token != "alice-token" {
+		t.Fatalf("Load() with same fingerprint = %+v, %v; want alice-token", got, err)
+	}
+
+	// Different identity: the session is invalid and removed
+	other := NewSessionCache(sessionFile, 30*time.Minute)
+	other.SetFingerprint(fingerprintB)
+	if got, err := other.Load(); err != nil || got != nil {
+		t.Errorf("Load() with other fingerprint = %+v, %v; want nil, nil", got, err)
+	}
+	if _, err := os.Stat(sessionFile); !os.IsNotExist(err) {
+		t.Error("session saved under another fingerprint was not removed")
+	}
+}
+
+func TestSessionStore_Fingerprint(t *testing.T) {
+	store := NewSessionStore()
+	alice := store.Cache("bitwarden", "default", "", time.Hour)
+	alice.SetFingerprint(CredentialFingerprint("alice@example.com"))
+	_ = alice.Save("alice-token", "bitwarden")
+
+	bob := store.Cache("bitwarden", "default", "", time.Hour)
+	bob.SetFingerprint(CredentialFingerprint("bob@example.com"))
+	if got, _ := bob.Load(); got != nil {
+		t.Errorf("Load() with other fingerprint = %+v, want nil", got)
+	}
+	if got, _ := alice.Load(); got != nil {
+		t.Errorf("Load() after mismatch = %+v, want session dropped", got)
+	}
+}
+
 func TestSessionCache_InvalidJSON(t *testing.T) {
 	tmpDir := t.TempDir()
 	sessionFile := filepath.Join(tmpDir, ".invalid-session")
@@ -382,3 +427,160 @@ func TestSessionCache_ErrorPaths(t *testing.T) {
 		_ = err
 	})
 }
+
+// countingFS wraps osFS and counts reads.
+type countingFS struct {
+	osFS
+	reads int
+}
+
+func (f *countingFS) ReadFile(name string) ([]byte, error) {
+	f.reads++
+	return f.osFS.ReadFile(name)
+}
+
+func TestSessionStore_SharedAcrossCaches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	fs := &countingFS{}
+	store := NewSessionStore()
+	store.fs = fs
+
+	// Two backend instances for the same account share one store
+	first := store.Cache("bitwarden", "alice", path, time.Hour)
+	second := store.Cache("bitwarden", "alice", path, time.Hour)
+
+	if err := first.Save("shared-token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("session not mirrored to disk: %v", err)
+	}
+
+	got, err := second.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got == nil || got.Token != "shared-token" {
+		t.Fatalf("Load() = %+v, want token shared-token", got)
+	}
+	if fs.reads != 0 {
+		t.Errorf("Load() read disk %d times, want 0", fs.reads)
+	}
+
+	// Other accounts do not see the session
+	other := store.Cache("bitwarden", "bob", "", time.Hour)
+	if got, _ := other.Load(); got != nil {
+		t.Errorf("Load() for other account = %+v, want nil", got)
+	}
+}
+
+func TestSessionStore_LoadsFromDiskOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := NewSessionCache(path, time.Hour).Save("disk-token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	fs := &countingFS{}
+	store := NewSessionStore()
+	store.fs = fs
+
+	for i := 0; i < 2; i++ {
+		got, err := store.Cache("bitwarden", "alice", path, time.Hour).Load()
+		if err != nil || got == nil || got.Token != "disk-token" {
+			t.Fatalf("Load() = %+v, %v", got, err)
+		}
+	}
+	if fs.reads != 1 {
+		t.Errorf("disk reads = %d, want 1", fs.reads)
+	}
+}
+
+func TestSessionStore_Invalidate(t *testing.T) {
+	store := NewSessionStore()
+	cache := store.Cache("1password", "alice", "", time.Hour)
+
+	if err := cache.Save("token", "1password"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	store.Invalidate("1password", "alice")
+
+	if got, _ := cache.Load(); got != nil {
+		t.Errorf("Load() after Invalidate = %+v, want nil", got)
+	}
+}
+
+func TestSessionStore_Expired(t *testing.T) {
+	store := NewSessionStore()
+	cache := store.Cache("bitwarden", "alice", "", -time.Second)
+
+	if err := cache.Save("token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, _ := cache.Load(); got != nil {
+		t.Errorf("Load() of expired session = %+v, want nil", got)
+	}
+}
+
+// fakeClock is a manually advanced Clock.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestSessionCache_Clock(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := NewSessionCache(filepath.Join(t.TempDir(), "session.json"), time.Hour)
+	cache.SetClock(clock)
+
+	if err := cache.Save("token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	clock.now = clock.now.Add(59 * time.Minute)
+	if got, _ := cache.Load(); got == nil {
+		t.Error("Load() before expiry = nil, want session")
+	}
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	if got, _ := cache.Load(); got != nil {
+		t.Errorf("Load() after expiry = %+v, want nil", got)
+	}
+}
+
+func TestSessionStore_Clock(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := NewSessionStore()
+	store.SetClock(clock)
+	cache := store.Cache("bitwarden", "alice", "", time.Minute)
+
+	if err := cache.Save("token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	if got, _ := cache.Load(); got != nil {
+		t.Errorf("Load() after expiry = %+v, want nil", got)
+	}
+}
+
+func TestSessionStore_UsesCacheClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewSessionStore()
+	cache := store.Cache("bitwarden", "alice", "", time.Minute)
+	clock := &fakeClock{now: start}
+	cache.SetClock(clock)
+
+	if err := cache.Save("token", "bitwarden"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// The store's own clock is the system clock, long past start+1m; expiry
+	// must follow the cache's clock instead.
+	if got, _ := cache.Load(); got == nil {
+		t.Error("Load() before expiry on cache clock = nil, want session")
+	}
+
+	clock.now = start.Add(2 * time.Minute)
+	if got, _ := cache.Load(); got != nil {
+		t.Errorf("Load() after expiry on cache clock = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,59 @@
+package vaultmux
+
+import "context"
+
+// ItemOrError is an element of a ListItemsChan stream: either an item or
+// the error that ended the listing.
+type ItemOrError struct {
+	Item *Item
+	Err  error
+}
+
+// ItemStreamer is implemented by backends that can deliver listed items as
+// they are fetched, page by page, instead of after the whole listing.
+type ItemStreamer interface {
+	ListItemsChan(ctx context.Context, session Session) (<-chan ItemOrError, error)
+}
+
+// ListItemsChan streams the items ListItems would return on a channel, for
+// callers that process items in a pipeline. Backends implementing
+// ItemStreamer yield items as pages arrive; others are listed in full first.
+//
+// The channel is closed once all items are sent, after an error element,
+// or when ctx is cancelled. Errors that occur after streaming starts are
+// delivered as an element with Err set; the returned error is reserved for
+// failures before streaming starts, such as an invalid session.
+func ListItemsChan(ctx context.Context, b Backend, session Session) (<-chan ItemOrError, error) {
+	if streamer, ok := b.(ItemStreamer); ok {
+		return streamer.ListItemsChan(ctx, session)
+	}
+
+	ch := make(chan ItemOrError)
+	go func() {
+		defer close(ch)
+
+		items, err := b.ListItems(ctx, session)
+		if err != nil {
+			SendItem(ctx, ch, ItemOrError{Err: err})
+			return
+		}
+		for _, item := range items {
+			if !SendItem(ctx, ch, ItemOrError{Item: item}) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// SendItem sends v on ch unless ctx is cancelled first, and reports whether
+// it was sent. ItemStreamer implementations use it so a consumer that stops
+// reading and cancels ctx doesn't leak the producing goroutine.
+func SendItem(ctx context.Context, ch chan<- ItemOrError, v ItemOrError) bool {
+	select {
+	case ch <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestListItemsChan_Fallback(t *testing.T) {
+	ctx := context.Background()
+	backend := newExportMock(20)
+
+	ch, err := vaultmux.ListItemsChan(ctx, backend, nil)
+	if err != nil {
+		t.Fatalf("ListItemsChan() error = %v", err)
+	}
+	var names []string
+	for v := range ch {
+		if v.Err != nil {
+			t.Fatalf("element error = %v", v.Err)
+		}
+		names = append(names, v.Item.Name)
+	}
+	sort.Strings(names)
+	if len(names) != 20 || names[0] != "item-000" || names[19] != "item-019" {
+		t.Errorf("ListItemsChan() names = %v", names)
+	}
+}
+
+func TestListItemsChan_ErrorElement(t *testing.T) {
+	ch, err := vaultmux.ListItemsChan(context.Background(), &failingLister{mock.New()}, nil)
+	if err != nil {
+		t.Fatalf("ListItemsChan() error = %v", err)
+	}
+
+	var got []vaultmux.ItemOrError
+	for v := range ch {
+		got = append(got, v)
+	}
+	if len(got) != 1 || !errors.Is(got[0].Err, vaultmux.ErrPermissionDenied) {
+		t.Errorf("ListItemsChan() elements = %+v, want one ErrPermissionDenied", got)
+	}
+}
+
+func TestListItemsChan_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := vaultmux.ListItemsChan(ctx, newExportMock(20), nil)
+	if err != nil {
+		t.Fatalf("ListItemsChan() error = %v", err)
+	}
+	<-ch
+	cancel()
+
+	received := 1
+	for range ch {
+		received++
+	}
+	if received == 20 {
+		t.Error("cancelled stream still delivered every item")
+	}
+}
@@ -0,0 +1,74 @@
+package vaultmux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ItemSchemaVersion is the version of the envelope written by
+// SetStructuredItem. Bump it when Item gains fields that older envelopes
+// need migrated, and add a step to migrateEnvelope.
+//
+// Version history:
+//   - 1: notes and fields only
+//   - 2: the full Item, including description and version
+const ItemSchemaVersion = 2
+
+// itemEnvelope is the JSON stored as the value of a structured item.
+type itemEnvelope struct {
+	SchemaVersion int `json:"schemaVersion,omitempty"` // Absent in version 1
+	Item
+}
+
+// SetStructuredItem stores item, including its metadata, as a JSON envelope
+// in the value of item.Name, for backends that only store a single value.
+// Read it back with GetStructuredItem.
+func SetStructuredItem(ctx context.Context, b Backend, item *Item, session Session) error {
+	data, err := json.Marshal(itemEnvelope{SchemaVersion: ItemSchemaVersion, Item: *item})
+	if err != nil {
+		return WrapError(b.Name(), "encode", item.Name, err)
+	}
+	return SetItem(ctx, b, item.Name, string(data), session)
+}
+
+// GetStructuredItem reads an item stored by SetStructuredItem. Envelopes
+// written with an older schema version are migrated to the current Item,
+// with fields they predate set to defaults. Envelopes from a newer version
+// are rejected with ErrInvalidEncoding rather than silently dropping fields.
+func GetStructuredItem(ctx context.Context, b Backend, name string, session Session) (*Item, error) {
+	stored, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+
+	var env itemEnvelope
+	if err := json.Unmarshal([]byte(stored), &env); err != nil {
+		return nil, WrapError(b.Name(), "decode", name, fmt.Errorf("%w: %w", ErrInvalidEncoding, err))
+	}
+	if err := migrateEnvelope(&env, name); err != nil {
+		return nil, WrapError(b.Name(), "decode", name, err)
+	}
+	return &env.Item, nil
+}
+
+// migrateEnvelope upgrades env in place to ItemSchemaVersion.
+func migrateEnvelope(env *itemEnvelope, name string) error {
+	if env.SchemaVersion == 0 {
+		env.SchemaVersion = 1
+	}
+	if env.SchemaVersion > ItemSchemaVersion {
+		return fmt.Errorf("%w: schema version %d is newer than supported version %d",
+			ErrInvalidEncoding, env.SchemaVersion, ItemSchemaVersion)
+	}
+
+	if env.SchemaVersion == 1 {
+		// Version 1 stored only the value and fields; identity comes from
+		// where it was stored.
+		env.ID = name
+		env.Name = name
+		env.Type = ItemTypeSecureNote
+		env.SchemaVersion = 2
+	}
+	return nil
+}
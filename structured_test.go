@@ -0,0 +1,75 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestStructuredItem_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	item := &vaultmux.Item{
+		ID:          "db",
+		Name:        "db",
+		Type:        vaultmux.ItemTypeLogin,
+		Notes:       "s3cret",
+		Description: "primary database",
+		Fields:      map[string]string{"username": "admin"},
+		Modified:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:     "3",
+	}
+	if err := vaultmux.SetStructuredItem(ctx, backend, item, session); err != nil {
+		t.Fatalf("SetStructuredItem() error = %v", err)
+	}
+
+	got, err := vaultmux.GetStructuredItem(ctx, backend, "db", session)
+	if err != nil {
+		t.Fatalf("GetStructuredItem() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, item) {
+		t.Errorf("GetStructuredItem() = %+v, want %+v", got, item)
+	}
+}
+
+func TestStructuredItem_MigratesV1(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("db", `{"notes":"s3cret","fields":{"username":"admin"}}`)
+	session, _ := backend.Authenticate(ctx)
+
+	got, err := vaultmux.GetStructuredItem(ctx, backend, "db", session)
+	if err != nil {
+		t.Fatalf("GetStructuredItem() error = %v", err)
+	}
+
+	want := &vaultmux.Item{
+		ID:     "db",
+		Name:   "db",
+		Type:   vaultmux.ItemTypeSecureNote,
+		Notes:  "s3cret",
+		Fields: map[string]string{"username": "admin"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStructuredItem() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStructuredItem_RejectsNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("db", `{"schemaVersion":99,"notes":"s3cret"}`)
+	session, _ := backend.Authenticate(ctx)
+
+	_, err := vaultmux.GetStructuredItem(ctx, backend, "db", session)
+	if !errors.Is(err, vaultmux.ErrInvalidEncoding) {
+		t.Errorf("GetStructuredItem() error = %v, want ErrInvalidEncoding", err)
+	}
+}
@@ -0,0 +1,18 @@
+package vaultmux
+
+import "context"
+
+// TaggableBackend is implemented by backends that can attach key/value tags
+// to items, such as AWS tags, GCP labels or Azure Key Vault tags. Tags are
+// metadata and never part of the secret value.
+type TaggableBackend interface {
+	// SetTags replaces all tags on the named item.
+	SetTags(ctx context.Context, name string, tags map[string]string, session Session) error
+
+	// GetTags returns the tags on the named item (empty if it has none).
+	GetTags(ctx context.Context, name string, session Session) (map[string]string, error)
+
+	// ListItemsByTag returns the items tagged key=value. An empty value
+	// matches every item with the key, whatever its value.
+	ListItemsByTag(ctx context.Context, key, value string, session Session) ([]*Item, error)
+}
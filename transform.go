@@ -0,0 +1,110 @@
+package vaultmux
+
+import "context"
+
+// NameTransformer wraps a Backend and applies a naming convention to every
+// item name before it reaches the backend, such as lowercasing or replacing
+// "_" with "-". Names that already follow the convention pass through
+// unchanged, provided the transform leaves them alone.
+//
+// If an inverse is given, it is applied to the names of returned items for
+// display. Without one, items are reported under their stored names.
+//
+// CreateItemWithOptions and SetItem transform the name as well. Calls made
+// on the backend returned by Unwrap take stored names.
+type NameTransformer struct {
+	Backend
+
+	transform func(string) string
+	inverse   func(string) string
+}
+
+// NewNameTransformer wraps b so that transform is applied to item names.
+// inverse may be nil.
+func NewNameTransformer(b Backend, transform, inverse func(string) string) *NameTransformer {
+	return &NameTransformer{Backend: b, transform: transform, inverse: inverse}
+}
+
+// GetItem retrieves the item stored under the transformed name.
+func (t *NameTransformer) GetItem(ctx context.Context, name string, session Session) (*Item, error) {
+	item, err := t.Backend.GetItem(ctx, t.transform(name), session)
+	if err != nil {
+		return nil, err
+	}
+	return t.display(item), nil
+}
+
+// GetNotes retrieves the notes of the item stored under the transformed name.
+func (t *NameTransformer) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	return t.Backend.GetNotes(ctx, t.transform(name), session)
+}
+
+// ItemExists checks for an item under the transformed name.
+func (t *NameTransformer) ItemExists(ctx context.Context, name string, session Session) (bool, error) {
+	return t.Backend.ItemExists(ctx, t.transform(name), session)
+}
+
+// ListItems lists all items, with names passed through the inverse.
+func (t *NameTransformer) ListItems(ctx context.Context, session Session) ([]*Item, error) {
+	items, err := t.Backend.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		items[i] = t.display(item)
+	}
+	return items, nil
+}
+
+// CreateItem creates an item under the transformed name.
+func (t *NameTransformer) CreateItem(ctx context.Context, name, content string, session Session) error {
+	return t.Backend.CreateItem(ctx, t.transform(name), content, session)
+}
+
+// CreateItemWithOptions creates an item under the transformed name.
+// Options are ignored if the wrapped backend is not an ItemCreator.
+func (t *NameTransformer) CreateItemWithOptions(ctx context.Context, name, content string, session Session, opts ...CreateOption) error {
+	return createItemWithOptions(ctx, t.Backend, t.transform(name), content, session, opts...)
+}
+
+// SetItem creates or updates the item under the transformed name.
+func (t *NameTransformer) SetItem(ctx context.Context, name, content string, session Session) error {
+	return SetItem(ctx, t.Backend, t.transform(name), content, session)
+}
+
+// UpdateItem updates the item under the transformed name.
+func (t *NameTransformer) UpdateItem(ctx context.Context, name, content string, session Session) error {
+	return t.Backend.UpdateItem(ctx, t.transform(name), content, session)
+}
+
+// DeleteItem deletes the item under the transformed name.
+func (t *NameTransformer) DeleteItem(ctx context.Context, name string, session Session) error {
+	return t.Backend.DeleteItem(ctx, t.transform(name), session)
+}
+
+// ListItemsInLocation lists items in a location, with names passed through
+// the inverse.
+func (t *NameTransformer) ListItemsInLocation(ctx context.Context, locType, locValue string, session Session) ([]*Item, error) {
+	items, err := t.Backend.ListItemsInLocation(ctx, locType, locValue, session)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		items[i] = t.display(item)
+	}
+	return items, nil
+}
+
+// Unwrap returns the wrapped backend.
+func (t *NameTransformer) Unwrap() Backend {
+	return t.Backend
+}
+
+// display returns item with its name passed through the inverse, if any.
+func (t *NameTransformer) display(item *Item) *Item {
+	if t.inverse == nil || item == nil {
+		return item
+	}
+	item.Name = t.inverse(item.Name)
+	return item
+}
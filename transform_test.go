@@ -0,0 +1,147 @@
+package vaultmux_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestConfig_NameTransform(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	vaultmux.RegisterBackend("mock-transform", func(cfg vaultmux.Config) (vaultmux.Backend, error) {
+		return store, nil
+	})
+
+	backend, err := vaultmux.New(vaultmux.Config{
+		Backend:       "mock-transform",
+		NameTransform: strings.ToLower,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	session, _ := backend.Authenticate(ctx)
+
+	if err := backend.CreateItem(ctx, "MySecret", "value", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	// Stored under the transformed name
+	if exists, _ := store.ItemExists(ctx, "mysecret", session); !exists {
+		t.Error("item not stored as mysecret")
+	}
+	if exists, _ := store.ItemExists(ctx, "MySecret", session); exists {
+		t.Error("item stored under untransformed name")
+	}
+
+	// Readable by either spelling through the backend
+	for _, name := range []string{"MySecret", "mysecret"} {
+		if got, err := backend.GetNotes(ctx, name, session); err != nil || got != "value" {
+			t.Errorf("GetNotes(%s) = %q, %v; want value", name, got, err)
+		}
+	}
+
+	// Already-canonical names are untouched
+	if err := backend.CreateItem(ctx, "api-key", "k", session); err != nil {
+		t.Fatalf("CreateItem(api-key) error = %v", err)
+	}
+	if got, _ := store.GetNotes(ctx, "api-key", session); got != "k" {
+		t.Errorf("canonical name not stored as-is: got %q", got)
+	}
+}
+
+func TestNameTransformer_Inverse(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	backend := vaultmux.NewNameTransformer(store,
+		func(name string) string { return strings.ReplaceAll(name, "_", "-") },
+		func(name string) string { return strings.ReplaceAll(name, "-", "_") })
+
+	if err := backend.CreateItem(ctx, "db_password", "pw", nil); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if exists, _ := store.ItemExists(ctx, "db-password", nil); !exists {
+		t.Error("item not stored as db-password")
+	}
+
+	item, err := backend.GetItem(ctx, "db_password", nil)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Name != "db_password" {
+		t.Errorf("GetItem().Name = %q, want display name db_password", item.Name)
+	}
+
+	items, _ := backend.ListItems(ctx, nil)
+	if len(items) != 1 || items[0].Name != "db_password" {
+		t.Errorf("ListItems() = %v, want [db_password]", items)
+	}
+
+	if err := backend.DeleteItem(ctx, "db_password", nil); err != nil {
+		t.Errorf("DeleteItem() error = %v", err)
+	}
+}
+
+func TestNameTransformer_OptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	backend := vaultmux.NewNameTransformer(store, strings.ToLower, nil)
+
+	if err := backend.CreateItemWithOptions(ctx, "API_KEY", "k1", nil, vaultmux.WithDescription("billing")); err != nil {
+		t.Fatalf("CreateItemWithOptions() error = %v", err)
+	}
+	if item, err := store.GetItem(ctx, "api_key", nil); err != nil || item.Description != "billing" {
+		t.Errorf("stored api_key = %+v, %v; want it with description billing", item, err)
+	}
+	if err := vaultmux.SetItem(ctx, backend, "API_KEY", "k2", nil); err != nil {
+		t.Fatalf("SetItem() error = %v", err)
+	}
+	if notes, _ := store.GetNotes(ctx, "api_key", nil); notes != "k2" {
+		t.Errorf("api_key after SetItem = %q, want k2", notes)
+	}
+	if exists, _ := store.ItemExists(ctx, "API_KEY", nil); exists {
+		t.Error("SetItem() stored the untransformed name")
+	}
+	if backend.Unwrap() != vaultmux.Backend(store) {
+		t.Error("Unwrap() did not return the wrapped backend")
+	}
+}
+
+func TestConfig_NameTransformWithCache(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	vaultmux.RegisterBackend("mock-transform-cache", func(cfg vaultmux.Config) (vaultmux.Backend, error) {
+		return store, nil
+	})
+
+	backend, err := vaultmux.New(vaultmux.Config{
+		Backend:       "mock-transform-cache",
+		NameTransform: strings.ToLower,
+		CacheTTL:      time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	session, _ := backend.Authenticate(ctx)
+
+	if err := backend.CreateItem(ctx, "foo", "v1", session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if got, _ := backend.GetNotes(ctx, "foo", session); got != "v1" {
+		t.Fatalf("GetNotes(foo) = %q, want v1", got)
+	}
+
+	// A write through another spelling invalidates the entry read as "foo"
+	if err := backend.UpdateItem(ctx, "FOO", "v2", session); err != nil {
+		t.Fatalf("UpdateItem(FOO) error = %v", err)
+	}
+	for _, name := range []string{"foo", "FOO"} {
+		if got, _ := backend.GetNotes(ctx, name, session); got != "v2" {
+			t.Errorf("GetNotes(%s) after UpdateItem(FOO) = %q, want v2", name, got)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package vaultmux
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// TreeNode is a node of the hierarchy built by ListTree. Folders have
+// Children; leaves have an Item. A name that is both an item and a path
+// prefix of other items (e.g. "a/b" and "a/b/c") has both.
+type TreeNode struct {
+	Name     string      // Path segment, "" for the root
+	Path     string      // Full path from the root, e.g. "a/b"
+	Item     *Item       // Set if an item has this exact name
+	Children []*TreeNode // Sorted by name
+}
+
+// Leaves returns the number of items at or below n.
+func (n *TreeNode) Leaves() int {
+	count := 0
+	if n.Item != nil {
+		count++
+	}
+	for _, child := range n.Children {
+		count += child.Leaves()
+	}
+	return count
+}
+
+// Child returns the direct child named name, or nil.
+func (n *TreeNode) Child(name string) *TreeNode {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// ListTree lists b's items and arranges them into a tree by splitting their
+// names on "/", for display in file-browser style interfaces. Intermediate
+// segments become folder nodes; empty segments (as in "a//b") are dropped.
+func ListTree(ctx context.Context, b Backend, session Session) (*TreeNode, error) {
+	items, err := b.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &TreeNode{}
+	for _, item := range items {
+		node := root
+		for _, segment := range strings.Split(item.Name, "/") {
+			if segment == "" {
+				continue
+			}
+			child := node.Child(segment)
+			if child == nil {
+				child = &TreeNode{Name: segment, Path: strings.TrimPrefix(node.Path+"/"+segment, "/")}
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+		node.Item = item
+	}
+
+	sortTree(root)
+	return root, nil
+}
+
+// sortTree orders the children of every node by name.
+func sortTree(n *TreeNode) {
+	sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Name < n.Children[j].Name })
+	for _, child := range n.Children {
+		sortTree(child)
+	}
+}
@@ -0,0 +1,58 @@
+package vaultmux_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestListTree(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	for _, name := range []string{"a/b/c", "a/b/d", "a/e", "f"} {
+		backend.SetItem(name, "value")
+	}
+	session, _ := backend.Authenticate(ctx)
+
+	root, err := vaultmux.ListTree(ctx, backend, session)
+	if err != nil {
+		t.Fatalf("ListTree() error = %v", err)
+	}
+
+	if got := childNames(root); len(got) != 2 || got[0] != "a" || got[1] != "f" {
+		t.Fatalf("root children = %v, want [a f]", got)
+	}
+	if root.Leaves() != 4 {
+		t.Errorf("root.Leaves() = %d, want 4", root.Leaves())
+	}
+
+	a := root.Child("a")
+	if a.Item != nil || a.Leaves() != 3 {
+		t.Errorf("a: item = %v, leaves = %d; want folder with 3 leaves", a.Item, a.Leaves())
+	}
+	if got := childNames(a); len(got) != 2 || got[0] != "b" || got[1] != "e" {
+		t.Errorf("a children = %v, want [b e]", got)
+	}
+
+	b := a.Child("b")
+	if b.Leaves() != 2 || b.Path != "a/b" {
+		t.Errorf("a/b: path = %q, leaves = %d; want a/b with 2 leaves", b.Path, b.Leaves())
+	}
+	if c := b.Child("c"); c == nil || c.Item == nil || c.Item.Name != "a/b/c" || len(c.Children) != 0 {
+		t.Errorf("a/b/c = %+v, want leaf for item a/b/c", c)
+	}
+
+	if f := root.Child("f"); f == nil || f.Item == nil || f.Path != "f" {
+		t.Errorf("f = %+v, want leaf for item f", f)
+	}
+}
+
+func childNames(n *vaultmux.TreeNode) []string {
+	var names []string
+	for _, child := range n.Children {
+		names = append(names, child.Name)
+	}
+	return names
+}
@@ -0,0 +1,67 @@
+package vaultmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Unmarshal populates the struct pointed to by dst from items in b. Each
+// field tagged `vaultmux:"name"` is set to the value of the item prefix+name;
+// fields must be of type string or []byte. Untagged fields and fields
+// tagged "-" are ignored.
+//
+// A missing item leaves its field unchanged, so defaults can be set before
+// calling Unmarshal, unless the tag has the required option:
+//
+//	type Config struct {
+//	    DBPassword string `vaultmux:"db-password,required"`
+//	    TLSKey     []byte `vaultmux:"tls-key"`
+//	}
+//
+// A missing required item returns an error naming the field and wrapping
+// ErrNotFound. Other read errors are returned as they occur.
+func Unmarshal(ctx context.Context, b Backend, session Session, prefix string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal needs a non-nil struct pointer, got %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("vaultmux")
+		if !ok || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		required := opts == "required"
+
+		fv := v.Field(i)
+		if !field.IsExported() {
+			return fmt.Errorf("field %s is unexported", field.Name)
+		}
+		isBytes := fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8
+		if fv.Kind() != reflect.String && !isBytes {
+			return fmt.Errorf("field %s has unsupported type %s (want string or []byte)", field.Name, fv.Type())
+		}
+
+		notes, err := b.GetNotes(ctx, prefix+name, session)
+		if errors.Is(err, ErrNotFound) && !required {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("field %s (item %q): %w", field.Name, prefix+name, err)
+		}
+
+		if isBytes {
+			fv.SetBytes([]byte(notes))
+		} else {
+			fv.SetString(notes)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package vaultmux
+
+import "context"
+
+// UpdateResult describes what an update did to the stored secret.
+type UpdateResult struct {
+	// Changed reports whether the stored value differs from the previous
+	// one.
+	Changed bool
+
+	// NewVersion is the ID of the version the update created, or "" if the
+	// backend overwrote the value in place or no version was added.
+	NewVersion string
+}
+
+// ResultUpdater is implemented by backends that can report whether an
+// update created a version (GCP, AWS, Azure) or overwrote the value in
+// place (pass), and whether the value changed.
+type ResultUpdater interface {
+	UpdateItemWithResult(ctx context.Context, name, content string, session Session) (UpdateResult, error)
+}
+
+// UpdateItemWithResult updates the named item like UpdateItem and reports
+// the outcome. Backends implementing ResultUpdater report it natively;
+// otherwise the previous value is read first to tell whether it changed,
+// and NewVersion is left empty.
+func UpdateItemWithResult(ctx context.Context, b Backend, name, content string, session Session) (UpdateResult, error) {
+	if u, ok := b.(ResultUpdater); ok {
+		return u.UpdateItemWithResult(ctx, name, content, session)
+	}
+
+	previous, err := b.GetNotes(ctx, name, session)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+	if err := b.UpdateItem(ctx, name, content, session); err != nil {
+		return UpdateResult{}, err
+	}
+	return UpdateResult{Changed: hashContent(previous) != hashContent(content)}, nil
+}
@@ -0,0 +1,33 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestUpdateItemWithResult_Fallback(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	backend.SetItem("token", "v1")
+
+	result, err := vaultmux.UpdateItemWithResult(ctx, backend, "token", "v1", nil)
+	if err != nil || result != (vaultmux.UpdateResult{}) {
+		t.Errorf("UpdateItemWithResult(same) = %+v, %v; want unchanged", result, err)
+	}
+
+	result, err = vaultmux.UpdateItemWithResult(ctx, backend, "token", "v2", nil)
+	if err != nil || result != (vaultmux.UpdateResult{Changed: true}) {
+		t.Errorf("UpdateItemWithResult(new) = %+v, %v; want changed without a version", result, err)
+	}
+	if notes, _ := backend.GetNotes(ctx, "token", nil); notes != "v2" {
+		t.Errorf("token = %q, want v2", notes)
+	}
+
+	if _, err := vaultmux.UpdateItemWithResult(ctx, backend, "missing", "v1", nil); !errors.Is(err, vaultmux.ErrNotFound) {
+		t.Errorf("UpdateItemWithResult(missing) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,21 @@
+package vaultmux
+
+import "context"
+
+// SetItem stores content under name whether or not the item exists.
+// Backends implementing ItemSetter handle this natively; otherwise the item
+// is checked with ItemExists and then created or updated.
+func SetItem(ctx context.Context, b Backend, name, content string, session Session) error {
+	if setter, ok := b.(ItemSetter); ok {
+		return setter.SetItem(ctx, name, content, session)
+	}
+
+	exists, err := b.ItemExists(ctx, name, session)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return b.UpdateItem(ctx, name, content, session)
+	}
+	return b.CreateItem(ctx, name, content, session)
+}
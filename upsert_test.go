@@ -0,0 +1,34 @@
+package vaultmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestSetItem_Fallback(t *testing.T) {
+	ctx := context.Background()
+	backend := mock.New()
+	session, _ := backend.Authenticate(ctx)
+
+	if err := vaultmux.SetItem(ctx, backend, "key", "v1", session); err != nil {
+		t.Fatalf("SetItem() create error = %v", err)
+	}
+	if err := vaultmux.SetItem(ctx, backend, "key", "v2", session); err != nil {
+		t.Fatalf("SetItem() update error = %v", err)
+	}
+
+	got, err := backend.GetNotes(ctx, "key", session)
+	if err != nil || got != "v2" {
+		t.Errorf("GetNotes() = %q, %v; want %q, nil", got, err, "v2")
+	}
+
+	updateErr := errors.New("update failed")
+	backend.UpdateError = updateErr
+	if err := vaultmux.SetItem(ctx, backend, "key", "v3", session); !errors.Is(err, updateErr) {
+		t.Errorf("SetItem() error = %v, want %v", err, updateErr)
+	}
+}
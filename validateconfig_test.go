@@ -0,0 +1,72 @@
+package vaultmux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blackwell-systems/vaultmux"
+	_ "github.com/blackwell-systems/vaultmux/backends/awssecrets"
+	_ "github.com/blackwell-systems/vaultmux/backends/azurekeyvault"
+	_ "github.com/blackwell-systems/vaultmux/backends/gcpsecrets"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   vaultmux.Config
+		field string // offending option, "" if valid
+	}{
+		{
+			name: "aws",
+			cfg:  vaultmux.Config{Backend: vaultmux.BackendAWSSecretsManager, Options: map[string]string{"region": "us-east-1"}},
+		},
+		{
+			name: "gcp",
+			cfg:  vaultmux.Config{Backend: vaultmux.BackendGCPSecretManager, Options: map[string]string{"project_id": "my-project"}},
+		},
+		{
+			name: "azure",
+			cfg:  vaultmux.Config{Backend: vaultmux.BackendAzureKeyVault, Options: map[string]string{"vault_url": "https://myvault.vault.azure.net/"}},
+		},
+		{
+			name:  "gcp missing project_id",
+			cfg:   vaultmux.Config{Backend: vaultmux.BackendGCPSecretManager},
+			field: "project_id",
+		},
+		{
+			name:  "azure missing vault_url",
+			cfg:   vaultmux.Config{Backend: vaultmux.BackendAzureKeyVault},
+			field: "vault_url",
+		},
+		{
+			name:  "aws invalid proxy_url",
+			cfg:   vaultmux.Config{Backend: vaultmux.BackendAWSSecretsManager, Options: map[string]string{"proxy_url": "ftp://proxy"}},
+			field: "proxy_url",
+		},
+		{
+			name:  "unknown backend",
+			cfg:   vaultmux.Config{Backend: "nope"},
+			field: "backend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := vaultmux.ValidateConfig(tt.cfg)
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("ValidateConfig() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, vaultmux.ErrInvalidConfig) {
+				t.Fatalf("ValidateConfig() error = %v, want ErrInvalidConfig", err)
+			}
+			var cfgErr *vaultmux.ConfigError
+			if errors.As(err, &cfgErr) && cfgErr.Field != tt.field {
+				t.Errorf("ConfigError.Field = %q, want %q", cfgErr.Field, tt.field)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package vaultmux
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -54,3 +56,108 @@ func ValidateItemName(name string) error {
 func ValidateLocationName(name string) error {
 	return ValidateItemName(name)
 }
+
+// ValidateItemNameNoTraversal applies ValidateItemName and additionally
+// rejects names that could escape a directory when joined onto it: absolute
+// paths and "." or ".." path segments. File-backed backends (pass) use it
+// before building filesystem paths from item or location names.
+func ValidateItemNameNoTraversal(name string) error {
+	if err := ValidateItemName(name); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(name, "/") {
+		return fmt.Errorf("%w: absolute paths are not allowed", ErrInvalidItemName)
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("%w: contains %q path segment", ErrInvalidItemName, segment)
+		}
+	}
+
+	return nil
+}
+
+// Warning is a non-fatal problem with a name that passes validation but is
+// likely to surprise the caller on a particular backend.
+type Warning struct {
+	Message string
+}
+
+// String returns the warning message.
+func (w Warning) String() string { return w.Message }
+
+// nameRules are the stricter naming rules of a cloud backend.
+type nameRules struct {
+	limits          Limits         // As reported by the backend's Limits()
+	disallowed      *regexp.Regexp // Matches one character outside limits.AllowedNameCharset
+	allowedDesc     string
+	caseInsensitive bool
+}
+
+// newNameRules compiles the character check for limits once, at package
+// initialization.
+func newNameRules(limits Limits, allowedDesc string, caseInsensitive bool) nameRules {
+	rules := nameRules{limits: limits, allowedDesc: allowedDesc, caseInsensitive: caseInsensitive}
+	if limits.AllowedNameCharset != "" {
+		rules.disallowed = regexp.MustCompile("[^" + limits.AllowedNameCharset + "]")
+	}
+	return rules
+}
+
+// backendNameRules holds the naming rules of backends whose names are
+// stricter than ValidateItemName. The limits must match what each backend's
+// Limits() reports, which TestBackendLimits_Builtin checks. Names are
+// checked without the backend prefix, so lengths are upper bounds.
+var backendNameRules = map[BackendType]nameRules{
+	BackendGCPSecretManager: newNameRules(Limits{MaxNameLength: 255, AllowedNameCharset: "A-Za-z0-9_-"},
+		"letters, digits, '-' and '_'", false),
+	BackendAzureKeyVault: newNameRules(Limits{MaxNameLength: 127, AllowedNameCharset: "A-Za-z0-9-"},
+		"letters, digits and '-'", true),
+	BackendAWSSecretsManager: newNameRules(Limits{MaxNameLength: 512, AllowedNameCharset: "A-Za-z0-9/_+=.@-"},
+		"letters, digits and '/_+=.@-'", false),
+	BackendWindowsCredentialManager: newNameRules(Limits{}, "", true),
+}
+
+// ValidateItemNameForBackend returns non-fatal warnings for a name that may
+// cause surprises on backend type t, such as characters the provider rejects
+// or names that collide on case-insensitive backends. It does not replace
+// ValidateItemName, which still decides whether the name is usable at all.
+func ValidateItemNameForBackend(name string, t BackendType) []Warning {
+	var warnings []Warning
+
+	if strings.TrimSpace(name) != name {
+		warnings = append(warnings, Warning{"name has leading or trailing whitespace"})
+	}
+
+	rules, ok := backendNameRules[t]
+	if !ok {
+		return warnings
+	}
+
+	if rules.disallowed != nil {
+		var rejected []string
+		for _, r := range rules.disallowed.FindAllString(name, -1) {
+			if !slices.Contains(rejected, r) {
+				rejected = append(rejected, r)
+			}
+		}
+		for _, r := range rejected {
+			warnings = append(warnings, Warning{fmt.Sprintf(
+				"name contains %q which %s will reject (allowed: %s)", r, t, rules.allowedDesc)})
+		}
+	}
+
+	if maxLen := rules.limits.MaxNameLength; maxLen > 0 && len(name) > maxLen {
+		warnings = append(warnings, Warning{fmt.Sprintf(
+			"name is %d characters; %s allows at most %d including the prefix", len(name), t, maxLen)})
+	}
+
+	if rules.caseInsensitive && strings.ToLower(name) != name {
+		warnings = append(warnings, Warning{fmt.Sprintf(
+			"%s names are case-insensitive; names differing only by case refer to the same item", t)})
+	}
+
+	return warnings
+}
@@ -287,6 +287,38 @@ func TestValidateLocationName(t *testing.T) {
 	}
 }
 
+func TestValidateItemNameNoTraversal(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "plain name", input: "api-key"},
+		{name: "nested name", input: "a/b"},
+		{name: "dots inside segment", input: "app/v1..2/key.gpg"},
+		{name: "hidden file", input: ".env"},
+		{name: "parent traversal", input: "../../evil", wantErr: true},
+		{name: "parent in middle", input: "a/../../evil", wantErr: true},
+		{name: "trailing parent", input: "a/..", wantErr: true},
+		{name: "current dir segment", input: "./a", wantErr: true},
+		{name: "bare parent", input: "..", wantErr: true},
+		{name: "absolute path", input: "/etc/passwd", wantErr: true},
+		{name: "base rules still apply", input: "a;b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateItemNameNoTraversal(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateItemNameNoTraversal(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidItemName) {
+				t.Errorf("error should wrap ErrInvalidItemName, got %v", err)
+			}
+		})
+	}
+}
+
 // TestValidateItemName_RealWorldExamples tests realistic secret names
 func TestValidateItemName_RealWorldExamples(t *testing.T) {
 	validNames := []string{
@@ -310,3 +342,66 @@ func TestValidateItemName_RealWorldExamples(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateItemNameForBackend(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		backend      BackendType
+		wantWarnings []string // substrings, one per expected warning
+	}{
+		{
+			name:         "slash on GCP",
+			input:        "app/prod",
+			backend:      BackendGCPSecretManager,
+			wantWarnings: []string{`"/" which gcpsecrets will reject`},
+		},
+		{
+			name:    "plain name on GCP",
+			input:   "app-prod",
+			backend: BackendGCPSecretManager,
+		},
+		{
+			name:    "slash on AWS",
+			input:   "app/prod",
+			backend: BackendAWSSecretsManager,
+		},
+		{
+			name:         "underscore and case on Azure",
+			input:        "App_Key",
+			backend:      BackendAzureKeyVault,
+			wantWarnings: []string{`"_" which azurekeyvault will reject`, "case-insensitive"},
+		},
+		{
+			name:         "too long for Azure",
+			input:        strings.Repeat("a", 128),
+			backend:      BackendAzureKeyVault,
+			wantWarnings: []string{"at most 127"},
+		},
+		{
+			name:         "trailing whitespace on pass",
+			input:        "api-key ",
+			backend:      BackendPass,
+			wantWarnings: []string{"whitespace"},
+		},
+		{
+			name:    "plain name on pass",
+			input:   "dotfiles/ssh-key",
+			backend: BackendPass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateItemNameForBackend(tt.input, tt.backend)
+			if len(got) != len(tt.wantWarnings) {
+				t.Fatalf("ValidateItemNameForBackend(%q, %s) = %v, want %d warnings", tt.input, tt.backend, got, len(tt.wantWarnings))
+			}
+			for i, want := range tt.wantWarnings {
+				if !strings.Contains(got[i].Message, want) {
+					t.Errorf("warning[%d] = %q, want containing %q", i, got[i].Message, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package vaultmux
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ValueTransform converts secret values on their way to and from the
+// backend, e.g. to compress them or add an application-level encryption
+// envelope. Decode must invert Encode.
+type ValueTransform interface {
+	Encode(value []byte) ([]byte, error)
+	Decode(stored []byte) ([]byte, error)
+}
+
+// NameBoundTransform is implemented by ValueTransforms that bind each
+// value to the name of the item holding it, so a stored value copied to
+// another item fails to decode. ValueTransformer uses it in place of
+// Encode and Decode when the transform implements it.
+type NameBoundTransform interface {
+	EncodeNamed(name string, value []byte) ([]byte, error)
+	DecodeNamed(name string, stored []byte) ([]byte, error)
+}
+
+// Markers prefixing transformed values. The encoded bytes follow in
+// standard base64, since backends store text. Values written by a
+// NameBoundTransform carry the v2 marker.
+const (
+	valueTransformMarker      = "vaultmux:v1:"
+	namedValueTransformMarker = "vaultmux:v2:"
+)
+
+// ValueTransformer wraps a Backend and passes every secret value through a
+// ValueTransform: Encode when creating or updating, Decode when reading.
+// Stored values carry a marker, so values written before the transform was
+// enabled are read back unchanged, unless RequireMarker is set.
+//
+// Only one transform is identified by the marker. Switching transforms
+// requires rewriting existing values.
+//
+// CreateItemWithOptions and SetItem encode the value too. Other optional
+// interfaces are reached through Unwrap and see stored values: anything
+// read or written that way is not encoded or decoded.
+type ValueTransformer struct {
+	Backend
+
+	transform     ValueTransform
+	requireMarker bool
+}
+
+// ValueTransformerOption configures a ValueTransformer.
+type ValueTransformerOption func(*ValueTransformer)
+
+// RequireMarker makes a ValueTransformer reject stored values that were not
+// written through a transform, instead of returning them unchanged. Use it
+// once existing values have been rewritten, so that anyone able to write
+// to the backend can't plant a plaintext value that bypasses the
+// transform, such as an AESGCMTransform encrypting values at rest.
+func RequireMarker() ValueTransformerOption {
+	return func(t *ValueTransformer) { t.requireMarker = true }
+}
+
+// NewValueTransformer wraps b so that values pass through transform.
+func NewValueTransformer(b Backend, transform ValueTransform, opts ...ValueTransformerOption) *ValueTransformer {
+	t := &ValueTransformer{Backend: b, transform: transform}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// GetItem retrieves an item with its notes decoded.
+func (t *ValueTransformer) GetItem(ctx context.Context, name string, session Session) (*Item, error) {
+	item, err := t.Backend.GetItem(ctx, name, session)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.decodeItem(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetNotes retrieves the decoded notes of an item.
+func (t *ValueTransformer) GetNotes(ctx context.Context, name string, session Session) (string, error) {
+	stored, err := t.Backend.GetNotes(ctx, name, session)
+	if err != nil {
+		return "", err
+	}
+	return t.decode(name, stored)
+}
+
+// ListItems lists all items, decoding notes where the backend includes them.
+func (t *ValueTransformer) ListItems(ctx context.Context, session Session) ([]*Item, error) {
+	items, err := t.Backend.ListItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return t.decodeItems(items)
+}
+
+// ListItemsInLocation lists items in a location, decoding notes where the
+// backend includes them.
+func (t *ValueTransformer) ListItemsInLocation(ctx context.Context, locType, locValue string, session Session) ([]*Item, error) {
+	items, err := t.Backend.ListItemsInLocation(ctx, locType, locValue, session)
+	if err != nil {
+		return nil, err
+	}
+	return t.decodeItems(items)
+}
+
+// CreateItem creates an item with an encoded value.
+func (t *ValueTransformer) CreateItem(ctx context.Context, name, content string, session Session) error {
+	stored, err := t.encode(name, content)
+	if err != nil {
+		return err
+	}
+	return t.Backend.CreateItem(ctx, name, stored, session)
+}
+
+// UpdateItem updates an item with an encoded value.
+func (t *ValueTransformer) UpdateItem(ctx context.Context, name, content string, session Session) error {
+	stored, err := t.encode(name, content)
+	if err != nil {
+		return err
+	}
+	return t.Backend.UpdateItem(ctx, name, stored, session)
+}
+
+// CreateItemWithOptions creates an item with an encoded value. Options are
+// ignored if the wrapped backend is not an ItemCreator.
+func (t *ValueTransformer) CreateItemWithOptions(ctx context.Context, name, content string, session Session, opts ...CreateOption) error {
+	stored, err := t.encode(name, content)
+	if err != nil {
+		return err
+	}
+	return createItemWithOptions(ctx, t.Backend, name, stored, session, opts...)
+}
+
+// SetItem creates or updates an item with an encoded value.
+func (t *ValueTransformer) SetItem(ctx context.Context, name, content string, session Session) error {
+	stored, err := t.encode(name, content)
+	if err != nil {
+		return err
+	}
+	return SetItem(ctx, t.Backend, name, stored, session)
+}
+
+// Unwrap returns the wrapped backend.
+func (t *ValueTransformer) Unwrap() Backend {
+	return t.Backend
+}
+
+// encode returns the marked, text-safe stored form of content.
+func (t *ValueTransformer) encode(name, content string) (string, error) {
+	marker := valueTransformMarker
+	var encoded []byte
+	var err error
+	if named, ok := t.transform.(NameBoundTransform); ok {
+		marker = namedValueTransformMarker
+		encoded, err = named.EncodeNamed(name, []byte(content))
+	} else {
+		encoded, err = t.transform.Encode([]byte(content))
+	}
+	if err != nil {
+		return "", WrapError(t.Name(), "encode", name, err)
+	}
+	return marker + base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+// decode reverses encode. Values without a marker are returned as-is,
+// or rejected if requireMarker is set.
+func (t *ValueTransformer) decode(name, stored string) (string, error) {
+	named, isNamed := t.transform.(NameBoundTransform)
+	data, bound := strings.CutPrefix(stored, namedValueTransformMarker)
+	if !bound {
+		var ok bool
+		if data, ok = strings.CutPrefix(stored, valueTransformMarker); !ok {
+			if t.requireMarker {
+				return "", WrapError(t.Name(), "decode", name, fmt.Errorf("%w: value was not written through the value transform", ErrInvalidEncoding))
+			}
+			return stored, nil
+		}
+	}
+	encoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", WrapError(t.Name(), "decode", name, fmt.Errorf("%w: %w", ErrInvalidEncoding, err))
+	}
+
+	var value []byte
+	switch {
+	case bound && isNamed:
+		value, err = named.DecodeNamed(name, encoded)
+	case bound:
+		err = fmt.Errorf("%w: value is bound to its name but the transform is not a NameBoundTransform", ErrInvalidEncoding)
+	default:
+		value, err = t.transform.Decode(encoded)
+	}
+	if err != nil {
+		return "", WrapError(t.Name(), "decode", name, err)
+	}
+	return string(value), nil
+}
+
+func (t *ValueTransformer) decodeItem(item *Item) error {
+	notes, err := t.decode(item.Name, item.Notes)
+	if err != nil {
+		return err
+	}
+	item.Notes = notes
+	return nil
+}
+
+func (t *ValueTransformer) decodeItems(items []*Item) ([]*Item, error) {
+	for _, item := range items {
+		if err := t.decodeItem(item); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// GzipTransform compresses values with gzip. It suits large, repetitive
+// secrets such as certificate bundles or JSON documents.
+type GzipTransform struct{}
+
+// Encode compresses value.
+func (GzipTransform) Encode(value []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(value); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode decompresses stored.
+func (GzipTransform) Decode(stored []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(stored))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+	return io.ReadAll(zr)
+}
+
+// AESGCMTransform encrypts values with AES-GCM under an application key,
+// so the backend only ever stores ciphertext. Each value gets a random
+// nonce, stored in front of the ciphertext. Through a ValueTransformer the
+// item name is bound as additional data (see NameBoundTransform), so a
+// ciphertext moved to another item fails to decrypt.
+type AESGCMTransform struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMTransform creates an AES-GCM transform. key must be 16, 24 or
+// 32 bytes, selecting AES-128, AES-192 or AES-256.
+func NewAESGCMTransform(key []byte) (*AESGCMTransform, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESGCMTransform{aead: aead}, nil
+}
+
+// Encode encrypts value without binding it to a name.
+func (t *AESGCMTransform) Encode(value []byte) ([]byte, error) {
+	return t.seal(value, nil)
+}
+
+// Decode decrypts stored, as written by Encode. It fails if stored was
+// encrypted under a different key or has been modified.
+func (t *AESGCMTransform) Decode(stored []byte) ([]byte, error) {
+	return t.open(stored, nil)
+}
+
+// EncodeNamed encrypts value with name as additional data.
+func (t *AESGCMTransform) EncodeNamed(name string, value []byte) ([]byte, error) {
+	return t.seal(value, []byte(name))
+}
+
+// DecodeNamed decrypts stored, as written by EncodeNamed for the same name.
+// It also fails if stored was encrypted for another item.
+func (t *AESGCMTransform) DecodeNamed(name string, stored []byte) ([]byte, error) {
+	return t.open(stored, []byte(name))
+}
+
+func (t *AESGCMTransform) seal(value, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, t.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return t.aead.Seal(nonce, nonce, value, additionalData), nil
+}
+
+func (t *AESGCMTransform) open(stored, additionalData []byte) ([]byte, error) {
+	if len(stored) < t.aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := stored[:t.aead.NonceSize()], stored[t.aead.NonceSize():]
+	return t.aead.Open(nil, nonce, ciphertext, additionalData)
+}
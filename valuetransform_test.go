@@ -0,0 +1,165 @@
+package vaultmux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/vaultmux"
+	"github.com/blackwell-systems/vaultmux/mock"
+)
+
+func TestConfig_ValueTransform_Gzip(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	vaultmux.RegisterBackend("mock-value-transform", func(cfg vaultmux.Config) (vaultmux.Backend, error) {
+		return store, nil
+	})
+
+	backend, err := vaultmux.New(vaultmux.Config{
+		Backend:        "mock-value-transform",
+		ValueTransform: vaultmux.GzipTransform{},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	session, _ := backend.Authenticate(ctx)
+
+	value := strings.Repeat("0123456789abcdef", 100*1024/16)
+	if err := backend.CreateItem(ctx, "bundle", value, session); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	stored, _ := store.GetNotes(ctx, "bundle", session)
+	if len(stored) >= len(value)/10 {
+		t.Errorf("stored value is %d bytes, want well under %d", len(stored), len(value))
+	}
+
+	got, err := backend.GetNotes(ctx, "bundle", session)
+	if err != nil {
+		t.Fatalf("GetNotes() error = %v", err)
+	}
+	if got != value {
+		t.Errorf("GetNotes() returned %d bytes, not the original %d-byte value", len(got), len(value))
+	}
+
+	item, err := backend.GetItem(ctx, "bundle", session)
+	if err != nil || item.Notes != value {
+		t.Errorf("GetItem() notes differ from original value (err = %v)", err)
+	}
+}
+
+func TestValueTransformer_LegacyValue(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	store.SetItem("legacy", "plain value")
+	backend := vaultmux.NewValueTransformer(store, vaultmux.GzipTransform{})
+
+	if got, err := backend.GetNotes(ctx, "legacy", nil); err != nil || got != "plain value" {
+		t.Errorf("GetNotes(legacy) = %q, %v; want plain value", got, err)
+	}
+}
+
+func TestValueTransformer_AESGCM(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	key := bytes.Repeat([]byte{7}, 32)
+	aesgcm, err := vaultmux.NewAESGCMTransform(key)
+	if err != nil {
+		t.Fatalf("NewAESGCMTransform() error = %v", err)
+	}
+	backend := vaultmux.NewValueTransformer(store, aesgcm)
+
+	if err := backend.CreateItem(ctx, "db", "hunter2", nil); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if stored, _ := store.GetNotes(ctx, "db", nil); strings.Contains(stored, "hunter2") {
+		t.Errorf("stored value contains plaintext: %q", stored)
+	}
+	if got, err := backend.GetNotes(ctx, "db", nil); err != nil || got != "hunter2" {
+		t.Errorf("GetNotes() = %q, %v; want hunter2", got, err)
+	}
+
+	otherKey, _ := vaultmux.NewAESGCMTransform(bytes.Repeat([]byte{8}, 32))
+	if _, err := vaultmux.NewValueTransformer(store, otherKey).GetNotes(ctx, "db", nil); err == nil {
+		t.Error("GetNotes() with the wrong key succeeded, want error")
+	}
+
+	if _, err := vaultmux.NewAESGCMTransform([]byte("short")); err == nil {
+		t.Error("NewAESGCMTransform(5-byte key) succeeded, want error")
+	}
+}
+
+func TestValueTransformer_AESGCMBindsName(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	aesgcm, _ := vaultmux.NewAESGCMTransform(bytes.Repeat([]byte{7}, 32))
+	backend := vaultmux.NewValueTransformer(store, aesgcm)
+
+	if err := backend.CreateItem(ctx, "db", "hunter2", nil); err != nil {
+		t.Fatalf("CreateItem(db) error = %v", err)
+	}
+	if err := backend.CreateItem(ctx, "api-key", "k1", nil); err != nil {
+		t.Fatalf("CreateItem(api-key) error = %v", err)
+	}
+
+	// Someone with write access copies db's ciphertext over api-key
+	stored, _ := store.GetNotes(ctx, "db", nil)
+	store.SetItem("api-key", stored)
+	if got, err := backend.GetNotes(ctx, "api-key", nil); err == nil {
+		t.Errorf("GetNotes(api-key) with db's ciphertext = %q, want error", got)
+	}
+	if got, err := backend.GetNotes(ctx, "db", nil); err != nil || got != "hunter2" {
+		t.Errorf("GetNotes(db) = %q, %v; want hunter2", got, err)
+	}
+}
+
+func TestValueTransformer_RequireMarker(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	store.SetItem("planted", "plain value")
+	aesgcm, _ := vaultmux.NewAESGCMTransform(bytes.Repeat([]byte{7}, 32))
+	backend := vaultmux.NewValueTransformer(store, aesgcm, vaultmux.RequireMarker())
+
+	if _, err := backend.GetNotes(ctx, "planted", nil); !errors.Is(err, vaultmux.ErrInvalidEncoding) {
+		t.Errorf("GetNotes(planted) error = %v, want ErrInvalidEncoding", err)
+	}
+	if err := backend.CreateItem(ctx, "db", "hunter2", nil); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if got, err := backend.GetNotes(ctx, "db", nil); err != nil || got != "hunter2" {
+		t.Errorf("GetNotes(db) = %q, %v; want hunter2", got, err)
+	}
+}
+
+func TestValueTransformer_OptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	store := mock.New()
+	backend := vaultmux.NewValueTransformer(store, vaultmux.GzipTransform{})
+
+	if err := backend.CreateItemWithOptions(ctx, "cert", "-----BEGIN-----", nil, vaultmux.WithDescription("tls")); err != nil {
+		t.Fatalf("CreateItemWithOptions() error = %v", err)
+	}
+	stored, _ := store.GetItem(ctx, "cert", nil)
+	if !strings.HasPrefix(stored.Notes, "vaultmux:v1:") || stored.Description != "tls" {
+		t.Errorf("stored item = %+v, want encoded value with description", stored)
+	}
+
+	// Through a stacked wrapper, as New builds it
+	cached := vaultmux.NewCachingBackend(backend, time.Minute, nil)
+	if err := vaultmux.SetItem(ctx, cached, "cert", "-----END-----", nil); err != nil {
+		t.Fatalf("SetItem() error = %v", err)
+	}
+	if notes, _ := store.GetNotes(ctx, "cert", nil); !strings.HasPrefix(notes, "vaultmux:v1:") {
+		t.Errorf("SetItem() stored %q, want an encoded value", notes)
+	}
+	if got, _ := cached.GetNotes(ctx, "cert", nil); got != "-----END-----" {
+		t.Errorf("GetNotes() = %q, want -----END-----", got)
+	}
+	if backend.Unwrap() != vaultmux.Backend(store) {
+		t.Error("Unwrap() did not return the wrapped backend")
+	}
+}
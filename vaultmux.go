@@ -133,16 +133,41 @@ type LocationManager interface {
 	ListItemsInLocation(ctx context.Context, locType, locValue string, session Session) ([]*Item, error)
 }
 
+// ItemSetter is implemented by backends that can create-or-update an item in
+// a single call, typically because the provider API is itself an upsert.
+// Use the SetItem helper, which falls back to ItemExists plus CreateItem or
+// UpdateItem for backends that don't implement it.
+type ItemSetter interface {
+	// SetItem creates the item if missing, or updates it if present.
+	SetItem(ctx context.Context, name, content string, session Session) error
+}
+
+// PasswordManager is implemented by backends with login items that carry a
+// dedicated password field (Bitwarden, 1Password).
+// Callers can type-assert a Backend to PasswordManager to use it.
+type PasswordManager interface {
+	// GetPassword retrieves the password field of a login item.
+	GetPassword(ctx context.Context, name string, session Session) (string, error)
+
+	// SetPassword sets the password field of a login item, creating a
+	// login item if it doesn't exist.
+	SetPassword(ctx context.Context, name, password string, session Session) error
+}
+
 // Item represents a vault item.
 type Item struct {
-	ID       string            `json:"id"`
-	Name     string            `json:"name"`
-	Type     ItemType          `json:"type"`
-	Notes    string            `json:"notes,omitempty"`
-	Fields   map[string]string `json:"fields,omitempty"`
-	Location string            `json:"location,omitempty"` // Folder/vault
-	Created  time.Time         `json:"created,omitempty"`
-	Modified time.Time         `json:"modified,omitempty"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Type        ItemType          `json:"type"`
+	Notes       string            `json:"notes,omitempty"`
+	Description string            `json:"description,omitempty"` // Metadata, separate from the secret value
+	Fields      map[string]string `json:"fields,omitempty"`
+	Location    string            `json:"location,omitempty"` // Folder/vault
+	Created     time.Time         `json:"created,omitempty"`
+	Modified    time.Time         `json:"modified,omitempty"`
+	Version     string            `json:"version,omitempty"`  // Current version ID, for backends that version secrets
+	Disabled    bool              `json:"disabled,omitempty"` // Set by backends that can disable a secret without deleting it (Azure)
+	Tags        map[string]string `json:"tags,omitempty"`     // Key/value metadata (GCP labels), set by backends that list it
 }
 
 // ItemType indicates the type of vault item.
@@ -204,4 +229,24 @@ var (
 
 	// ErrNotSupported indicates the operation is not supported by this backend.
 	ErrNotSupported = errors.New("operation not supported")
+
+	// ErrAmbiguous indicates a name matches more than one item, e.g. the
+	// same title in two 1Password vaults. See AmbiguousError.
+	ErrAmbiguous = errors.New("ambiguous item name")
+
+	// ErrDisabled indicates the item exists but is disabled, so its value
+	// cannot be read until it is enabled again.
+	ErrDisabled = errors.New("item is disabled")
+
+	// ErrInvalidConfig indicates a missing or invalid backend option.
+	// See ConfigError.
+	ErrInvalidConfig = errors.New("invalid config")
+
+	// ErrListTruncated indicates a listing stopped at its result limit, so
+	// the items returned with it are incomplete. See Config.MaxListResults.
+	ErrListTruncated = errors.New("list truncated")
+
+	// ErrConflict indicates a conditional write or delete was rejected
+	// because the item changed since it was read.
+	ErrConflict = errors.New("item changed since it was read")
 )
@@ -0,0 +1,24 @@
+package vaultmux
+
+// Wrapper is implemented by backends that wrap another Backend, such as
+// those New stacks around a backend for Config.CacheTTL or
+// Config.NameTransform. Wrappers implement ItemCreator and ItemSetter
+// themselves; for other optional interfaces, type-assert the backend
+// returned by Unwrap or Innermost.
+type Wrapper interface {
+	// Unwrap returns the wrapped backend.
+	Unwrap() Backend
+}
+
+// Innermost returns the backend at the bottom of a stack of Wrappers, or b
+// itself if it wraps nothing. Calls made directly on the result bypass the
+// wrappers' behavior (caching, transforms, error masking).
+func Innermost(b Backend) Backend {
+	for {
+		w, ok := b.(Wrapper)
+		if !ok {
+			return b
+		}
+		b = w.Unwrap()
+	}
+}